@@ -0,0 +1,599 @@
+// Package acme is a small client for ACME (RFC 8555) certificate authorities
+// such as Let's Encrypt
+package acme
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	LetsEncrypt        = "https://acme-v02.api.letsencrypt.org/directory"
+	LetsEncryptStaging = "https://acme-staging-v02.api.letsencrypt.org/directory"
+)
+
+var (
+	PollInterval = 2 * time.Second
+	PollTimeout  = 2 * time.Minute
+)
+
+// Client talks to one ACME directory on behalf of one account key
+type Client struct {
+	Directory  string
+	Key        *ecdsa.PrivateKey
+	AccountURL string
+	HTTPClient *http.Client
+
+	dir   *directory
+	nonce string
+}
+
+type directory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type Identifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type Order struct {
+	URL            string       `json:"-"`
+	Status         string       `json:"status"`
+	Identifiers    []Identifier `json:"identifiers"`
+	Authorizations []string     `json:"authorizations"`
+	Finalize       string       `json:"finalize"`
+	Certificate    string       `json:"certificate"`
+	Error          *Problem     `json:"error"`
+}
+
+type Authorization struct {
+	Status     string      `json:"status"`
+	Identifier Identifier  `json:"identifier"`
+	Challenges []Challenge `json:"challenges"`
+	Wildcard   bool        `json:"wildcard"`
+}
+
+type Challenge struct {
+	Type   string   `json:"type"`
+	URL    string   `json:"url"`
+	Token  string   `json:"token"`
+	Status string   `json:"status"`
+	Error  *Problem `json:"error"`
+}
+
+// Problem is an error document returned by the authority
+type Problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func (p *Problem) Error() string {
+	return fmt.Sprintf("acme: %s: %s", strings.TrimPrefix(p.Type, "urn:ietf:params:acme:error:"), p.Detail)
+}
+
+// Solver answers one type of challenge for the domains of an order
+type Solver interface {
+	Type() string
+	Present(domain, token, keyAuth string) error
+	CleanUp(domain, token, keyAuth string) error
+}
+
+func NewClient(directory string, key *ecdsa.PrivateKey) *Client {
+	return &Client{
+		Directory:  directory,
+		Key:        key,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// GenerateKey creates a new account key
+func GenerateKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// EncodeKey returns an account key as PEM
+func EncodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+// DecodeKey reads an account key written by EncodeKey
+func DecodeKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid account key")
+	}
+
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+// Register creates an account for the key, or finds the existing one, and
+// agrees to the terms of service of the authority
+func (c *Client) Register(email string) error {
+	dir, err := c.directory()
+	if err != nil {
+		return err
+	}
+
+	req := map[string]interface{}{
+		"termsOfServiceAgreed": true,
+	}
+
+	if email != "" {
+		req["contact"] = []string{"mailto:" + email}
+	}
+
+	res, _, err := c.post(dir.NewAccount, req)
+	if err != nil {
+		return err
+	}
+
+	c.AccountURL = res.Header.Get("Location")
+
+	if c.AccountURL == "" {
+		return fmt.Errorf("acme: no account url returned")
+	}
+
+	return nil
+}
+
+// NewOrder asks for a certificate covering domains
+func (c *Client) NewOrder(domains []string) (*Order, error) {
+	dir, err := c.directory()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []Identifier{}
+
+	for _, d := range domains {
+		ids = append(ids, Identifier{Type: "dns", Value: d})
+	}
+
+	res, body, err := c.post(dir.NewOrder, map[string]interface{}{"identifiers": ids})
+	if err != nil {
+		return nil, err
+	}
+
+	var o Order
+
+	if err := json.Unmarshal(body, &o); err != nil {
+		return nil, err
+	}
+
+	o.URL = res.Header.Get("Location")
+
+	return &o, nil
+}
+
+func (c *Client) Order(url string) (*Order, error) {
+	_, body, err := c.post(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var o Order
+
+	if err := json.Unmarshal(body, &o); err != nil {
+		return nil, err
+	}
+
+	o.URL = url
+
+	return &o, nil
+}
+
+func (c *Client) Authorization(url string) (*Authorization, error) {
+	_, body, err := c.post(url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var a Authorization
+
+	if err := json.Unmarshal(body, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// Accept tells the authority a challenge is ready to be checked
+func (c *Client) Accept(ch Challenge) error {
+	_, _, err := c.post(ch.URL, struct{}{})
+	return err
+}
+
+// WaitAuthorization polls an authorization until it is valid or has failed
+func (c *Client) WaitAuthorization(url string) (*Authorization, error) {
+	deadline := time.Now().Add(PollTimeout)
+
+	for {
+		a, err := c.Authorization(url)
+		if err != nil {
+			return nil, err
+		}
+
+		switch a.Status {
+		case "valid":
+			return a, nil
+		case "pending", "processing":
+		default:
+			for _, ch := range a.Challenges {
+				if ch.Error != nil {
+					return nil, fmt.Errorf("%s: %s", a.Identifier.Value, ch.Error.Error())
+				}
+			}
+
+			return nil, fmt.Errorf("acme: authorization for %s is %s", a.Identifier.Value, a.Status)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("acme: timeout waiting for authorization of %s", a.Identifier.Value)
+		}
+
+		time.Sleep(PollInterval)
+	}
+}
+
+// Finalize submits a certificate request for an order and waits for it to be issued
+func (c *Client) Finalize(o *Order, csr []byte) (*Order, error) {
+	_, _, err := c.post(o.Finalize, map[string]string{"csr": encode(csr)})
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(PollTimeout)
+
+	for {
+		o, err = c.Order(o.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		switch o.Status {
+		case "valid":
+			return o, nil
+		case "pending", "ready", "processing":
+		default:
+			if o.Error != nil {
+				return nil, o.Error
+			}
+
+			return nil, fmt.Errorf("acme: order is %s", o.Status)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("acme: timeout waiting for certificate")
+		}
+
+		time.Sleep(PollInterval)
+	}
+}
+
+// Certificate downloads an issued certificate chain as PEM
+func (c *Client) Certificate(url string) ([]byte, error) {
+	_, body, err := c.post(url, nil)
+	return body, err
+}
+
+// KeyAuthorization is the response a challenge token expects from this account
+func (c *Client) KeyAuthorization(token string) string {
+	sum := sha256.Sum256([]byte(c.jwk()))
+	return token + "." + encode(sum[:])
+}
+
+// DNS01Value is the TXT record value for a dns-01 key authorization
+func DNS01Value(keyAuth string) string {
+	sum := sha256.Sum256([]byte(keyAuth))
+	return encode(sum[:])
+}
+
+// Obtain runs a complete order for domains, answering challenges with solver. It
+// returns the certificate, its intermediate chain and a new private key, all as PEM.
+func (c *Client) Obtain(domains []string, solver Solver) (cert, chain, key []byte, err error) {
+	o, err := c.NewOrder(domains)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, url := range o.Authorizations {
+		a, err := c.Authorization(url)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if a.Status == "valid" {
+			continue
+		}
+
+		var ch *Challenge
+
+		for i := range a.Challenges {
+			if a.Challenges[i].Type == solver.Type() {
+				ch = &a.Challenges[i]
+			}
+		}
+
+		if ch == nil {
+			return nil, nil, nil, fmt.Errorf("acme: no %s challenge offered for %s", solver.Type(), a.Identifier.Value)
+		}
+
+		domain := a.Identifier.Value
+
+		if a.Wildcard {
+			domain = "*." + domain
+		}
+
+		keyAuth := c.KeyAuthorization(ch.Token)
+
+		if err := solver.Present(domain, ch.Token, keyAuth); err != nil {
+			return nil, nil, nil, err
+		}
+
+		err = c.Accept(*ch)
+
+		if err == nil {
+			_, err = c.WaitAuthorization(url)
+		}
+
+		solver.CleanUp(domain, ch.Token, keyAuth)
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	rkey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: strings.TrimPrefix(domains[0], "*.")},
+		DNSNames: domains,
+	}, rkey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	o, err = c.Finalize(o, csr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	bundle, err := c.Certificate(o.Certificate)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cert, chain = splitChain(bundle)
+
+	if cert == nil {
+		return nil, nil, nil, fmt.Errorf("acme: no certificate returned")
+	}
+
+	key = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rkey)})
+
+	return cert, chain, key, nil
+}
+
+// splitChain separates the leaf certificate from its intermediates
+func splitChain(bundle []byte) ([]byte, []byte) {
+	var cert, chain []byte
+
+	for {
+		block, rest := pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+
+		if cert == nil {
+			cert = pem.EncodeToMemory(block)
+		} else {
+			chain = append(chain, pem.EncodeToMemory(block)...)
+		}
+
+		bundle = rest
+	}
+
+	return cert, chain
+}
+
+func (c *Client) directory() (*directory, error) {
+	if c.dir != nil {
+		return c.dir, nil
+	}
+
+	res, err := c.HTTPClient.Get(c.Directory)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("acme: could not read directory %s: %s", c.Directory, res.Status)
+	}
+
+	var dir directory
+
+	if err := json.NewDecoder(res.Body).Decode(&dir); err != nil {
+		return nil, err
+	}
+
+	c.dir = &dir
+
+	return c.dir, nil
+}
+
+func (c *Client) getNonce() (string, error) {
+	if n := c.nonce; n != "" {
+		c.nonce = ""
+		return n, nil
+	}
+
+	dir, err := c.directory()
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.HTTPClient.Head(dir.NewNonce)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+
+	n := res.Header.Get("Replay-Nonce")
+
+	if n == "" {
+		return "", fmt.Errorf("acme: no nonce returned")
+	}
+
+	return n, nil
+}
+
+// post sends a signed request. A nil payload makes a POST-as-GET.
+func (c *Client) post(url string, payload interface{}) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		res, body, err := c.postOnce(url, payload)
+
+		if p, ok := err.(*Problem); ok && p.Type == "urn:ietf:params:acme:error:badNonce" && attempt < 2 {
+			continue
+		}
+
+		return res, body, err
+	}
+}
+
+func (c *Client) postOnce(url string, payload interface{}) (*http.Response, []byte, error) {
+	nonce, err := c.getNonce()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := c.sign(url, nonce, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/jose+json")
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	c.nonce = res.Header.Get("Replay-Nonce")
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.StatusCode >= 400 {
+		var p Problem
+
+		if err := json.Unmarshal(body, &p); err != nil || p.Type == "" {
+			return nil, nil, fmt.Errorf("acme: %s: %s", res.Status, strings.TrimSpace(string(body)))
+		}
+
+		return nil, nil, &p
+	}
+
+	return res, body, nil
+}
+
+// sign wraps a payload in a flattened JWS signed with ES256
+func (c *Client) sign(url, nonce string, payload interface{}) ([]byte, error) {
+	protected := map[string]interface{}{
+		"alg":   "ES256",
+		"nonce": nonce,
+		"url":   url,
+	}
+
+	if c.AccountURL != "" {
+		protected["kid"] = c.AccountURL
+	} else {
+		protected["jwk"] = json.RawMessage(c.jwk())
+	}
+
+	ph, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+
+	pl := ""
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		pl = encode(data)
+	}
+
+	input := encode(ph) + "." + pl
+	sum := sha256.Sum256([]byte(input))
+
+	r, s, err := ecdsa.Sign(rand.Reader, c.Key, sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	sig := append(pad(r, 32), pad(s, 32)...)
+
+	return json.Marshal(map[string]string{
+		"protected": encode(ph),
+		"payload":   pl,
+		"signature": encode(sig),
+	})
+}
+
+// jwk is the public account key in the canonical form used for thumbprints
+func (c *Client) jwk() string {
+	return fmt.Sprintf(`{"crv":"P-256","kty":"EC","x":"%s","y":"%s"}`, encode(pad(c.Key.X, 32)), encode(pad(c.Key.Y, 32)))
+}
+
+func pad(n *big.Int, size int) []byte {
+	b := n.Bytes()
+
+	if len(b) >= size {
+		return b
+	}
+
+	return append(make([]byte, size-len(b)), b...)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
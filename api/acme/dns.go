@@ -0,0 +1,135 @@
+package acme
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DNSProvider creates and removes the TXT records that answer dns-01 challenges
+type DNSProvider interface {
+	Present(fqdn, value string) error
+	CleanUp(fqdn, value string) error
+}
+
+type DNSProviderFunc func(config map[string]string) (DNSProvider, error)
+
+var dnsProviders = map[string]DNSProviderFunc{
+	"webhook": newWebhookProvider,
+}
+
+// RegisterDNSProvider makes a DNS provider available by name
+func RegisterDNSProvider(name string, fn DNSProviderFunc) {
+	dnsProviders[name] = fn
+}
+
+// NewDNSProvider configures a registered DNS provider
+func NewDNSProvider(name string, config map[string]string) (DNSProvider, error) {
+	fn, ok := dnsProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown dns provider: %s, must be one of %s", name, strings.Join(DNSProviders(), ", "))
+	}
+
+	return fn(config)
+}
+
+// DNSProviders returns the names of the registered DNS providers
+func DNSProviders() []string {
+	names := []string{}
+
+	for name := range dnsProviders {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// DNSSolver answers dns-01 challenges through a DNS provider, waiting for the
+// record to propagate before the authority is asked to check it
+type DNSSolver struct {
+	Provider    DNSProvider
+	Propagation time.Duration
+}
+
+func (s DNSSolver) Type() string {
+	return "dns-01"
+}
+
+func (s DNSSolver) Present(domain, token, keyAuth string) error {
+	if err := s.Provider.Present(challengeRecord(domain), DNS01Value(keyAuth)); err != nil {
+		return err
+	}
+
+	time.Sleep(s.Propagation)
+
+	return nil
+}
+
+func (s DNSSolver) CleanUp(domain, token, keyAuth string) error {
+	return s.Provider.CleanUp(challengeRecord(domain), DNS01Value(keyAuth))
+}
+
+func challengeRecord(domain string) string {
+	return "_acme-challenge." + strings.TrimPrefix(domain, "*.")
+}
+
+// webhookProvider asks an https endpoint to manage the records. It posts
+// {"action":"present|cleanup","fqdn":...,"value":...} and expects a 2xx response.
+type webhookProvider struct {
+	url   string
+	token string
+}
+
+var webhookClient = &http.Client{Timeout: 30 * time.Second}
+
+func newWebhookProvider(config map[string]string) (DNSProvider, error) {
+	if config["url"] == "" {
+		return nil, fmt.Errorf("webhook dns provider needs a url")
+	}
+
+	return &webhookProvider{url: config["url"], token: config["token"]}, nil
+}
+
+func (p *webhookProvider) Present(fqdn, value string) error {
+	return p.send("present", fqdn, value)
+}
+
+func (p *webhookProvider) CleanUp(fqdn, value string) error {
+	return p.send("cleanup", fqdn, value)
+}
+
+func (p *webhookProvider) send(action, fqdn, value string) error {
+	data, err := json.Marshal(map[string]string{"action": action, "fqdn": fqdn, "value": value})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", p.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	res, err := webhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("dns webhook %s %s: %s", action, fqdn, res.Status)
+	}
+
+	return nil
+}
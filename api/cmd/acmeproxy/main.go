@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// acmeproxy sits between an app balancer and the app on port 80. Requests for
+// /.well-known/acme-challenge/ are answered by the rack, which keeps the
+// http-01 challenges, and every other connection is passed to the app as is.
+
+const challengePath = "/.well-known/acme-challenge/"
+
+var (
+	backend string
+	rack    string
+)
+
+func main() {
+	if len(os.Args) != 4 {
+		fmt.Println("usage: acmeproxy <port> <backend> <rack>")
+		os.Exit(1)
+	}
+
+	backend = os.Args[2]
+	rack = os.Args[3]
+
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%s", os.Args[1]))
+	if err != nil {
+		fmt.Printf("ns=acmeproxy at=listen err=%q\n", err)
+		os.Exit(1)
+	}
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			fmt.Printf("ns=acmeproxy at=accept err=%q\n", err)
+			continue
+		}
+
+		go handle(conn)
+	}
+}
+
+func handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+
+	// read the request line, after the proxy protocol header if there is one
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	head, line := sniff(r)
+
+	conn.SetReadDeadline(time.Time{})
+
+	if token, ok := challengeToken(line); ok {
+		if err := challenge(conn, r, token); err != nil {
+			fmt.Printf("ns=acmeproxy at=challenge token=%q err=%q\n", token, err)
+		}
+		return
+	}
+
+	splice(conn, io.MultiReader(bytes.NewReader(append(head, line...)), r))
+}
+
+// sniff reads the proxy protocol header and the first line after it
+func sniff(r *bufio.Reader) ([]byte, []byte) {
+	line, _ := r.ReadSlice('\n')
+	line = append([]byte{}, line...)
+
+	if !bytes.HasPrefix(line, []byte("PROXY ")) {
+		return nil, line
+	}
+
+	next, _ := r.ReadSlice('\n')
+
+	return line, append([]byte{}, next...)
+}
+
+// challengeToken returns the token of a GET request line for an acme challenge
+func challengeToken(line []byte) (string, bool) {
+	parts := strings.Fields(string(line))
+
+	if len(parts) != 3 || parts[0] != "GET" || !strings.HasPrefix(parts[1], challengePath) {
+		return "", false
+	}
+
+	token := strings.TrimPrefix(parts[1], challengePath)
+
+	if token == "" || strings.Contains(token, "/") {
+		return "", false
+	}
+
+	return token, true
+}
+
+// challenge answers an acme challenge with the key authorization the rack holds
+func challenge(conn net.Conn, r *bufio.Reader, token string) error {
+	// read the rest of the request so the connection closes cleanly
+	for {
+		line, err := r.ReadSlice('\n')
+		if err != nil {
+			return err
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			break
+		}
+	}
+
+	res, err := http.Get(fmt.Sprintf("http://%s%s%s", rack, challengePath, token))
+	if err != nil {
+		fmt.Fprintf(conn, "HTTP/1.1 502 Bad Gateway\r\nContent-Length: 0\r\nConnection: close\r\n\r\n")
+		return err
+	}
+
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(conn, "HTTP/1.1 %s\r\nContent-Type: text/plain\r\nContent-Length: %d\r\nConnection: close\r\n\r\n", res.Status, len(data))
+
+	_, err = conn.Write(data)
+
+	return err
+}
+
+// splice passes a connection through to the app
+func splice(conn net.Conn, r io.Reader) {
+	app, err := net.Dial("tcp", backend)
+	if err != nil {
+		fmt.Printf("ns=acmeproxy at=dial backend=%q err=%q\n", backend, err)
+		return
+	}
+
+	defer app.Close()
+
+	done := make(chan bool, 2)
+
+	go func() {
+		io.Copy(app, r)
+		if c, ok := app.(*net.TCPConn); ok {
+			c.CloseWrite()
+		}
+		done <- true
+	}()
+
+	go func() {
+		io.Copy(conn, app)
+		if c, ok := conn.(*net.TCPConn); ok {
+			c.CloseWrite()
+		}
+		done <- true
+	}()
+
+	<-done
+	<-done
+}
@@ -7,9 +7,17 @@ import (
 )
 
 func main() {
+	go workers.StartAlerts()
 	go workers.StartAutoscale()
+	go workers.StartCertificates()
 	go workers.StartCluster()
+	go workers.StartCrashes()
+	go workers.StartDrains()
 	go workers.StartHeartbeat()
+	go workers.StartMetrics()
+	go workers.StartReviews()
+	go workers.StartRuns()
+	go workers.StartServiceRotations()
 	go workers.StartServicesCapacity()
 
 	for {
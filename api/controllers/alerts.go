@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/convox/rack/api/httperr"
+	"github.com/convox/rack/api/models"
+	"github.com/gorilla/mux"
+)
+
+func AlertList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	alerts, err := models.ListAlerts()
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, alerts)
+}
+
+func AlertCreate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	a := models.Alert{
+		App:     GetForm(r, "app"),
+		Metric:  GetForm(r, "metric"),
+		Process: GetForm(r, "process"),
+		Window:  5 * time.Minute,
+	}
+
+	if s := GetForm(r, "threshold"); s != "" {
+		t, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return httperr.Errorf(403, "invalid alert threshold: %s", s)
+		}
+
+		a.Threshold = t
+	}
+
+	if s := GetForm(r, "window"); s != "" {
+		w, err := time.ParseDuration(s)
+		if err != nil {
+			return httperr.Errorf(403, "invalid alert window: %s", s)
+		}
+
+		a.Window = w
+	}
+
+	if herr := optionalApp(a.App); herr != nil {
+		return herr
+	}
+
+	alert, err := models.CreateAlert(a)
+	if herr := alertError(err); herr != nil {
+		return herr
+	}
+
+	return RenderJson(rw, alert)
+}
+
+func AlertDelete(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	a, err := models.DeleteAlert(mux.Vars(r)["alert"])
+	if herr := alertError(err); herr != nil {
+		return herr
+	}
+
+	return RenderJson(rw, a)
+}
+
+// AlertSilence suppresses notifications for an alert for a duration. A zero duration removes the silence.
+func AlertSilence(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	d, err := time.ParseDuration(GetForm(r, "duration"))
+	if err != nil || d < 0 {
+		return httperr.Errorf(403, "invalid silence duration: %s", GetForm(r, "duration"))
+	}
+
+	until := time.Time{}
+
+	if d > 0 {
+		until = time.Now().UTC().Add(d)
+	}
+
+	a, err := models.SilenceAlert(mux.Vars(r)["alert"], until)
+	if herr := alertError(err); herr != nil {
+		return herr
+	}
+
+	return RenderJson(rw, a)
+}
+
+func alertError(err error) *httperr.Error {
+	switch {
+	case err == nil:
+		return nil
+	case strings.HasPrefix(err.Error(), "no such alert"):
+		return httperr.New(404, err)
+	case strings.HasPrefix(err.Error(), "invalid alert"):
+		return httperr.New(403, err)
+	}
+
+	return httperr.Server(err)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -61,6 +62,30 @@ func AppCreate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 
 	// If unbound check fails this will result in a bound app.
 	app := &models.App{Name: name}
+
+	if branch := r.FormValue("review-branch"); branch != "" {
+		source := r.FormValue("review-source")
+
+		if _, err := models.GetApp(source); err != nil {
+			return httperr.Errorf(404, "no such app: %s", source)
+		}
+
+		var ttl time.Duration
+
+		if t := r.FormValue("review-ttl"); t != "" {
+			d, err := time.ParseDuration(t)
+			if err != nil {
+				return httperr.Errorf(403, "invalid ttl: %s", t)
+			}
+
+			ttl = d
+		}
+
+		// bound apps must carry a Name tag
+		app.Tags = models.ReviewTags(source, branch, ttl)
+		app.Tags["Name"] = name
+	}
+
 	err := app.Create()
 
 	if awsError(err) == "AlreadyExistsException" {
@@ -112,35 +137,173 @@ func AppDelete(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 	return RenderSuccess(rw)
 }
 
+// AppTemplate renders the stack template and parameters the current release of an
+// app would have with the given manifest
+func AppTemplate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+
+	_, err := models.GetApp(app)
+
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	manifest := GetForm(r, "manifest")
+
+	if manifest == "" {
+		return httperr.Errorf(403, "must specify a manifest")
+	}
+
+	t, err := models.ManifestTemplate(app, manifest, GetForm(r, "diff") == "true")
+
+	if err != nil && (strings.HasPrefix(err.Error(), "invalid manifest") || strings.HasPrefix(err.Error(), "app has no releases")) {
+		return httperr.Errorf(403, "%s", err)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, t)
+}
+
 func AppLogs(ws *websocket.Conn) *httperr.Error {
 	app := mux.Vars(ws.Request())["app"]
 	header := ws.Request().Header
 
+	opts, herr := logStreamOptions(header.Get)
+	if herr != nil {
+		return herr
+	}
+
+	opts.Follow = header.Get("Follow") != "false"
+
+	err := provider.LogStream(app, ws, opts)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "write: broken pipe") {
+			return nil
+		}
+		return httperr.Server(err)
+	}
+	return nil
+}
+
+func AppLogsQuery(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+	query := r.URL.Query()
+
+	opts, herr := logStreamOptions(func(name string) string {
+		return query.Get(strings.ToLower(name))
+	})
+	if herr != nil {
+		return herr
+	}
+
+	records, err := provider.LogQuery(app, opts)
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, records)
+}
+
+// AppMetrics returns process metrics for an app, one sample per process every step
+func AppMetrics(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+	query := r.URL.Query()
+
 	var err error
 
-	follow := true
-	if header.Get("Follow") == "false" {
-		follow = false
+	opts := structs.MetricsOptions{
+		End:     time.Now().UTC(),
+		Process: query.Get("process"),
+		Step:    1 * time.Minute,
 	}
 
-	since := 2 * time.Minute
-	if s := header.Get("Since"); s != "" {
-		since, err = time.ParseDuration(s)
+	if s := query.Get("to"); s != "" {
+		opts.End, err = time.Parse(time.RFC3339, s)
 		if err != nil {
-			return httperr.Errorf(403, "Invalid duration %s", s)
+			return httperr.Errorf(403, "Invalid to time %s", s)
 		}
 	}
 
-	err = provider.LogStream(app, ws, structs.LogStreamOptions{
-		Filter: header.Get("Filter"),
-		Follow: follow,
-		Since:  since,
-	})
-	if err != nil {
-		if strings.HasSuffix(err.Error(), "write: broken pipe") {
-			return nil
+	opts.Start = opts.End.Add(-1 * time.Hour)
+
+	if s := query.Get("from"); s != "" {
+		opts.Start, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return httperr.Errorf(403, "Invalid from time %s", s)
+		}
+	}
+
+	if s := query.Get("step"); s != "" {
+		opts.Step, err = time.ParseDuration(s)
+		if err != nil || opts.Step <= 0 {
+			return httperr.Errorf(403, "Invalid step %s", s)
 		}
+	}
+
+	if !opts.Start.Before(opts.End) {
+		return httperr.Errorf(403, "from must be before to")
+	}
+
+	samples, err := provider.MetricsGet(app, opts)
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+	if err != nil {
 		return httperr.Server(err)
 	}
-	return nil
+
+	return RenderJson(rw, samples)
+}
+
+// logStreamOptions reads log options from websocket headers or query parameters
+func logStreamOptions(get func(string) string) (structs.LogStreamOptions, *httperr.Error) {
+	var err error
+
+	opts := structs.LogStreamOptions{
+		Filter:   get("Filter"),
+		Instance: get("Instance"),
+		Process:  get("Process"),
+		Release:  get("Release"),
+		Since:    2 * time.Minute,
+	}
+
+	if s := get("Since"); s != "" {
+		opts.Since, err = time.ParseDuration(s)
+		if err != nil {
+			return opts, httperr.Errorf(403, "Invalid duration %s", s)
+		}
+	}
+
+	if s := get("Start"); s != "" {
+		opts.Start, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return opts, httperr.Errorf(403, "Invalid start time %s", s)
+		}
+	}
+
+	if s := get("End"); s != "" {
+		opts.End, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			return opts, httperr.Errorf(403, "Invalid end time %s", s)
+		}
+	}
+
+	if s := get("Limit"); s != "" {
+		opts.Limit, err = strconv.Atoi(s)
+		if err != nil || opts.Limit < 0 {
+			return opts, httperr.Errorf(403, "Invalid limit %s", s)
+		}
+	}
+
+	return opts, nil
 }
@@ -5,9 +5,13 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/convox/rack/api/controllers"
 	"github.com/convox/rack/api/models"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+	"github.com/convox/rack/client"
 	"github.com/convox/rack/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -175,3 +179,98 @@ func TestAppDeleteWithAppNotFound(t *testing.T) {
 func TestAppLogs(t *testing.T) {
 
 }
+
+func TestAppLogsQuery(t *testing.T) {
+	start := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	testProvider := &provider.TestProviderRunner{
+		LogRecords: structs.LogRecords{
+			structs.NewLogRecord("i-1234", "web:RABCDEFGHI/0123456789ab GET / 200", start),
+		},
+	}
+	provider.CurrentProvider = testProvider
+
+	defer func() {
+		provider.CurrentProvider = new(provider.TestProviderRunner)
+	}()
+
+	testProvider.On("LogQuery", "myapp", structs.LogStreamOptions{
+		Limit:   10,
+		Process: "web",
+		Since:   2 * time.Minute,
+		Start:   start,
+	}).Return(testProvider.LogRecords, nil)
+
+	body := test.HTTPBody("GET", "http://convox/apps/myapp/logs/query", url.Values{
+		"limit":   []string{"10"},
+		"process": []string{"web"},
+		"start":   []string{"2016-09-01T10:00:00Z"},
+	})
+
+	var records client.LogRecords
+
+	err := json.Unmarshal([]byte(body), &records)
+
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(records)) {
+		assert.Equal(t, "i-1234", records[0].Instance)
+		assert.Equal(t, "web", records[0].Process)
+		assert.Equal(t, "RABCDEFGHI", records[0].Release)
+		assert.Equal(t, "0123456789ab", records[0].Container)
+		assert.Equal(t, "GET / 200", records[0].Message)
+	}
+
+	testProvider.AssertExpectations(t)
+}
+
+func TestAppLogsQueryInvalidStart(t *testing.T) {
+	body := test.HTTPBody("GET", "http://convox/apps/myapp/logs/query", url.Values{"start": []string{"yesterday"}})
+
+	assert.Equal(t, `{"error":"Invalid start time yesterday"}`, body)
+}
+
+func TestAppMetrics(t *testing.T) {
+	from := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	testProvider := &provider.TestProviderRunner{
+		Metrics: structs.MetricSamples{
+			{Process: "web", Timestamp: from, Count: 2, Cpu: 12.5, Memory: 0.25, Restarts: 1},
+		},
+	}
+	provider.CurrentProvider = testProvider
+
+	defer func() {
+		provider.CurrentProvider = new(provider.TestProviderRunner)
+	}()
+
+	testProvider.On("MetricsGet", "myapp", structs.MetricsOptions{
+		End:     from.Add(1 * time.Hour),
+		Process: "web",
+		Start:   from,
+		Step:    5 * time.Minute,
+	}).Return(testProvider.Metrics, nil)
+
+	body := test.HTTPBody("GET", "http://convox/apps/myapp/metrics", url.Values{
+		"from":    []string{"2016-09-01T10:00:00Z"},
+		"process": []string{"web"},
+		"step":    []string{"5m"},
+		"to":      []string{"2016-09-01T11:00:00Z"},
+	})
+
+	var samples client.MetricSamples
+
+	err := json.Unmarshal([]byte(body), &samples)
+
+	if assert.Nil(t, err) && assert.Equal(t, 1, len(samples)) {
+		assert.Equal(t, "web", samples[0].Process)
+		assert.Equal(t, 12.5, samples[0].Cpu)
+		assert.Equal(t, 1.0, samples[0].Restarts)
+	}
+
+	testProvider.AssertExpectations(t)
+}
+
+func TestAppMetricsInvalidStep(t *testing.T) {
+	body := test.HTTPBody("GET", "http://convox/apps/myapp/metrics", url.Values{"step": []string{"often"}})
+
+	assert.Equal(t, `{"error":"Invalid step often"}`, body)
+}
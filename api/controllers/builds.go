@@ -1,10 +1,15 @@
 package controllers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -192,6 +197,103 @@ func BuildCopy(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 	return RenderJson(rw, b)
 }
 
+func BuildExport(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	vars := mux.Vars(r)
+	app := vars["app"]
+	build := vars["build"]
+
+	a, err := models.GetApp(app)
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	b, err := provider.BuildGet(app, build)
+	if err != nil && strings.HasPrefix(err.Error(), "no such build") {
+		return httperr.New(404, err)
+	}
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	if b.Status != "complete" {
+		return httperr.Errorf(403, "can not export build with status: %s", b.Status)
+	}
+
+	// Log into registry that we will pull from
+	_, err = models.AppDockerLogin(*a)
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	// write the artifact to disk first so a failed export is an error response and
+	// not a truncated download
+	fd, err := ioutil.TempFile("", "export")
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	defer os.Remove(fd.Name())
+	defer fd.Close()
+
+	hash := sha256.New()
+
+	err = provider.BuildExport(app, build, io.MultiWriter(fd, hash))
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	size, err := fd.Seek(0, 1)
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	if _, err := fd.Seek(0, 0); err != nil {
+		return httperr.Server(err)
+	}
+
+	rw.Header().Set("Checksum", hex.EncodeToString(hash.Sum(nil)))
+	rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.tgz", build))
+	rw.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	rw.Header().Set("Content-Type", "application/gzip")
+
+	if _, err := io.Copy(rw, fd); err != nil {
+		fmt.Printf("ns=kernel at=build.export app=%s build=%s err=%q\n", app, build, err)
+	}
+
+	return nil
+}
+
+func BuildImport(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+
+	a, err := models.GetApp(app)
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	// Log into registry that we will push to
+	_, err = models.AppDockerLogin(*a)
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	b, err := provider.BuildImport(app, r.Body)
+	if err != nil && strings.HasPrefix(err.Error(), "invalid build artifact") {
+		return httperr.New(403, err)
+	}
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, b)
+}
+
 func BuildLogs(ws *websocket.Conn) *httperr.Error {
 	vars := mux.Vars(ws.Request())
 
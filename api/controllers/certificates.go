@@ -2,10 +2,11 @@ package controllers
 
 import (
 	"net/http"
-	"sort"
 	"strings"
 
+	"github.com/convox/rack/api/acme"
 	"github.com/convox/rack/api/httperr"
+	"github.com/convox/rack/api/models"
 	"github.com/convox/rack/api/provider"
 	"github.com/gorilla/mux"
 )
@@ -48,13 +49,101 @@ func CertificateGenerate(rw http.ResponseWriter, r *http.Request) *httperr.Error
 }
 
 func CertificateList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
-	certs, err := provider.CertificateList()
+	certs, err := models.ListCertificates()
 
 	if err != nil {
 		return httperr.Server(err)
 	}
 
-	sort.Sort(certs)
+	return RenderJson(rw, certs)
+}
+
+func CertificateReplace(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	id := GetForm(r, "certificate")
+
+	if id == "" {
+		return httperr.Errorf(403, "must specify a certificate")
+	}
+
+	balancers, err := models.ReplaceCertificate(mux.Vars(r)["id"], id)
+
+	switch {
+	case err == nil:
+	case strings.HasPrefix(err.Error(), "no balancers use"):
+		return httperr.New(404, err)
+	case strings.HasPrefix(err.Error(), "invalid replacement"), strings.HasPrefix(err.Error(), "can not update"):
+		return httperr.New(403, err)
+	default:
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, balancers)
+}
+
+func CertificateAcmeCreate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	domains := strings.Split(GetForm(r, "domains"), ",")
+	directory := GetForm(r, "directory")
+
+	if directory == "" && GetForm(r, "staging") == "true" {
+		directory = acme.LetsEncryptStaging
+	}
+
+	config := map[string]string{}
+
+	for _, line := range strings.Split(GetForm(r, "dns-config"), "\n") {
+		if parts := strings.SplitN(strings.TrimSpace(line), "=", 2); len(parts) == 2 {
+			config[parts[0]] = parts[1]
+		}
+	}
+
+	cert, err := models.CreateAcmeCertificate(domains, GetForm(r, "email"), directory, GetForm(r, "challenge"), GetForm(r, "dns"), config)
+	if err != nil {
+		return acmeError(err)
+	}
+
+	go models.IssueAcmeCertificate(cert.Id)
+
+	return RenderJson(rw, cert)
+}
+
+func CertificateAcmeDelete(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	cert, err := models.DeleteAcmeCertificate(mux.Vars(r)["id"])
+	if err != nil {
+		return acmeError(err)
+	}
+
+	return RenderJson(rw, cert)
+}
+
+func CertificateAcmeList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	certs, err := models.ListAcmeCertificates()
+	if err != nil {
+		return httperr.Server(err)
+	}
 
 	return RenderJson(rw, certs)
 }
+
+// AcmeChallenge answers http-01 challenges for certificates the rack is issuing.
+// It is reached by the authority without credentials.
+func AcmeChallenge(rw http.ResponseWriter, r *http.Request) {
+	keyAuth, err := models.AcmeChallenge(mux.Vars(r)["token"])
+	if err != nil {
+		http.NotFound(rw, r)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/plain")
+	rw.Write([]byte(keyAuth))
+}
+
+func acmeError(err error) *httperr.Error {
+	switch {
+	case strings.HasPrefix(err.Error(), "no such certificate"):
+		return httperr.New(404, err)
+	case strings.HasPrefix(err.Error(), "invalid certificate"):
+		return httperr.New(403, err)
+	}
+
+	return httperr.Server(err)
+}
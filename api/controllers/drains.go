@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/convox/rack/api/httperr"
+	"github.com/convox/rack/api/models"
+	"github.com/gorilla/mux"
+)
+
+func DrainList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+
+	if herr := optionalApp(app); herr != nil {
+		return herr
+	}
+
+	drains, err := models.ListDrains(app)
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	for i := range drains {
+		drains[i] = drains[i].Masked()
+	}
+
+	return RenderJson(rw, drains)
+}
+
+func DrainCreate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+
+	if herr := optionalApp(app); herr != nil {
+		return herr
+	}
+
+	d, err := models.CreateDrain(app, GetForm(r, "url"), GetForm(r, "format"))
+	if herr := drainError(err); herr != nil {
+		return herr
+	}
+
+	return RenderJson(rw, d.Masked())
+}
+
+func DrainDelete(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+
+	if herr := optionalApp(app); herr != nil {
+		return herr
+	}
+
+	d, err := models.DeleteDrain(app, mux.Vars(r)["drain"])
+	if herr := drainError(err); herr != nil {
+		return herr
+	}
+
+	return RenderJson(rw, d.Masked())
+}
+
+// optionalApp checks the app exists. Rack-wide drains and alerts have no app.
+func optionalApp(app string) *httperr.Error {
+	if app == "" {
+		return nil
+	}
+
+	_, err := models.GetApp(app)
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return nil
+}
+
+func drainError(err error) *httperr.Error {
+	switch {
+	case err == nil:
+		return nil
+	case strings.HasPrefix(err.Error(), "no such drain"):
+		return httperr.New(404, err)
+	case strings.HasPrefix(err.Error(), "invalid drain"), strings.HasPrefix(err.Error(), "drain already exists"):
+		return httperr.New(403, err)
+	}
+
+	return httperr.Server(err)
+}
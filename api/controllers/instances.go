@@ -54,7 +54,14 @@ func InstanceSSH(ws *websocket.Conn) *httperr.Error {
 		}
 	}
 
-	return httperr.Server(models.InstanceSSH(id, cmd, term, height, width, ws))
+	rw, done, err := recordSession(ws, models.Session{Kind: "ssh", Target: id, Command: cmd, Height: height, Width: width})
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	defer done()
+
+	return httperr.Server(models.InstanceSSH(id, cmd, term, height, width, rw))
 }
 
 func InstanceTerminate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
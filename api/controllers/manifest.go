@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/convox/rack/api/httperr"
+	"github.com/convox/rack/api/manifest"
+	"github.com/convox/rack/api/models"
+)
+
+// ManifestValidate reports the problems in a manifest, checking environment
+// references against an app when one is given
+func ManifestValidate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	data := GetForm(r, "manifest")
+
+	if data == "" {
+		return httperr.Errorf(403, "must specify a manifest")
+	}
+
+	var env map[string]string
+
+	if app := GetForm(r, "app"); app != "" {
+		e, err := models.GetEnvironment(app)
+
+		if awsError(err) == "ValidationError" {
+			return httperr.Errorf(404, "no such app: %s", app)
+		}
+
+		if err != nil {
+			return httperr.Server(err)
+		}
+
+		env = e
+	}
+
+	return RenderJson(rw, manifest.Validate([]byte(data), env))
+}
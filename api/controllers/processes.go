@@ -1,10 +1,16 @@
 package controllers
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/convox/rack/api/httperr"
 	"github.com/convox/rack/api/models"
@@ -22,6 +28,15 @@ func ProcessList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 		return httperr.Errorf(404, "no such app: %s", app)
 	}
 
+	if r.URL.Query().Get("stopped") == "true" {
+		stopped, err := models.ListStoppedProcesses(app)
+		if err != nil {
+			return httperr.Server(err)
+		}
+
+		return RenderJson(rw, stopped)
+	}
+
 	processes, err := models.ListProcesses(app)
 
 	if err != nil {
@@ -107,7 +122,78 @@ func ProcessExecAttached(ws *websocket.Conn) *httperr.Error {
 		return httperr.Server(err)
 	}
 
-	return httperr.Server(a.ExecAttached(pid, command, height, width, ws))
+	rw, done, err := recordSession(ws, models.Session{Kind: "exec", App: app, Target: pid, Command: command, Height: height, Width: width})
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	defer done()
+
+	return httperr.Server(a.ExecAttached(pid, command, height, width, rw))
+}
+
+// ProcessFilesGet streams a tar archive of the path in the Path header from a process
+func ProcessFilesGet(ws *websocket.Conn) *httperr.Error {
+	vars := mux.Vars(ws.Request())
+	app := vars["app"]
+
+	a, err := models.GetApp(app)
+
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	// write the archive to disk first so a failed copy is an error and not a truncated archive
+	fd, err := ioutil.TempFile("", "files")
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	defer os.Remove(fd.Name())
+	defer fd.Close()
+
+	err = a.CopyFrom(vars["pid"], ws.Request().Header.Get("Path"), fd)
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	if _, err := fd.Seek(0, 0); err != nil {
+		return httperr.Server(err)
+	}
+
+	_, err = io.Copy(ws, fd)
+
+	return httperr.Server(err)
+}
+
+// ProcessFilesPut extracts a tar archive read from the websocket to the path in the
+// Path header of a process and writes an exit status when it is done
+func ProcessFilesPut(ws *websocket.Conn) *httperr.Error {
+	vars := mux.Vars(ws.Request())
+	app := vars["app"]
+
+	a, err := models.GetApp(app)
+
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	err = a.CopyTo(vars["pid"], ws.Request().Header.Get("Path"), ws)
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	_, err = ws.Write([]byte(fmt.Sprintf("%s%d\n", models.StatusCodePrefix, 0)))
+
+	return httperr.Server(err)
 }
 
 func ProcessRunDetached(rw http.ResponseWriter, r *http.Request) *httperr.Error {
@@ -117,19 +203,58 @@ func ProcessRunDetached(rw http.ResponseWriter, r *http.Request) *httperr.Error
 	command := GetForm(r, "command")
 	release := GetForm(r, "release")
 
+	opts, herr := runOptions(GetForm(r, "cpu"), GetForm(r, "memory"), GetForm(r, "timeout"))
+	if herr != nil {
+		return herr
+	}
+
+	// the User header is set by the client and can not be trusted
+	opts.ClaimedUser = r.Header.Get("User")
+
 	a, err := models.GetApp(app)
 
 	if awsError(err) == "ValidationError" {
 		return httperr.Errorf(404, "no such app: %s", app)
 	}
 
-	err = a.RunDetached(process, command, release)
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	run, err := a.RunDetached(process, command, release, opts)
+
+	if err != nil && strings.HasPrefix(err.Error(), "can not run release") {
+		return httperr.New(403, err)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, run)
+}
+
+// ProcessRunList returns the detached runs of an app, newest first
+func ProcessRunList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	app := mux.Vars(r)["app"]
+
+	_, err := models.GetApp(app)
+
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	runs, err := models.ListRuns(app)
 
 	if err != nil {
 		return httperr.Server(err)
 	}
 
-	return RenderSuccess(rw)
+	return RenderJson(rw, runs)
 }
 
 func ProcessRunAttached(ws *websocket.Conn) *httperr.Error {
@@ -143,6 +268,11 @@ func ProcessRunAttached(ws *websocket.Conn) *httperr.Error {
 	height, _ := strconv.Atoi(header.Get("Height"))
 	width, _ := strconv.Atoi(header.Get("Width"))
 
+	opts, herr := runOptions(header.Get("Cpu"), header.Get("Memory"), header.Get("Timeout"))
+	if herr != nil {
+		return herr
+	}
+
 	a, err := models.GetApp(app)
 
 	if awsError(err) == "ValidationError" {
@@ -153,7 +283,14 @@ func ProcessRunAttached(ws *websocket.Conn) *httperr.Error {
 		return httperr.Server(err)
 	}
 
-	return httperr.Server(a.RunAttached(process, command, release, height, width, ws))
+	rw, done, err := recordSession(ws, models.Session{Kind: "run", App: app, Target: process, Command: command, Height: height, Width: width})
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	defer done()
+
+	return httperr.Server(a.RunAttached(process, command, release, height, width, opts, rw))
 }
 
 func ProcessStop(rw http.ResponseWriter, r *http.Request) *httperr.Error {
@@ -185,3 +322,37 @@ func ProcessStop(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 
 	return RenderJson(rw, ps)
 }
+
+// runOptions parses the cpu units, memory in megabytes and timeout of a one-off process
+func runOptions(cpu, memory, timeout string) (models.RunOptions, *httperr.Error) {
+	opts := models.RunOptions{}
+
+	if cpu != "" {
+		c, err := strconv.ParseInt(cpu, 10, 64)
+		if err != nil || c < 0 {
+			return opts, httperr.Errorf(403, "cpu must be a number of cpu units")
+		}
+
+		opts.Cpu = c
+	}
+
+	if memory != "" {
+		m, err := strconv.ParseInt(memory, 10, 64)
+		if err != nil || m < 0 {
+			return opts, httperr.Errorf(403, "memory must be a number of megabytes")
+		}
+
+		opts.Memory = m
+	}
+
+	if timeout != "" {
+		t, err := time.ParseDuration(timeout)
+		if err != nil || t < 0 {
+			return opts, httperr.Errorf(403, "invalid timeout: %s", timeout)
+		}
+
+		opts.Timeout = t
+	}
+
+	return opts, nil
+}
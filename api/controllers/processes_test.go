@@ -306,3 +306,11 @@ func TestProcessesListWithDetached(t *testing.T) {
 // func TestGetProcessesEmpty(t *testing.T) {}
 
 // func TestGetProcessesFailure(t *testing.T) {}
+
+func TestProcessRunDetachedInvalidOptions(t *testing.T) {
+	body := test.HTTPBody("POST", "http://convox/apps/myapp/processes/worker/run", url.Values{"memory": []string{"lots"}})
+	assert.Equal(t, `{"error":"memory must be a number of megabytes"}`, body)
+
+	body = test.HTTPBody("POST", "http://convox/apps/myapp/processes/worker/run", url.Values{"timeout": []string{"30"}})
+	assert.Equal(t, `{"error":"invalid timeout: 30"}`, body)
+}
@@ -78,3 +78,80 @@ func ReleasePromote(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 
 	return RenderJson(rw, rr)
 }
+
+// ReleaseDiff shows what changes between two releases, including the stack parameters
+// a promote of the second release would change
+func ReleaseDiff(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	vars := mux.Vars(r)
+	app := vars["app"]
+
+	_, err := models.GetApp(app)
+
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	releases := []*models.Release{}
+
+	for _, id := range []string{vars["release"], vars["other"]} {
+		rr, err := models.GetRelease(app, id)
+
+		if err != nil && strings.HasPrefix(err.Error(), "no such release") {
+			return httperr.Errorf(404, "no such release: %s", id)
+		}
+
+		if err != nil {
+			return httperr.Server(err)
+		}
+
+		releases = append(releases, rr)
+	}
+
+	diff, err := models.DiffReleases(app, releases[0], releases[1])
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, diff)
+}
+
+// ReleaseTemplate renders the stack template and parameters a promote of the release
+// would send without applying them
+func ReleaseTemplate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	vars := mux.Vars(r)
+	app := vars["app"]
+	release := vars["release"]
+
+	_, err := models.GetApp(app)
+
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such app: %s", app)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	rr, err := models.GetRelease(app, release)
+
+	if err != nil && strings.HasPrefix(err.Error(), "no such release") {
+		return httperr.Errorf(404, "no such release: %s", release)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	t, err := rr.Template(r.URL.Query().Get("diff") == "true")
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, t)
+}
@@ -14,21 +14,32 @@ func HandlerFunc(w http.ResponseWriter, req *http.Request) {
 func NewRouter() (router *mux.Router) {
 	router = mux.NewRouter()
 
+	router.HandleFunc("/alerts", api("alert.list", AlertList)).Methods("GET")
+	router.HandleFunc("/alerts", api("alert.create", AlertCreate)).Methods("POST")
+	router.HandleFunc("/alerts/{alert}", api("alert.delete", AlertDelete)).Methods("DELETE")
+	router.HandleFunc("/alerts/{alert}/silence", api("alert.silence", AlertSilence)).Methods("POST")
 	router.HandleFunc("/apps", api("app.list", AppList)).Methods("GET")
 	router.HandleFunc("/apps", api("app.create", AppCreate)).Methods("POST")
 	router.HandleFunc("/apps/{app}", api("app.get", AppShow)).Methods("GET")
 	router.HandleFunc("/apps/{app}", api("app.delete", AppDelete)).Methods("DELETE")
 	router.HandleFunc("/apps/{app}/builds", api("build.list", BuildList)).Methods("GET")
 	router.HandleFunc("/apps/{app}/builds", api("build.create", BuildCreate)).Methods("POST")
+	router.HandleFunc("/apps/{app}/builds/import", api("build.import", BuildImport)).Methods("POST")
 	router.HandleFunc("/apps/{app}/builds/{build}", api("build.get", BuildGet)).Methods("GET")
 	router.HandleFunc("/apps/{app}/builds/{build}", api("build.update", BuildUpdate)).Methods("PUT")
 	router.HandleFunc("/apps/{app}/builds/{build}", api("build.delete", BuildDelete)).Methods("DELETE")
 	router.HandleFunc("/apps/{app}/builds/{build}/copy", api("build.copy", BuildCopy)).Methods("POST")
+	router.HandleFunc("/apps/{app}/builds/{build}/export", api("build.export", BuildExport)).Methods("GET")
+	router.HandleFunc("/apps/{app}/drains", api("drain.list", DrainList)).Methods("GET")
+	router.HandleFunc("/apps/{app}/drains", api("drain.create", DrainCreate)).Methods("POST")
+	router.HandleFunc("/apps/{app}/drains/{drain}", api("drain.delete", DrainDelete)).Methods("DELETE")
 	router.HandleFunc("/apps/{app}/environment", api("environment.list", EnvironmentList)).Methods("GET")
 	router.HandleFunc("/apps/{app}/environment", api("environment.set", EnvironmentSet)).Methods("POST")
 	router.HandleFunc("/apps/{app}/environment/{name}", api("environment.delete", EnvironmentDelete)).Methods("DELETE")
 	router.HandleFunc("/apps/{app}/formation", api("formation.list", FormationList)).Methods("GET")
 	router.HandleFunc("/apps/{app}/formation/{process}", api("formation.set", FormationSet)).Methods("POST")
+	router.HandleFunc("/apps/{app}/logs/query", api("app.logs.query", AppLogsQuery)).Methods("GET")
+	router.HandleFunc("/apps/{app}/metrics", api("app.metrics", AppMetrics)).Methods("GET")
 	router.HandleFunc("/apps/{app}/parameters", api("parameters.list", ParametersList)).Methods("GET")
 	router.HandleFunc("/apps/{app}/parameters", api("parameters.set", ParametersSet)).Methods("POST")
 	router.HandleFunc("/apps/{app}/processes", api("process.list", ProcessList)).Methods("GET")
@@ -37,19 +48,31 @@ func NewRouter() (router *mux.Router) {
 	router.HandleFunc("/apps/{app}/processes/{process}/run", api("process.run.detach", ProcessRunDetached)).Methods("POST")
 	router.HandleFunc("/apps/{app}/releases", api("release.list", ReleaseList)).Methods("GET")
 	router.HandleFunc("/apps/{app}/releases/{release}", api("release.get", ReleaseGet)).Methods("GET")
+	router.HandleFunc("/apps/{app}/releases/{release}/diff/{other}", api("release.diff", ReleaseDiff)).Methods("GET")
 	router.HandleFunc("/apps/{app}/releases/{release}/promote", api("release.promote", ReleasePromote)).Methods("POST")
+	router.HandleFunc("/apps/{app}/releases/{release}/template", api("release.template", ReleaseTemplate)).Methods("GET")
+	router.HandleFunc("/apps/{app}/runs", api("process.run.list", ProcessRunList)).Methods("GET")
 	router.HandleFunc("/apps/{app}/ssl", api("ssl.list", SSLList)).Methods("GET")
 	router.HandleFunc("/apps/{app}/ssl/{process}/{port}", api("ssl.update", SSLUpdate)).Methods("PUT")
+	router.HandleFunc("/apps/{app}/template", api("app.template", AppTemplate)).Methods("POST")
 	router.HandleFunc("/auth", api("auth", Auth)).Methods("GET")
 	router.HandleFunc("/certificates", api("certificate.list", CertificateList)).Methods("GET")
 	router.HandleFunc("/certificates", api("certificate.create", CertificateCreate)).Methods("POST")
+	router.HandleFunc("/certificates/acme", api("certificate.acme.list", CertificateAcmeList)).Methods("GET")
+	router.HandleFunc("/certificates/acme", api("certificate.acme.create", CertificateAcmeCreate)).Methods("POST")
+	router.HandleFunc("/certificates/acme/{id}", api("certificate.acme.delete", CertificateAcmeDelete)).Methods("DELETE")
 	router.HandleFunc("/certificates/generate", api("certificate.generate", CertificateGenerate)).Methods("POST")
 	router.HandleFunc("/certificates/{id}", api("certificate.delete", CertificateDelete)).Methods("DELETE")
+	router.HandleFunc("/certificates/{id}/replace", api("certificate.replace", CertificateReplace)).Methods("POST")
+	router.HandleFunc("/drains", api("drain.list", DrainList)).Methods("GET")
+	router.HandleFunc("/drains", api("drain.create", DrainCreate)).Methods("POST")
+	router.HandleFunc("/drains/{drain}", api("drain.delete", DrainDelete)).Methods("DELETE")
 	router.HandleFunc("/index/diff", api("index.diff", IndexDiff)).Methods("POST")
 	router.HandleFunc("/index/file/{hash}", api("index.upload", IndexUpload)).Methods("POST")
 	router.HandleFunc("/instances", api("instances.get", InstancesList)).Methods("GET")
 	router.HandleFunc("/instances/{id}", api("instance.delete", InstanceTerminate)).Methods("DELETE")
 	router.HandleFunc("/instances/keyroll", api("instances.keyroll", InstancesKeyroll)).Methods("POST")
+	router.HandleFunc("/manifest/validate", api("manifest.validate", ManifestValidate)).Methods("POST")
 	router.HandleFunc("/racks", api("rack.list", RackList)).Methods("GET")
 	router.HandleFunc("/registries", api("registry.list", RegistryList)).Methods("GET")
 	router.HandleFunc("/registries", api("registry.create", RegistryCreate)).Methods("POST")
@@ -62,25 +85,36 @@ func NewRouter() (router *mux.Router) {
 	router.HandleFunc("/services/{service}", api("service.delete", ServiceDelete)).Methods("DELETE")
 	router.HandleFunc("/services/{service}/links", api("link.create", LinkCreate)).Methods("POST")
 	router.HandleFunc("/services/{service}/links/{app}", api("link.delete", LinkDelete)).Methods("DELETE")
+	router.HandleFunc("/services/{service}/rotate", api("service.rotate", ServiceRotate)).Methods("POST")
+	router.HandleFunc("/services/{service}/rotation", api("service.rotation", ServiceRotation)).Methods("GET")
 	router.HandleFunc("/sns", SNSProxy).Methods("POST").Headers("X-Amz-Sns-Message-Type", "Notification")
 	router.HandleFunc("/sns", SNSConfirm).Methods("POST").Headers("X-Amz-Sns-Message-Type", "SubscriptionConfirmation")
 	router.HandleFunc("/system", api("system.show", SystemShow)).Methods("GET")
 	router.HandleFunc("/system", api("system.update", SystemUpdate)).Methods("PUT")
 	router.HandleFunc("/system/capacity", api("system.capacity", SystemCapacity)).Methods("GET")
 	router.HandleFunc("/system/releases", api("system.release.list", SystemReleaseList)).Methods("GET")
+	router.HandleFunc("/sessions", api("session.list", SessionList)).Methods("GET")
+	router.HandleFunc("/sessions/recording", api("session.recording.get", SessionRecordingGet)).Methods("GET")
+	router.HandleFunc("/sessions/recording", api("session.recording.set", SessionRecordingSet)).Methods("POST")
+	router.HandleFunc("/sessions/{session}/cast", api("session.cast", SessionCast)).Methods("GET")
 	router.HandleFunc("/switch", api("switch", Switch)).Methods("POST")
 
 	// websockets
 	router.Handle("/apps/{app}/logs", ws("app.logs", AppLogs)).Methods("GET")
 	router.Handle("/apps/{app}/builds/{build}/logs", ws("build.logs", BuildLogs)).Methods("GET")
 	router.Handle("/apps/{app}/processes/{pid}/exec", ws("process.exec.attach", ProcessExecAttached)).Methods("GET")
+	router.Handle("/apps/{app}/processes/{pid}/files", ws("process.files.get", ProcessFilesGet)).Methods("GET")
+	router.Handle("/apps/{app}/processes/{pid}/files/upload", ws("process.files.put", ProcessFilesPut)).Methods("GET")
 	router.Handle("/apps/{app}/processes/{process}/run", ws("process.run.attach", ProcessRunAttached)).Methods("GET")
 	router.Handle("/instances/{id}/ssh", ws("instance.ssh", InstanceSSH)).Methods("GET")
 	router.Handle("/proxy/{host}/{port}", ws("proxy", Proxy)).Methods("GET")
+	router.Handle("/sessions/{session}/watch", ws("session.watch", SessionWatch)).Methods("GET")
+	router.Handle("/system/logs", ws("system.logs", SystemLogs)).Methods("GET")
 
 	// utility
 	router.HandleFunc("/boom", UtilityBoom).Methods("GET")
 	router.HandleFunc("/check", UtilityCheck).Methods("GET")
+	router.HandleFunc("/.well-known/acme-challenge/{token}", AcmeChallenge).Methods("GET")
 
 	// limbo
 	// auth.HandleFunc("/apps/{app}/debug", controllers.AppDebug).Methods("GET")
@@ -3,6 +3,7 @@ package controllers
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/convox/rack/api/httperr"
@@ -193,6 +194,62 @@ func ServiceUpdate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 	return RenderJson(rw, s)
 }
 
+func ServiceRotate(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	service := mux.Vars(r)["service"]
+
+	s, err := models.GetService(service)
+
+	if awsError(err) == "ValidationError" {
+		return httperr.Errorf(404, "no such service: %s", service)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	if s.Status != "running" {
+		return httperr.Errorf(403, "can not rotate service with status: %s", s.Status)
+	}
+
+	grace := time.Duration(0)
+
+	if g := GetForm(r, "grace"); g != "" {
+		grace, err = time.ParseDuration(g)
+
+		if err != nil {
+			return httperr.Errorf(403, "invalid grace period: %s", g)
+		}
+	}
+
+	rotation, err := s.Rotate(grace)
+
+	if err != nil && strings.HasPrefix(err.Error(), "can not rotate") {
+		return httperr.New(403, err)
+	}
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, rotation)
+}
+
+func ServiceRotation(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	service := mux.Vars(r)["service"]
+
+	rotation, err := models.GetServiceRotation(service)
+
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	if rotation == nil {
+		return httperr.Errorf(404, "no rotation for service: %s", service)
+	}
+
+	return RenderJson(rw, rotation)
+}
+
 func convoxifyCloudformationError(msg string) string {
 	newMsg := strings.Replace(msg, "do not exist in the template", "are not supported by this service", 1)
 	newMsg = strings.Replace(newMsg, "Parameters:", "Options:", 1)
@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/convox/rack/api/httperr"
+	"github.com/convox/rack/api/models"
+	"github.com/gorilla/mux"
+	"golang.org/x/net/websocket"
+)
+
+func SessionList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	sessions, err := models.ListSessions()
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, sessions)
+}
+
+func SessionRecordingGet(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	enabled, err := models.SessionRecording()
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	return RenderJson(rw, map[string]bool{"enabled": enabled})
+}
+
+func SessionRecordingSet(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	enabled := GetForm(r, "enabled")
+
+	if enabled != "true" && enabled != "false" {
+		return httperr.Errorf(403, "enabled must be true or false")
+	}
+
+	err := models.SetSessionRecording(enabled == "true")
+	if err != nil {
+		return httperr.Server(err)
+	}
+
+	models.NotifySuccess("session:recording", map[string]string{"enabled": enabled})
+
+	return RenderJson(rw, map[string]bool{"enabled": enabled == "true"})
+}
+
+// SessionCast returns a recording as an asciicast v2 file
+func SessionCast(rw http.ResponseWriter, r *http.Request) *httperr.Error {
+	s, err := models.GetSession(mux.Vars(r)["session"])
+	if herr := sessionError(err); herr != nil {
+		return herr
+	}
+
+	rw.Header().Set("Content-Type", "application/x-asciicast")
+
+	return httperr.Server(s.Cast(rw))
+}
+
+// SessionWatch streams the output of a session as it is recorded. Input from the
+// watcher is ignored.
+func SessionWatch(ws *websocket.Conn) *httperr.Error {
+	id := mux.Vars(ws.Request())["session"]
+
+	s, err := models.GetSession(id)
+	if herr := sessionError(err); herr != nil {
+		return herr
+	}
+
+	next := 0
+
+	for {
+		for ; next < s.Chunks; next++ {
+			data, err := s.Chunk(next)
+			if err != nil {
+				return httperr.Server(err)
+			}
+
+			for _, line := range bytes.Split(data, []byte("\n")) {
+				var event []interface{}
+
+				if err := json.Unmarshal(line, &event); err != nil || len(event) != 3 || event[1] != "o" {
+					continue
+				}
+
+				if out, ok := event[2].(string); ok {
+					if _, err := ws.Write([]byte(out)); err != nil {
+						return nil
+					}
+				}
+			}
+		}
+
+		if !s.Live() {
+			return nil
+		}
+
+		time.Sleep(1 * time.Second)
+
+		s, err = models.GetSession(id)
+		if err != nil {
+			return httperr.Server(err)
+		}
+	}
+}
+
+// recordSession wraps the websocket of an interactive session in a recorder when
+// recording is turned on. Sessions are refused if they can not be recorded or if
+// the recording setting can not be read. The returned func must be called when the
+// session ends.
+func recordSession(ws *websocket.Conn, s models.Session) (io.ReadWriter, func(), error) {
+	enabled, err := models.SessionRecording()
+	if err != nil {
+		return nil, nil, fmt.Errorf("can not check session recording: %s", err)
+	}
+
+	if !enabled {
+		return ws, func() {}, nil
+	}
+
+	r := ws.Request()
+
+	// the User header is set by the client and can not be trusted
+	s.ClaimedUser = r.Header.Get("User")
+	s.Remote = r.RemoteAddr
+
+	if f := r.Header.Get("X-Forwarded-For"); f != "" {
+		s.Remote = strings.TrimSpace(strings.Split(f, ",")[0])
+	}
+
+	rec, err := models.RecordSession(s, ws)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rec, func() {
+		if err := rec.Close(); err != nil {
+			logError("session.record", httperr.Server(err))
+		}
+	}, nil
+}
+
+func sessionError(err error) *httperr.Error {
+	switch {
+	case err == nil:
+		return nil
+	case strings.HasPrefix(err.Error(), "no such session"):
+		return httperr.New(404, err)
+	}
+
+	return httperr.Server(err)
+}
@@ -3,10 +3,12 @@ package controllers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/convox/rack/api/httperr"
 	"github.com/convox/rack/api/models"
 	"github.com/convox/rack/api/provider"
+	"golang.org/x/net/websocket"
 )
 
 func SystemReleaseList(rw http.ResponseWriter, r *http.Request) *httperr.Error {
@@ -107,3 +109,23 @@ func SystemCapacity(rw http.ResponseWriter, r *http.Request) *httperr.Error {
 
 	return RenderJson(rw, capacity)
 }
+
+func SystemLogs(ws *websocket.Conn) *httperr.Error {
+	header := ws.Request().Header
+
+	opts, herr := logStreamOptions(header.Get)
+	if herr != nil {
+		return herr
+	}
+
+	opts.Follow = header.Get("Follow") != "false"
+
+	err := provider.SystemLogs(ws, opts)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "write: broken pipe") {
+			return nil
+		}
+		return httperr.Server(err)
+	}
+	return nil
+}
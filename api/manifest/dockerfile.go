@@ -0,0 +1,222 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// Dockerfile is the part of a Dockerfile that matters to a docker-compose.yml. Only the
+// final stage of a multi-stage build is kept.
+type Dockerfile struct {
+	// Command and Entrypoint are a string in shell form or a []string in exec form
+	Command    interface{}
+	Entrypoint interface{}
+
+	Env     []string
+	Expose  []int
+	Volumes []string
+}
+
+func readDockerfile(path string) (*Dockerfile, error) {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDockerfile(data), nil
+}
+
+func parseDockerfile(data []byte) *Dockerfile {
+	df := &Dockerfile{}
+
+	for _, line := range dockerfileLines(data) {
+		parts := strings.SplitN(line, " ", 2)
+
+		if len(parts) < 2 {
+			continue
+		}
+
+		args := strings.TrimSpace(parts[1])
+
+		switch strings.ToUpper(parts[0]) {
+		case "FROM":
+			df = &Dockerfile{}
+		case "CMD":
+			df.Command = dockerfileCommand(args)
+		case "ENTRYPOINT":
+			df.Entrypoint = dockerfileCommand(args)
+		case "ENV":
+			for _, env := range dockerfileEnv(args) {
+				df.setEnv(env)
+			}
+		case "EXPOSE":
+			for _, p := range strings.Fields(args) {
+				ps := strings.SplitN(p, "/", 2)
+
+				// the balancers only carry tcp
+				if len(ps) == 2 && strings.ToLower(ps[1]) != "tcp" {
+					continue
+				}
+
+				if port, err := strconv.Atoi(ps[0]); err == nil {
+					df.Expose = append(df.Expose, port)
+				}
+			}
+		case "VOLUME":
+			var volumes []string
+
+			if err := json.Unmarshal([]byte(args), &volumes); err != nil {
+				volumes = strings.Fields(args)
+			}
+
+			df.Volumes = append(df.Volumes, volumes...)
+		}
+	}
+
+	return df
+}
+
+// Port returns the first exposed port or 0 if none are exposed
+func (df *Dockerfile) Port() int {
+	if len(df.Expose) == 0 {
+		return 0
+	}
+
+	return df.Expose[0]
+}
+
+// Runs returns true if the Dockerfile starts a process or exposes a port
+func (df *Dockerfile) Runs() bool {
+	return df.Command != nil || df.Entrypoint != nil || len(df.Expose) > 0
+}
+
+// setEnv adds KEY=value to the environment, replacing an earlier value for the same key
+func (df *Dockerfile) setEnv(env string) {
+	key := strings.SplitN(env, "=", 2)[0]
+
+	for i, e := range df.Env {
+		if strings.SplitN(e, "=", 2)[0] == key {
+			df.Env[i] = env
+			return
+		}
+	}
+
+	df.Env = append(df.Env, env)
+}
+
+// dockerfileLines joins continued lines and drops comments and blank lines
+func dockerfileLines(data []byte) []string {
+	lines := []string{}
+	current := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "#") || (line == "" && current == "") {
+			continue
+		}
+
+		if strings.HasSuffix(line, "\\") {
+			current += strings.TrimSuffix(line, "\\") + " "
+			continue
+		}
+
+		if line = strings.TrimSpace(current + line); line != "" {
+			lines = append(lines, line)
+		}
+
+		current = ""
+	}
+
+	if current = strings.TrimSpace(current); current != "" {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
+
+// dockerfileCommand returns a []string for the exec form of CMD or ENTRYPOINT and a
+// string for the shell form
+func dockerfileCommand(args string) interface{} {
+	var exec []string
+
+	if err := json.Unmarshal([]byte(args), &exec); err == nil {
+		return exec
+	}
+
+	return args
+}
+
+// dockerfileEnv returns KEY=value pairs for both the ENV KEY value and ENV KEY=value forms
+func dockerfileEnv(args string) []string {
+	words := shellWords(args)
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	if !strings.Contains(words[0], "=") {
+		return []string{words[0] + "=" + strings.TrimSpace(strings.TrimPrefix(args, strings.Fields(args)[0]))}
+	}
+
+	env := []string{}
+
+	for _, w := range words {
+		if strings.Contains(w, "=") {
+			env = append(env, w)
+		}
+	}
+
+	return env
+}
+
+// shellWords splits on unquoted whitespace, removing quotes and escapes
+func shellWords(s string) []string {
+	words := []string{}
+
+	var word bytes.Buffer
+	var quote rune
+
+	inWord := false
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words
+}
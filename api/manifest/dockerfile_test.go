@@ -0,0 +1,55 @@
+package manifest
+
+import "testing"
+
+func TestParseDockerfile(t *testing.T) {
+	df := parseDockerfile([]byte(`FROM golang AS build
+EXPOSE 8080
+CMD ["go", "run"]
+
+FROM alpine
+# comments are ignored
+ENV PATH /app/bin:$PATH
+ENV NAME="hello world" EMPTY= ESCAPED=a\ b
+ENV NAME=replaced
+expose 3000/tcp 3001
+VOLUME /data /cache
+CMD ["bin/web", \
+     "--port", "3000"]
+`))
+
+	cases := Cases{
+		{df.Command, []string{"bin/web", "--port", "3000"}},
+		{df.Entrypoint, nil},
+		{df.Env, []string{"PATH=/app/bin:$PATH", "NAME=replaced", "EMPTY=", "ESCAPED=a b"}},
+		{df.Expose, []int{3000, 3001}},
+		{df.Port(), 3000},
+		{df.Volumes, []string{"/data", "/cache"}},
+		{df.Runs(), true},
+	}
+
+	_assert(t, cases)
+}
+
+func TestParseDockerfileShellForm(t *testing.T) {
+	df := parseDockerfile([]byte("FROM alpine\nENTRYPOINT exec bin/start\nCMD bin/web --verbose\n"))
+
+	cases := Cases{
+		{df.Command, "bin/web --verbose"},
+		{df.Entrypoint, "exec bin/start"},
+		{df.Port(), 0},
+	}
+
+	_assert(t, cases)
+}
+
+func TestParseDockerfileEmpty(t *testing.T) {
+	df := parseDockerfile([]byte("FROM alpine\nRUN true\n"))
+
+	cases := Cases{
+		{df.Runs(), false},
+		{len(df.Env), 0},
+	}
+
+	_assert(t, cases)
+}
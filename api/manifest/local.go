@@ -0,0 +1,204 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// LocalProcess is a container started for a process by convox start
+type LocalProcess struct {
+	Name      string
+	Container string
+	Image     string
+	Status    string
+	Started   time.Time
+}
+
+// Subset returns the named processes along with everything they link to or depend on
+func (m *Manifest) Subset(names []string) (*Manifest, error) {
+	subset := Manifest{}
+
+	var add func(name string) error
+
+	add = func(name string) error {
+		if _, ok := subset[name]; ok {
+			return nil
+		}
+
+		me, ok := (*m)[name]
+
+		if !ok {
+			return fmt.Errorf("no such process: %s", name)
+		}
+
+		subset[name] = me
+
+		for _, dep := range me.dependencies() {
+			if err := add(dep); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := add(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &subset, nil
+}
+
+// RunLocal runs a one-off command in a new container for a process with the environment,
+// volumes and links that convox start gives the process. It returns the exit code of
+// the command.
+func (m *Manifest) RunLocal(app, process, command string, tty bool) (int, error) {
+	me, ok := (*m)[process]
+
+	if !ok {
+		return 0, fmt.Errorf("no such process: %s", process)
+	}
+
+	tag := fmt.Sprintf("%s/%s", app, process)
+
+	if err := Execer("docker", "inspect", tag).Run(); err != nil {
+		return 0, fmt.Errorf("no image for %s, run convox start first", process)
+	}
+
+	args := []string{"run", "--rm", "-i", "--name", fmt.Sprintf("%s-run-%s", containerName(app, process), randomString("", 6))}
+
+	if tty {
+		args = append(args, "-t")
+	}
+
+	envArgs, err := me.runEnvArgs(m, true, app)
+
+	if err != nil {
+		return 0, err
+	}
+
+	linkArgs, err := me.runLinkArgs(app)
+
+	if err != nil {
+		return 0, fmt.Errorf("linked processes must be running, start them with convox start")
+	}
+
+	args = append(args, envArgs...)
+	args = append(args, linkArgs...)
+	args = append(args, tag, "sh", "-c", command)
+
+	cmd := Execer("docker", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = Stdout
+	cmd.Stderr = Stderr
+
+	err = cmd.Run()
+
+	if ee, ok := err.(*exec.ExitError); ok {
+		if ws, ok := ee.Sys().(syscall.WaitStatus); ok {
+			return ws.ExitStatus(), nil
+		}
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
+
+// LocalProcesses returns the containers of the processes that convox start has created
+func (m *Manifest) LocalProcesses(app string) ([]LocalProcess, error) {
+	ps := []LocalProcess{}
+
+	order, err := m.runOrder()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range order {
+		container := containerName(app, name)
+
+		out, err := Execer("docker", "inspect", "-f", "{{ .State.Status }}|{{ .State.StartedAt }}|{{ .Config.Image }}", container).Output()
+
+		// the process has no container
+		if err != nil {
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimSpace(string(out)), "|", 3)
+
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("could not inspect %s", container)
+		}
+
+		started, _ := time.Parse(time.RFC3339Nano, parts[1])
+
+		ps = append(ps, LocalProcess{
+			Name:      name,
+			Container: container,
+			Status:    parts[0],
+			Started:   started,
+			Image:     parts[2],
+		})
+	}
+
+	return ps, nil
+}
+
+// Stop stops the running containers of the named processes, or of every process if none
+// are named. Dependents are stopped before the processes they depend on.
+func (m *Manifest) Stop(app string, names []string) error {
+	selected := map[string]bool{}
+
+	for _, name := range names {
+		if _, ok := (*m)[name]; !ok {
+			return fmt.Errorf("no such process: %s", name)
+		}
+
+		selected[name] = true
+	}
+
+	order, err := m.runOrder()
+
+	if err != nil {
+		return err
+	}
+
+	ps, err := m.LocalProcesses(app)
+
+	if err != nil {
+		return err
+	}
+
+	running := map[string]bool{}
+
+	for _, p := range ps {
+		running[p.Name] = p.Status == "running"
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		name := order[i]
+
+		if !running[name] || (len(selected) > 0 && !selected[name]) {
+			continue
+		}
+
+		fmt.Fprintf(Stdout, "Stopping %s... ", name)
+
+		if out, err := Execer("docker", "stop", containerName(app, name)).CombinedOutput(); err != nil {
+			return fmt.Errorf("could not stop %s: %s", name, strings.TrimSpace(string(out)))
+		}
+
+		fmt.Fprintln(Stdout, "OK")
+	}
+
+	return nil
+}
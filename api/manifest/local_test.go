@@ -0,0 +1,156 @@
+package manifest
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var localManifest = []byte(`web:
+  image: httpd
+  links:
+    - postgres
+  depends_on:
+    - worker
+worker:
+  image: worker
+  environment:
+    - QUEUE=jobs
+  links:
+    - redis
+redis:
+  image: convox/redis
+postgres:
+  image: convox/postgres
+other:
+  image: other
+`)
+
+func TestSubset(t *testing.T) {
+	var m Manifest
+
+	_ = yaml.Unmarshal(localManifest, &m)
+
+	s, err := m.Subset([]string{"web"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = m.Subset([]string{"web", "missing"})
+
+	cases := Cases{
+		{runOrder(t, s), []string{"postgres", "redis", "worker", "web"}},
+		{err.Error(), "no such process: missing"},
+	}
+
+	_assert(t, cases)
+}
+
+func TestStop(t *testing.T) {
+	var m Manifest
+
+	_ = yaml.Unmarshal(localManifest, &m)
+
+	commands := stubDocker(map[string]string{
+		"inspect": "running|2016-10-01T10:00:00.000Z|myapp/web",
+	})
+	defer func() { Execer = exec.Command }()
+
+	var out bytes.Buffer
+	Stdout = &out
+	defer func() { Stdout = os.Stdout }()
+
+	err := m.Stop("myapp", []string{"web", "redis"})
+
+	cases := Cases{
+		{err, nil},
+		{(*commands)[len(*commands)-2:], []string{"docker stop myapp-web", "docker stop myapp-redis"}},
+		{out.String(), "Stopping web... OK\nStopping redis... OK\n"},
+	}
+
+	_assert(t, cases)
+}
+
+func TestLocalProcesses(t *testing.T) {
+	m := Manifest{"web": ManifestEntry{Image: "httpd"}}
+
+	stubDocker(map[string]string{
+		"inspect": "exited|2016-10-01T10:00:00.5Z|myapp/web",
+	})
+	defer func() { Execer = exec.Command }()
+
+	ps, err := m.LocalProcesses("myapp")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := Cases{
+		{len(ps), 1},
+		{ps[0].Container, "myapp-web"},
+		{ps[0].Status, "exited"},
+		{ps[0].Started.Format("15:04:05.0"), "10:00:00.5"},
+	}
+
+	_assert(t, cases)
+}
+
+func TestRunLocal(t *testing.T) {
+	var m Manifest
+
+	_ = yaml.Unmarshal(localManifest, &m)
+
+	commands := stubDocker(map[string]string{
+		"inspect":    `[{"Config":{"Env":[]}}]`,
+		"inspect -f": "172.17.0.2",
+		"run":        "exit 3",
+	})
+	defer func() { Execer = exec.Command }()
+
+	code, err := m.RunLocal("myapp", "worker", "bin/migrate", false)
+
+	run := (*commands)[len(*commands)-1]
+
+	cases := Cases{
+		{err, nil},
+		{code, 3},
+		{strings.Contains(run, "-e QUEUE=jobs"), true},
+		{strings.Contains(run, "--add-host=myapp-redis:172.17.0.2"), true},
+		{strings.HasSuffix(run, "myapp/worker sh -c bin/migrate"), true},
+	}
+
+	_assert(t, cases)
+}
+
+// stubDocker records docker commands and answers them by echoing the output of the
+// longest matching prefix of their arguments. The run subcommand is executed as a shell
+// script.
+func stubDocker(outputs map[string]string) *[]string {
+	commands := []string{}
+
+	Execer = func(bin string, args ...string) *exec.Cmd {
+		command := strings.Join(args, " ")
+		commands = append(commands, bin+" "+command)
+
+		match := ""
+
+		for prefix := range outputs {
+			if strings.HasPrefix(command, prefix) && len(prefix) > len(match) {
+				match = prefix
+			}
+		}
+
+		if args[0] == "run" {
+			return exec.Command("sh", "-c", outputs[match])
+		}
+
+		return exec.Command("echo", outputs[match])
+	}
+
+	return &commands
+}
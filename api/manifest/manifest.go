@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"text/template"
 	"time"
 
 	"github.com/convox/rack/cmd/convox/changes"
@@ -81,6 +82,7 @@ type ManifestEntry struct {
 	Entrypoint  string      `yaml:"entrypoint,omitempty"`
 	Environment interface{} `yaml:"environment,omitempty"`
 	Labels      interface{} `yaml:"labels,omitempty"`
+	DependsOn   []string    `yaml:"depends_on,omitempty"`
 	Links       []string    `yaml:"links,omitempty"`
 	Networks    Networks    `yaml:"networks,omitempty"`
 	Ports       interface{} `yaml:"ports,omitempty"`
@@ -551,28 +553,38 @@ func (m *Manifest) Raw() ([]byte, error) {
 func (m *Manifest) Run(app string, cache, sync bool, shift int) []error {
 	ch := make(chan error)
 	sigch := make(chan os.Signal, 1)
+
+	order, err := m.runOrder()
+
+	if err != nil {
+		return []error{err}
+	}
+
 	signal.Notify(sigch, os.Interrupt, os.Kill)
 
 	go func() {
 		for _ = range sigch {
-			order := m.runOrder()
+			reversed := make([]string, len(order))
+			copy(reversed, order)
 
-			sort.Sort(sort.Reverse(sort.StringSlice(order)))
+			sort.Sort(sort.Reverse(sort.StringSlice(reversed)))
 
-			for _, name := range order {
+			for _, name := range reversed {
 				Execer("docker", "kill", containerName(app, name)).Run()
 			}
 		}
 	}()
 
-	for i, name := range m.runOrder() {
+	fmt.Printf("%s %s\n", m.systemPrefix(), system(fmt.Sprintf("starting %s", strings.Join(order, ", "))))
+
+	for i, name := range order {
 		sch := make(chan error)
 		go (*m)[name].runAsync(m, m.prefixForEntry(name, i), app, name, cache, shift, ch, sch)
 		<-sch // block until started successfully
 
 		if sync {
 			time.Sleep(1 * time.Second)
-			m.sync(app, name)
+			m.sync(app, name, m.prefixForEntry(name, i))
 		}
 
 		// block until we can get the container IP
@@ -590,6 +602,13 @@ func (m *Manifest) Run(app string, cache, sync bool, shift int) []error {
 			}
 			time.Sleep(100 * time.Millisecond)
 		}
+
+		// block until processes that others depend on are ready
+		if m.dependedOn(name) && os.Getenv("PROVIDER") != "test" {
+			if err := (*m)[name].waitReady(app, name, m.prefixForEntry(name, i)); err != nil {
+				fmt.Printf("%s %s\n", m.prefixForEntry(name, i), warning(err.Error()))
+			}
+		}
 	}
 
 	if sync {
@@ -611,8 +630,8 @@ func (m *Manifest) Run(app string, cache, sync bool, shift int) []error {
 	return errors
 }
 
-func (m *Manifest) sync(app, process string) error {
-	err := (*m)[process].syncAdds(app, process)
+func (m *Manifest) sync(app, process, prefix string) error {
+	err := (*m)[process].syncAdds(app, process, prefix)
 
 	if err != nil {
 		return err
@@ -705,7 +724,8 @@ func (m *Manifest) prefixForEntry(name string, pos int) string {
 	return c.SprintFunc()(name + strings.Repeat(" ", longest-len(name)) + " |")
 }
 
-func (m *Manifest) runOrder() []string {
+// runOrder sorts processes so each one comes after the processes it links to or depends on
+func (m *Manifest) runOrder() ([]string, error) {
 	unsorted := []string{}
 
 	for name, _ := range *m {
@@ -714,9 +734,19 @@ func (m *Manifest) runOrder() []string {
 
 	sort.Strings(unsorted)
 
+	for _, name := range unsorted {
+		for _, dep := range (*m)[name].dependencies() {
+			if _, ok := (*m)[dep]; !ok {
+				return nil, fmt.Errorf("%s depends on %s which is not in the manifest", name, dep)
+			}
+		}
+	}
+
 	sorted := []string{}
 
 	for len(sorted) < len(unsorted) {
+		progress := false
+
 		for _, name := range unsorted {
 			found := false
 
@@ -733,9 +763,7 @@ func (m *Manifest) runOrder() []string {
 
 			resolved := true
 
-			for _, link := range (*m)[name].Links {
-				lname := strings.Split(link, ":")[0]
-
+			for _, lname := range (*m)[name].dependencies() {
 				found := false
 
 				for _, n := range sorted {
@@ -752,11 +780,32 @@ func (m *Manifest) runOrder() []string {
 
 			if resolved {
 				sorted = append(sorted, name)
+				progress = true
+			}
+		}
+
+		if !progress {
+			remaining := []string{}
+
+			for _, name := range unsorted {
+				found := false
+
+				for _, n := range sorted {
+					if n == name {
+						found = true
+					}
+				}
+
+				if !found {
+					remaining = append(remaining, name)
+				}
 			}
+
+			return nil, fmt.Errorf("circular dependency between %s", strings.Join(remaining, ", "))
 		}
 	}
 
-	return sorted
+	return sorted, nil
 }
 
 func containerName(app, process string) string {
@@ -771,20 +820,14 @@ func (me ManifestEntry) runAsync(m *Manifest, prefix, app, process string, cache
 
 	args := []string{"run", "-i", "--name", name}
 
-	resolved, err := me.ResolvedEnvironment(m, cache, app)
+	envArgs, err := me.runEnvArgs(m, cache, app)
 
 	if err != nil {
 		ch <- err
 		return
 	}
 
-	for _, env := range resolved {
-		args = append(args, "-e", env)
-	}
-
-	if me.Privileged {
-		args = append(args, "--privileged")
-	}
+	args = append(args, envArgs...)
 
 	ports := []string{}
 
@@ -858,6 +901,69 @@ func (me ManifestEntry) runAsync(m *Manifest, prefix, app, process string, cache
 		args = append(args, "-p", fmt.Sprintf("%s:%s", host, container))
 	}
 
+	linkArgs, err := me.runLinkArgs(app)
+
+	if err != nil {
+		ch <- err
+		return
+	}
+
+	args = append(args, linkArgs...)
+
+	args = append(args, tag)
+
+	switch cmd := me.Command.(type) {
+	case string:
+		if cmd != "" {
+			args = append(args, "sh", "-c", cmd)
+		}
+	case []string:
+		args = append(args, cmd...)
+	}
+
+	err = runPrefix(prefix, sch, "docker", args...)
+
+	// a restart after a sync stops the attached docker run, so follow the restarted
+	// container until it exits on its own
+	for {
+		since, ok := <-restartStarted(name)
+
+		if !ok {
+			break
+		}
+
+		err = runPrefix(prefix, make(chan error, 1), "docker", "logs", "-f", "--since", since, name)
+	}
+
+	ch <- err
+}
+
+// runEnvArgs returns the docker run arguments for the environment of a process
+func (me ManifestEntry) runEnvArgs(m *Manifest, cache bool, app string) ([]string, error) {
+	args := []string{}
+
+	resolved, err := me.ResolvedEnvironment(m, cache, app)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, env := range resolved {
+		args = append(args, "-e", env)
+	}
+
+	if me.Privileged {
+		args = append(args, "--privileged")
+	}
+
+	return args, nil
+}
+
+// runLinkArgs returns the docker run arguments for the volumes, entrypoint and networks
+// of a process, adding the hosts of running linked containers
+func (me ManifestEntry) runLinkArgs(app string) ([]string, error) {
+	args := []string{}
+
 	for _, volume := range me.Volumes {
 		warnIfRoot(volume)
 		args = append(args, "-v", volume)
@@ -881,26 +987,14 @@ func (me ManifestEntry) runAsync(m *Manifest, prefix, app, process string, cache
 			ip, err := Execer("docker", "inspect", "-f", "{{ .NetworkSettings.IPAddress }}", host).CombinedOutput()
 
 			if err != nil {
-				ch <- err
-				return
+				return nil, err
 			}
 
 			args = append(args, fmt.Sprintf(`--add-host=%s:%s`, host, strings.TrimSpace(string(ip))))
 		}
 	}
 
-	args = append(args, tag)
-
-	switch cmd := me.Command.(type) {
-	case string:
-		if cmd != "" {
-			args = append(args, "sh", "-c", cmd)
-		}
-	case []string:
-		args = append(args, cmd...)
-	}
-
-	ch <- runPrefix(prefix, sch, "docker", args...)
+	return args, nil
 }
 
 func (me ManifestEntry) Label(key string) string {
@@ -952,7 +1046,7 @@ func (me ManifestEntry) Protocol(port string) string {
 	return proto
 }
 
-func (me ManifestEntry) syncAdds(app, process string) error {
+func (me ManifestEntry) syncAdds(app, process, prefix string) error {
 	// only sync containers with a build directive
 	if me.Build == "" {
 		return nil
@@ -976,6 +1070,13 @@ func (me ManifestEntry) syncAdds(app, process string) error {
 		return err
 	}
 
+	s := Sync{
+		Container: containerName(app, process),
+		Prefix:    prefix,
+		Restart:   me.Label("convox.start.restart") == "true",
+		Command:   me.Label("convox.start.command-on-change"),
+	}
+
 	for _, line := range strings.Split(string(data), "\n") {
 		parts := strings.Fields(line)
 
@@ -989,7 +1090,7 @@ func (me ManifestEntry) syncAdds(app, process string) error {
 				continue
 			}
 
-			registerSync(containerName(app, process), parts[1], parts[2])
+			registerSync(s, parts[1], parts[2])
 		}
 	}
 
@@ -1219,55 +1320,29 @@ func getDockerGateway() (string, error) {
 
 type Files map[string]time.Time
 
-func watchWalker(files Files, local string, adds map[string]bool, lock sync.Mutex) filepath.WalkFunc {
-	return func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-
-		if info.IsDir() {
-			return nil
-		}
-
-		if files[path] != info.ModTime() {
-			files[path] = info.ModTime()
-
-			blockLocalAddsLock.Lock()
-
-			if blockLocalAdds[path] > 0 {
-				blockLocalAdds[path] -= 1
-			} else {
-				lock.Lock()
-				adds[path] = true
-				lock.Unlock()
-			}
-
-			blockLocalAddsLock.Unlock()
-		}
-
-		return nil
-	}
-}
-
-func processAdds(prefix string, adds map[string]bool, lock sync.Mutex, syncs []Sync) {
+func processAdds(adds map[string]bool, lock *sync.Mutex, syncs []Sync) {
 	dc, _ := docker.NewClientFromEnv()
 
 	for {
 		time.Sleep(1 * time.Second)
 
+		lock.Lock()
+
 		if len(adds) == 0 {
+			lock.Unlock()
 			continue
 		}
 
-		lock.Lock()
-
-		fmt.Printf(system("%s uploading %d files\n"), prefix, len(adds))
+		changed := map[string]Sync{}
 
 		for _, sync := range syncs {
 			var buf bytes.Buffer
 
 			tgz := tar.NewWriter(&buf)
 
+			count := 0
+			size := int64(0)
+
 			for local := range adds {
 				info, err := os.Stat(local)
 
@@ -1277,6 +1352,12 @@ func processAdds(prefix string, adds map[string]bool, lock sync.Mutex, syncs []S
 
 				rel, err := filepath.Rel(sync.Local, local)
 
+				if err != nil || strings.HasPrefix(rel, "..") {
+					continue
+				}
+
+				fd, err := os.Open(local)
+
 				if err != nil {
 					continue
 				}
@@ -1294,27 +1375,34 @@ func processAdds(prefix string, adds map[string]bool, lock sync.Mutex, syncs []S
 					ModTime: info.ModTime(),
 				})
 
-				fd, err := os.Open(local)
-
-				if err != nil {
-					continue
-				}
-
 				io.Copy(tgz, fd)
 				fd.Close()
+
+				count += 1
+				size += info.Size()
 			}
 
 			tgz.Close()
 
+			if count == 0 {
+				continue
+			}
+
+			start := time.Now()
+
 			err := dc.UploadToContainer(sync.Container, docker.UploadToContainerOptions{
 				InputStream: &buf,
 				Path:        "/",
 			})
 
 			if err != nil {
-				fmt.Printf("err: %+v\n", err)
+				fmt.Printf("%s %s\n", sync.Prefix, warning(fmt.Sprintf("sync failed: %s", err)))
 				continue
 			}
+
+			fmt.Printf("%s %s\n", sync.Prefix, system(fmt.Sprintf("synced %d files (%d bytes) to %s in %dms", count, size, sync.Remote, time.Since(start)/time.Millisecond)))
+
+			changed[sync.Container] = sync
 		}
 
 		for key := range adds {
@@ -1322,40 +1410,53 @@ func processAdds(prefix string, adds map[string]bool, lock sync.Mutex, syncs []S
 		}
 
 		lock.Unlock()
+
+		for _, sync := range changed {
+			sync.changed()
+		}
 	}
 }
 
-func processRemoves(prefix string, removes map[string]bool, lock sync.Mutex, syncs []Sync) {
+func processRemoves(removes map[string]bool, lock *sync.Mutex, syncs []Sync) {
 	dc, _ := docker.NewClientFromEnv()
 
 	for {
 		time.Sleep(1 * time.Second)
 
-		if len(removes) == 0 {
-			continue
-		}
-
-		cmd := []string{"rm", "-f"}
-
 		lock.Lock()
 
-		fmt.Printf(system(fmt.Sprintf("%s removing %d remote files\n", prefix, len(removes))))
+		files := []string{}
 
 		for file := range removes {
-			cmd = append(cmd, file)
+			files = append(files, file)
 			delete(removes, file)
 		}
 
 		lock.Unlock()
 
+		changed := map[string]Sync{}
+
 		for _, sync := range syncs {
+			// removed directories are removed with everything in them
+			cmd := []string{"rm", "-rf"}
+
+			for _, file := range files {
+				if strings.HasPrefix(file, sync.Remote+"/") {
+					cmd = append(cmd, file)
+				}
+			}
+
+			if len(cmd) == 2 {
+				continue
+			}
+
 			res, err := dc.CreateExec(docker.CreateExecOptions{
 				Container: sync.Container,
 				Cmd:       cmd,
 			})
 
 			if err != nil {
-				fmt.Printf("err: %+v\n", err)
+				fmt.Printf("%s %s\n", sync.Prefix, warning(fmt.Sprintf("sync failed: %s", err)))
 				continue
 			}
 
@@ -1364,13 +1465,17 @@ func processRemoves(prefix string, removes map[string]bool, lock sync.Mutex, syn
 			})
 
 			if err != nil {
-				fmt.Printf("err: %+v\n", err)
+				fmt.Printf("%s %s\n", sync.Prefix, warning(fmt.Sprintf("sync failed: %s", err)))
 				continue
 			}
+
+			fmt.Printf("%s %s\n", sync.Prefix, system(fmt.Sprintf("removed %d files from %s", len(cmd)-2, sync.Remote)))
+
+			changed[sync.Container] = sync
 		}
 
-		for key := range removes {
-			delete(removes, key)
+		for _, sync := range changed {
+			sync.changed()
 		}
 	}
 }
@@ -1379,11 +1484,75 @@ type Sync struct {
 	Container string
 	Local     string
 	Remote    string
+
+	// Prefix is the output prefix of the process the files are synced to
+	Prefix string
+
+	// Restart and Command are set by the convox.start.restart and
+	// convox.start.command-on-change labels of the process
+	Restart bool
+	Command string
 }
 
 var syncs = []Sync{}
 
-func registerSync(container, local, remote string) error {
+var (
+	restarts     = map[string]chan string{}
+	restartsLock sync.Mutex
+)
+
+// restartStarted returns a channel that receives the time a pending restart of a
+// container began once it has finished. The channel is closed when no restart is
+// pending or the restart failed.
+func restartStarted(container string) chan string {
+	restartsLock.Lock()
+	defer restartsLock.Unlock()
+
+	ch, ok := restarts[container]
+
+	if !ok {
+		ch = make(chan string)
+		close(ch)
+	}
+
+	delete(restarts, container)
+
+	return ch
+}
+
+// changed runs the command of a process and then restarts it, as set by its labels,
+// once files have been synced
+func (s Sync) changed() {
+	if s.Command != "" {
+		if err := runPrefix(s.Prefix, make(chan error, 1), "docker", "exec", s.Container, "sh", "-c", s.Command); err != nil {
+			fmt.Printf("%s %s\n", s.Prefix, warning(fmt.Sprintf("command on change failed: %s", err)))
+		}
+	}
+
+	if s.Restart {
+		fmt.Printf("%s %s\n", s.Prefix, system("restarting after sync"))
+
+		// register the restart before it stops the container so the process runner
+		// follows the restarted container instead of treating the exit as the end
+		ready := make(chan string, 1)
+
+		restartsLock.Lock()
+		restarts[s.Container] = ready
+		restartsLock.Unlock()
+
+		since := strconv.FormatInt(time.Now().Unix(), 10)
+
+		if err := Execer("docker", "restart", "-t", "1", s.Container).Run(); err != nil {
+			fmt.Printf("%s %s\n", s.Prefix, warning(fmt.Sprintf("restart failed: %s", err)))
+			close(ready)
+			return
+		}
+
+		ready <- since
+	}
+}
+
+func registerSync(s Sync, local, remote string) error {
 	syscall.Setrlimit(syscall.RLIMIT_NOFILE, &syscall.Rlimit{
 		Max: 999999,
 		Cur: 999999,
@@ -1401,11 +1570,10 @@ func registerSync(container, local, remote string) error {
 		return err
 	}
 
-	syncs = append(syncs, Sync{
-		Container: container,
-		Local:     sym,
-		Remote:    remote,
-	})
+	s.Local = sym
+	s.Remote = remote
+
+	syncs = append(syncs, s)
 
 	return nil
 }
@@ -1480,7 +1648,7 @@ func (m *Manifest) scanRemoteChanges(container string, r io.Reader) {
 				local := filepath.Join(sync.Local, parts[2])
 				remote := filepath.Join(parts[1], parts[2])
 
-				if sync.Remote == parts[1] {
+				if sync.Remote == parts[1] && !syncIgnored(local, false) {
 					switch parts[0] {
 					case "add":
 						blockRemoteAddsLock.Lock()
@@ -1632,6 +1800,10 @@ func (m *Manifest) downloadRemoteAdds(container string, adds map[string]string)
 }
 
 func (m *Manifest) syncFiles() error {
+	if err := loadSyncIgnore("."); err != nil {
+		return err
+	}
+
 	watches := map[string][]Sync{}
 	candidates := []string{}
 
@@ -1672,49 +1844,46 @@ func (m *Manifest) syncFiles() error {
 }
 
 func (m *Manifest) processSync(local string, syncs []Sync) error {
-	files := Files{}
-
 	adds := map[string]bool{}
 	removes := map[string]bool{}
 
 	var alock, rlock sync.Mutex
 
-	go processAdds(m.systemPrefix(), adds, alock, syncs)
-	go processRemoves(m.systemPrefix(), removes, rlock, syncs)
-
-	filepath.Walk(local, func(path string, info os.FileInfo, err error) error {
-		if info != nil {
-			files[path] = info.ModTime()
-		}
-		return nil
-	})
-
-	for {
-		err := filepath.Walk(local, watchWalker(files, local, adds, alock))
-		for _, sync := range syncs {
-			if err != nil {
-				fmt.Printf("err: %+v\n", err)
-				continue
-			}
+	go processAdds(adds, &alock, syncs)
+	go processRemoves(removes, &rlock, syncs)
 
-			for file := range files {
-				if _, err := os.Stat(file); os.IsNotExist(err) {
-					rel, err := filepath.Rel(local, file)
+	changes := make(chan fileChange)
 
-					if err != nil {
-						continue
-					}
+	go watchFiles(local, changes)
 
-					rlock.Lock()
-					removes[filepath.Join(sync.Remote, rel)] = true
-					rlock.Unlock()
+	for change := range changes {
+		if change.Remove {
+			for _, sync := range syncs {
+				rel, err := filepath.Rel(sync.Local, change.Path)
 
-					delete(files, file)
+				if err != nil {
+					continue
 				}
+
+				rlock.Lock()
+				removes[filepath.Join(sync.Remote, rel)] = true
+				rlock.Unlock()
 			}
+
+			continue
 		}
 
-		time.Sleep(900 * time.Millisecond)
+		blockLocalAddsLock.Lock()
+
+		if blockLocalAdds[change.Path] > 0 {
+			blockLocalAdds[change.Path] -= 1
+		} else {
+			alock.Lock()
+			adds[change.Path] = true
+			alock.Unlock()
+		}
+
+		blockLocalAddsLock.Unlock()
 	}
 
 	return nil
@@ -1733,24 +1902,131 @@ func warnIfRoot(volume string) {
 
 // init
 
-func detectApplication(dir string) string {
+// Application is the kind of app found in a directory along with the details the init
+// templates need to build and run it
+type Application struct {
+	Kind      string
+	Framework string
+	Installer string
+	Main      string
+	Name      string
+
+	// Port is the port the web process listens on, 0 uses the convox proxy defaults
+	Port int
+}
+
+func (a Application) String() string {
+	details := []string{}
+
+	for _, d := range []string{a.Framework, a.Installer} {
+		if d != "" {
+			details = append(details, strings.TrimPrefix(d, "./"))
+		}
+	}
+
+	if len(details) == 0 {
+		return a.Kind
+	}
+
+	return fmt.Sprintf("%s (%s)", a.Kind, strings.Join(details, ", "))
+}
+
+func detectApplication(dir string) Application {
 	switch {
 	// case exists(filepath.Join(dir, ".meteor")):
 	//   return "meteor"
-	// case exists(filepath.Join(dir, "package.json")):
-	//   return "node"
 	case exists(filepath.Join(dir, "config/application.rb")):
-		return "rails"
+		return Application{Kind: "rails"}
 	case exists(filepath.Join(dir, "config.ru")):
-		return "sinatra"
+		return Application{Kind: "sinatra"}
 	case exists(filepath.Join(dir, "Gemfile.lock")):
-		return "ruby"
+		return Application{Kind: "ruby"}
+	case exists(filepath.Join(dir, "package.json")):
+		return detectNode(dir)
+	case exists(filepath.Join(dir, "requirements.txt")), exists(filepath.Join(dir, "Pipfile")):
+		return detectPython(dir)
+	case exists(filepath.Join(dir, "go.mod")):
+		return detectGo(dir)
+	case exists(filepath.Join(dir, "pom.xml")), exists(filepath.Join(dir, "build.gradle")), exists(filepath.Join(dir, "build.gradle.kts")):
+		return detectJava(dir)
+	case exists(filepath.Join(dir, "composer.json")):
+		return Application{Kind: "php", Installer: "composer", Port: 80}
+	}
+
+	return Application{Kind: "unknown"}
+}
+
+func detectNode(dir string) Application {
+	a := Application{Kind: "node", Installer: "npm", Port: 3000}
+
+	if exists(filepath.Join(dir, "yarn.lock")) {
+		a.Installer = "yarn"
+	}
+
+	return a
+}
+
+func detectPython(dir string) Application {
+	a := Application{Kind: "python", Installer: "pip", Port: 8000}
+
+	deps := "requirements.txt"
+
+	if exists(filepath.Join(dir, "Pipfile")) {
+		a.Installer = "pipenv"
+		deps = "Pipfile"
+	}
+
+	data, _ := ioutil.ReadFile(filepath.Join(dir, deps))
+
+	switch {
+	case exists(filepath.Join(dir, "manage.py")):
+		a.Framework = "django"
+	case strings.Contains(strings.ToLower(string(data)), "flask"):
+		a.Framework = "flask"
+		a.Main = "app.py"
+
+		for _, main := range []string{"app.py", "wsgi.py", "main.py"} {
+			if exists(filepath.Join(dir, main)) {
+				a.Main = main
+				break
+			}
+		}
+	}
+
+	return a
+}
+
+var reGoModule = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+func detectGo(dir string) Application {
+	a := Application{Kind: "go", Installer: "go", Name: "app", Port: 8080}
+
+	data, _ := ioutil.ReadFile(filepath.Join(dir, "go.mod"))
+
+	if m := reGoModule.FindStringSubmatch(string(data)); len(m) == 2 {
+		a.Name = filepath.Base(m[1])
 	}
 
-	return "unknown"
+	return a
+}
+
+func detectJava(dir string) Application {
+	a := Application{Kind: "java", Installer: "maven", Port: 8080}
+
+	switch {
+	case exists(filepath.Join(dir, "pom.xml")):
+	case exists(filepath.Join(dir, "gradlew")):
+		a.Installer = "./gradlew"
+	default:
+		a.Installer = "gradle"
+	}
+
+	return a
 }
 
 func initApplication(dir string) error {
+	app := detectApplication(dir)
+
 	wd, err := os.Getwd()
 
 	if err != nil {
@@ -1765,29 +2041,40 @@ func initApplication(dir string) error {
 		return nil
 	}
 
-	kind := detectApplication(dir)
+	fmt.Printf("Initializing %s\n", app)
 
-	fmt.Printf("Initializing %s\n", kind)
+	var df *Dockerfile
 
-	// TODO parse the Dockerfile and build a docker-compose.yml
-	if !exists("Dockerfile") {
-		if err := writeAsset("Dockerfile", fmt.Sprintf("init/%s/Dockerfile", kind)); err != nil {
+	if exists("Dockerfile") {
+		if df, err = readDockerfile("Dockerfile"); err != nil {
+			return err
+		}
+	} else {
+		if err := writeAsset("Dockerfile", fmt.Sprintf("init/%s/Dockerfile", app.Kind), app); err != nil {
 			return err
 		}
 	}
 
-	if err := generateManifest(dir, fmt.Sprintf("init/%s/docker-compose.yml", kind)); err != nil {
+	if err := generateManifest(dir, app, df); err != nil {
 		return err
 	}
 
-	if err := writeAsset(".dockerignore", fmt.Sprintf("init/%s/.dockerignore", kind)); err != nil {
+	if err := writeAsset(".dockerignore", fmt.Sprintf("init/%s/.dockerignore", app.Kind), app); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func generateManifest(dir string, def string) error {
+// generateManifest writes a docker-compose.yml built from an existing Dockerfile and a
+// Procfile, falling back to the template for the kind of app when there are neither
+func generateManifest(dir string, app Application, df *Dockerfile) error {
+	if df == nil && !exists("Procfile") {
+		return writeAsset("docker-compose.yml", fmt.Sprintf("init/%s/docker-compose.yml", app.Kind), app)
+	}
+
+	m := Manifest{}
+
 	if exists("Procfile") {
 		pf, err := readProcfile("Procfile")
 
@@ -1795,42 +2082,112 @@ func generateManifest(dir string, def string) error {
 			return err
 		}
 
-		m := Manifest{}
-
 		for _, e := range pf {
 			me := ManifestEntry{
 				Build:   ".",
 				Command: e.Command,
 			}
 
-			switch e.Name {
-			case "web":
-				me.Labels = []string{
-					"convox.port.443.protocol=tls",
-					"convox.port.443.proxy=true",
+			if e.Name == "web" {
+				if app.Port > 0 {
+					me.Environment = []string{
+						fmt.Sprintf("PORT=%d", app.Port),
+					}
 				}
 
-				me.Ports = []string{
-					"80:4000",
-					"443:4001",
-				}
+				me.balance(app.Port)
 			}
 
 			m[e.Name] = me
 		}
+	}
 
-		data, err := yaml.Marshal(m)
+	// without a web process in the Procfile only add one if the Dockerfile can run it
+	if _, ok := m["web"]; df != nil && (ok || len(m) == 0 || df.Runs()) {
+		m["web"] = df.web(m["web"], app.Port)
+	}
 
-		if err != nil {
-			return err
+	data, err := yaml.Marshal(m)
+
+	if err != nil {
+		return err
+	}
+
+	return writeFile("docker-compose.yml", data, 0644)
+}
+
+// balance adds the ports and labels that put a web process behind the balancer. Without
+// a port the app is expected to listen on 4000 and on 4001 behind the https proxy.
+func (me *ManifestEntry) balance(port int) {
+	if port == 0 {
+		me.Labels = []string{
+			"convox.port.443.protocol=tls",
+			"convox.port.443.proxy=true",
+		}
+
+		me.Ports = []string{
+			"80:4000",
+			"443:4001",
+		}
+
+		return
+	}
+
+	me.Labels = []string{
+		"convox.port.443.protocol=tls",
+	}
+
+	me.Ports = []string{
+		fmt.Sprintf("80:%d", port),
+		fmt.Sprintf("443:%d", port),
+	}
+}
+
+// web applies the Dockerfile to the web process. A command from the Procfile is kept and
+// exposed ports replace the default port for the kind of app.
+func (df *Dockerfile) web(me ManifestEntry, port int) ManifestEntry {
+	me.Build = "."
+
+	if me.Command == nil && df.Command != nil {
+		me.Command = df.Command
+	}
+
+	switch e := df.Entrypoint.(type) {
+	case string:
+		me.Entrypoint = e
+	case []string:
+		me.Entrypoint = strings.Join(e, " ")
+	}
+
+	if len(df.Env) > 0 {
+		me.Environment = df.Env
+	}
+
+	if len(df.Volumes) > 0 {
+		me.Volumes = df.Volumes
+	}
+
+	if len(df.Expose) == 0 {
+		if me.Ports == nil && port > 0 {
+			me.balance(port)
 		}
 
-		// write the generated docker-compose.yml and return
-		return writeFile("docker-compose.yml", data, 0644)
+		return me
+	}
+
+	me.balance(df.Port())
+
+	ports := me.Ports.([]string)
+
+	for _, port := range df.Expose[1:] {
+		if port != 80 && port != 443 {
+			ports = append(ports, fmt.Sprintf("%d:%d", port, port))
+		}
 	}
 
-	// write the default if we get here
-	return writeAsset("docker-compose.yml", def)
+	me.Ports = ports
+
+	return me
 }
 
 type ProcfileEntry struct {
@@ -1889,18 +2246,31 @@ func writeFile(path string, data []byte, mode os.FileMode) error {
 	return nil
 }
 
-func writeAsset(path, template string) error {
-	data, err := templates.Asset(template)
+// writeAsset renders a template from cmd/convox/templates with the details of the app
+func writeAsset(path, asset string, app Application) error {
+	data, err := templates.Asset(asset)
 
 	if err != nil {
 		return err
 	}
 
-	info, err := templates.AssetInfo(template)
+	info, err := templates.AssetInfo(asset)
 
 	if err != nil {
 		return err
 	}
 
-	return writeFile(path, data, info.Mode())
+	t, err := template.New(asset).Parse(string(data))
+
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	if err := t.Execute(&buf, app); err != nil {
+		return err
+	}
+
+	return writeFile(path, buf.Bytes(), info.Mode())
 }
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/fatih/color"
@@ -87,12 +88,47 @@ postgres:
 	_ = yaml.Unmarshal(data, &m)
 
 	cases := Cases{
-		{m.runOrder(), []string{"postgres", "redis", "web", "worker_1", "worker_2"}},
+		{runOrder(t, &m), []string{"postgres", "redis", "web", "worker_1", "worker_2"}},
 	}
 
 	_assert(t, cases)
 }
 
+func TestRunOrderInvalidDependencies(t *testing.T) {
+	m := Manifest{
+		"web": ManifestEntry{Links: []string{"database"}},
+	}
+
+	_, err := m.runOrder()
+
+	if err == nil || err.Error() != "web depends on database which is not in the manifest" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m = Manifest{
+		"web":    ManifestEntry{DependsOn: []string{"worker"}},
+		"worker": ManifestEntry{Links: []string{"web"}},
+		"redis":  ManifestEntry{Image: "convox/redis"},
+	}
+
+	_, err = m.runOrder()
+
+	if err == nil || err.Error() != "circular dependency between web, worker" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// runOrder returns the run order of a manifest, failing the test on an error
+func runOrder(t *testing.T, m *Manifest) []string {
+	order, err := m.runOrder()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return order
+}
+
 type TestCommand struct {
 	Command string
 	Args    []string
@@ -171,7 +207,7 @@ worker:
   build: .
   command: ruby worker.rb
 `},
-		{[]string{"web", "worker"}, m.runOrder()},
+		{[]string{"web", "worker"}, runOrder(t, m)},
 	}
 
 	_assert(t, cases)
@@ -192,22 +228,172 @@ func TestGenerateDockerfileProcfile(t *testing.T) {
 		{readFile(t, destDir, "docker-compose.yml"), `web:
   build: .
   command: ruby web.rb
+  environment:
+  - PORT=3000
   labels:
   - convox.port.443.protocol=tls
-  - convox.port.443.proxy=true
   ports:
-  - 80:4000
-  - 443:4001
+  - 80:3000
+  - 443:3000
 worker:
   build: .
   command: ruby worker.rb
 `},
-		{[]string{"web", "worker"}, m.runOrder()},
+		{[]string{"web", "worker"}, runOrder(t, m)},
 	}
 
 	_assert(t, cases)
 }
 
+func TestDetectApplication(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  Application
+	}{
+		{[]string{"config/application.rb", "package.json"}, Application{Kind: "rails"}},
+		{[]string{"package.json"}, Application{Kind: "node", Installer: "npm", Port: 3000}},
+		{[]string{"package.json", "yarn.lock"}, Application{Kind: "node", Installer: "yarn", Port: 3000}},
+		{[]string{"requirements.txt", "manage.py"}, Application{Kind: "python", Framework: "django", Installer: "pip", Port: 8000}},
+		{[]string{"Pipfile"}, Application{Kind: "python", Installer: "pipenv", Port: 8000}},
+		{[]string{"go.mod"}, Application{Kind: "go", Installer: "go", Name: "widgets", Port: 8080}},
+		{[]string{"pom.xml"}, Application{Kind: "java", Installer: "maven", Port: 8080}},
+		{[]string{"build.gradle", "gradlew"}, Application{Kind: "java", Installer: "./gradlew", Port: 8080}},
+		{[]string{"build.gradle.kts"}, Application{Kind: "java", Installer: "gradle", Port: 8080}},
+		{[]string{"composer.json"}, Application{Kind: "php", Installer: "composer", Port: 80}},
+		{[]string{"README.md"}, Application{Kind: "unknown"}},
+	}
+
+	for _, test := range tests {
+		dir := mkFiles(t, test.files, map[string]string{"go.mod": "module github.com/example/widgets\n"})
+
+		if got := detectApplication(dir); got != test.want {
+			t.Errorf("detectApplication(%v) = %+v, want %+v", test.files, got, test.want)
+		}
+
+		os.RemoveAll(dir)
+	}
+}
+
+func TestDetectApplicationFlask(t *testing.T) {
+	dir := mkFiles(t, []string{"requirements.txt", "wsgi.py"}, map[string]string{"requirements.txt": "Flask==1.0.2\n"})
+	defer os.RemoveAll(dir)
+
+	want := Application{Kind: "python", Framework: "flask", Installer: "pip", Main: "wsgi.py", Port: 8000}
+
+	if got := detectApplication(dir); got != want {
+		t.Errorf("detectApplication() = %+v, want %+v", got, want)
+	}
+}
+
+func TestInitNode(t *testing.T) {
+	dir := mkFiles(t, []string{"package.json", "yarn.lock"}, nil)
+	defer os.RemoveAll(dir)
+
+	Init(dir)
+
+	cases := Cases{
+		{readFile(t, dir, "docker-compose.yml"), `web:
+  build: .
+  command: yarn start
+  environment:
+    - PORT=3000
+  labels:
+    - convox.port.443.protocol=tls
+  ports:
+    - 80:3000
+    - 443:3000
+`},
+		{strings.Contains(readFile(t, dir, "Dockerfile"), "RUN yarn install --pure-lockfile"), true},
+		{exists(filepath.Join(dir, ".dockerignore")), true},
+	}
+
+	_assert(t, cases)
+}
+
+func TestInitProcfileWithPort(t *testing.T) {
+	dir := mkFiles(t, []string{"requirements.txt", "Procfile"}, map[string]string{"Procfile": "web: gunicorn app:app\nworker: celery worker\n"})
+	defer os.RemoveAll(dir)
+
+	Init(dir)
+
+	cases := Cases{
+		{readFile(t, dir, "docker-compose.yml"), `web:
+  build: .
+  command: gunicorn app:app
+  environment:
+  - PORT=8000
+  labels:
+  - convox.port.443.protocol=tls
+  ports:
+  - 80:8000
+  - 443:8000
+worker:
+  build: .
+  command: celery worker
+`},
+	}
+
+	_assert(t, cases)
+}
+
+func TestInitDockerfile(t *testing.T) {
+	dir := mkFiles(t, []string{"Dockerfile", "Procfile"}, map[string]string{
+		"Dockerfile": "FROM alpine\nENV RACK_ENV=production \\\n    LOG_LEVEL=info\nEXPOSE 5000 9000/tcp 53/udp\nVOLUME [\"/data\"]\nENTRYPOINT [\"bin/entry\"]\nCMD bin/web\n",
+		"Procfile":   "worker: bin/worker\n",
+	})
+	defer os.RemoveAll(dir)
+
+	Init(dir)
+
+	cases := Cases{
+		{readFile(t, dir, "docker-compose.yml"), `web:
+  build: .
+  command: bin/web
+  entrypoint: bin/entry
+  environment:
+  - RACK_ENV=production
+  - LOG_LEVEL=info
+  labels:
+  - convox.port.443.protocol=tls
+  ports:
+  - 80:5000
+  - 443:5000
+  - 9000:9000
+  volumes:
+  - /data
+worker:
+  build: .
+  command: bin/worker
+`},
+	}
+
+	_assert(t, cases)
+}
+
+// mkFiles creates a temporary directory containing files with the given contents, empty
+// unless listed in contents
+func mkFiles(t *testing.T, files []string, contents map[string]string) string {
+	dir, err := ioutil.TempDir("", "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range files {
+		path := filepath.Join(dir, f)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ioutil.WriteFile(path, []byte(contents[f]), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
 func mkBuildDir(t *testing.T, srcDir string) string {
 	destDir, err := ioutil.TempDir("", "")
 
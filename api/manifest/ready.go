@@ -0,0 +1,183 @@
+package manifest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// processes that others link to or depend on are checked with these before the
+// dependents start, see waitReady
+var (
+	ReadyInterval = 1 * time.Second
+	ReadyTimeout  = 60 * time.Second
+)
+
+// dependencies returns the processes that a process links to or depends on
+func (me ManifestEntry) dependencies() []string {
+	deps := []string{}
+
+	for _, link := range me.Links {
+		deps = append(deps, strings.Split(link, ":")[0])
+	}
+
+	return append(deps, me.DependsOn...)
+}
+
+// dependedOn returns true if any process links to or depends on the named process
+func (m *Manifest) dependedOn(name string) bool {
+	for _, me := range *m {
+		for _, dep := range me.dependencies() {
+			if dep == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// waitReady blocks until a process passes its readiness check or its timeout passes.
+// The convox.start.ready.command label runs a command in the container, the
+// convox.start.ready.path label requests a path over http and otherwise the first port
+// is expected to accept connections. Processes without a check or ports are ready once
+// started.
+func (me ManifestEntry) waitReady(app, process, prefix string) error {
+	timeout := ReadyTimeout
+
+	if t := me.Label("convox.start.ready.timeout"); t != "" {
+		secs, err := strconv.Atoi(t)
+
+		if err != nil {
+			return fmt.Errorf("invalid convox.start.ready.timeout: %s", t)
+		}
+
+		timeout = time.Duration(secs) * time.Second
+	}
+
+	check, desc := me.readyCheck(containerName(app, process))
+
+	if check == nil {
+		return nil
+	}
+
+	fmt.Printf("%s %s\n", prefix, system(fmt.Sprintf("waiting for %s", desc)))
+
+	start := time.Now()
+
+	for {
+		err := check()
+
+		if err == nil {
+			fmt.Printf("%s %s\n", prefix, system(fmt.Sprintf("ready after %ds", time.Since(start)/time.Second)))
+			return nil
+		}
+
+		if time.Since(start) >= timeout {
+			return fmt.Errorf("not ready after %s: %s", timeout, err)
+		}
+
+		time.Sleep(ReadyInterval)
+	}
+}
+
+func (me ManifestEntry) readyCheck(container string) (func() error, string) {
+	if cmd := me.Label("convox.start.ready.command"); cmd != "" {
+		return func() error {
+			return Execer("docker", "exec", container, "sh", "-c", cmd).Run()
+		}, fmt.Sprintf("command: %s", cmd)
+	}
+
+	ports := me.containerPorts()
+
+	if len(ports) == 0 {
+		return nil, ""
+	}
+
+	port := ports[0]
+
+	if path := me.Label("convox.start.ready.path"); path != "" {
+		return func() error {
+			host, err := publishedAddress(container, port)
+
+			if err != nil {
+				return err
+			}
+
+			res, err := http.Get(fmt.Sprintf("http://%s%s", host, path))
+
+			if err != nil {
+				return err
+			}
+
+			res.Body.Close()
+
+			if res.StatusCode >= 400 {
+				return fmt.Errorf("status %d from %s", res.StatusCode, path)
+			}
+
+			return nil
+		}, fmt.Sprintf("http on port %s at %s", port, path)
+	}
+
+	return func() error {
+		host, err := publishedAddress(container, port)
+
+		if err != nil {
+			return err
+		}
+
+		conn, err := net.DialTimeout("tcp", host, ReadyInterval)
+
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}, fmt.Sprintf("port %s", port)
+}
+
+// containerPorts returns the container side of each declared port
+func (me ManifestEntry) containerPorts() []string {
+	ports := []string{}
+
+	switch t := me.Ports.(type) {
+	case []string:
+		for _, port := range t {
+			ports = append(ports, port)
+		}
+	case []interface{}:
+		for _, port := range t {
+			ports = append(ports, fmt.Sprintf("%v", port))
+		}
+	}
+
+	for i, port := range ports {
+		parts := strings.Split(port, ":")
+		ports[i] = parts[len(parts)-1]
+	}
+
+	return ports
+}
+
+// publishedAddress returns the address on the docker host that a container port is
+// published to
+func publishedAddress(container, port string) (string, error) {
+	out, err := Execer("docker", "port", container, port).Output()
+
+	if err != nil {
+		return "", fmt.Errorf("port %s is not published", port)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	i := strings.LastIndex(lines[0], ":")
+
+	if i == -1 {
+		return "", fmt.Errorf("port %s is not published", port)
+	}
+
+	return net.JoinHostPort(dockerHost(), lines[0][i+1:]), nil
+}
@@ -0,0 +1,168 @@
+package manifest
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestRunOrderDependsOn(t *testing.T) {
+	var m Manifest
+	data := []byte(`web:
+  depends_on:
+    - worker
+  links:
+    - postgres
+worker:
+  links:
+    - redis
+redis:
+  image: convox/redis
+postgres:
+  image: convox/postgres
+`)
+
+	_ = yaml.Unmarshal(data, &m)
+
+	cases := Cases{
+		{runOrder(t, &m), []string{"postgres", "redis", "worker", "web"}},
+		{m.dependedOn("worker"), true},
+		{m.dependedOn("postgres"), true},
+		{m.dependedOn("web"), false},
+	}
+
+	_assert(t, cases)
+}
+
+func TestWaitReadyCommand(t *testing.T) {
+	defer stubReady(t)()
+
+	attempts := 0
+
+	Execer = func(bin string, args ...string) *exec.Cmd {
+		attempts++
+
+		if attempts < 3 {
+			return exec.Command("false")
+		}
+
+		return exec.Command("true")
+	}
+
+	me := ManifestEntry{Labels: []interface{}{"convox.start.ready.command=pg_isready"}}
+
+	err := me.waitReady("myapp", "postgres", "postgres |")
+
+	cases := Cases{
+		{err, nil},
+		{attempts, 3},
+	}
+
+	_assert(t, cases)
+}
+
+func TestWaitReadyTimeout(t *testing.T) {
+	defer stubReady(t)()
+
+	Execer = func(bin string, args ...string) *exec.Cmd {
+		return exec.Command("false")
+	}
+
+	me := ManifestEntry{Labels: []interface{}{"convox.start.ready.command=pg_isready", "convox.start.ready.timeout=0"}}
+
+	err := me.waitReady("myapp", "postgres", "postgres |")
+
+	if err == nil || !strings.HasPrefix(err.Error(), "not ready after 0s") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitReadyPort(t *testing.T) {
+	defer stubReady(t)()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer ln.Close()
+
+	ports := []string{}
+
+	Execer = func(bin string, args ...string) *exec.Cmd {
+		ports = append(ports, strings.Join(args, " "))
+		return exec.Command("echo", "0.0.0.0:"+strings.Split(ln.Addr().String(), ":")[1])
+	}
+
+	me := ManifestEntry{Ports: []interface{}{"5432"}}
+
+	cases := Cases{
+		{me.waitReady("myapp", "postgres", "postgres |"), nil},
+		{ports, []string{"port myapp-postgres 5432"}},
+	}
+
+	_assert(t, cases)
+}
+
+func TestWaitReadyPath(t *testing.T) {
+	defer stubReady(t)()
+
+	requests := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/health" || requests < 2 {
+			http.Error(w, "starting", 503)
+		}
+	}))
+
+	defer ts.Close()
+
+	Execer = func(bin string, args ...string) *exec.Cmd {
+		return exec.Command("echo", "0.0.0.0:"+strings.Split(ts.Listener.Addr().String(), ":")[1])
+	}
+
+	me := ManifestEntry{
+		Labels: []interface{}{"convox.start.ready.path=/health"},
+		Ports:  []interface{}{"80:3000"},
+	}
+
+	cases := Cases{
+		{me.waitReady("myapp", "web", "web |"), nil},
+		{requests, 2},
+	}
+
+	_assert(t, cases)
+}
+
+func TestWaitReadyNoPorts(t *testing.T) {
+	me := ManifestEntry{Image: "convox/worker"}
+
+	if err := me.waitReady("myapp", "worker", "worker |"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// stubReady speeds up readiness checks and points them at the local host
+func stubReady(t *testing.T) func() {
+	host := os.Getenv("DOCKER_HOST")
+	interval := ReadyInterval
+
+	os.Setenv("DOCKER_HOST", "tcp://127.0.0.1:2376")
+	ReadyInterval = 10 * time.Millisecond
+
+	return func() {
+		os.Setenv("DOCKER_HOST", host)
+		ReadyInterval = interval
+		Execer = exec.Command
+	}
+}
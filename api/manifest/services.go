@@ -0,0 +1,174 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Service is a rack service linked to an app that convox start runs a local stand-in for
+type Service struct {
+	Name string
+	Type string
+}
+
+// credentials used by the local stand-ins
+const (
+	standInUser     = "convox"
+	standInPassword = "password"
+)
+
+// standIns are the containers that take the place of rack services during local
+// development. The LINK_ variables give the same <NAME>_URL, <NAME>_HOST etc that a link
+// resolves to on the rack.
+var standIns = map[string]func(s Service) ManifestEntry{
+	"postgres": func(s Service) ManifestEntry {
+		return ManifestEntry{
+			Image: "postgres:9.6",
+			Environment: []interface{}{
+				"POSTGRES_USER=" + standInUser,
+				"POSTGRES_PASSWORD=" + standInPassword,
+				"POSTGRES_DB=app",
+				"LINK_SCHEME=postgres",
+				"LINK_USERNAME=" + standInUser,
+				"LINK_PASSWORD=" + standInPassword,
+				"LINK_PATH=/app",
+			},
+			Labels: []interface{}{
+				"convox.start.ready.command=pg_isready -h localhost -U " + standInUser,
+			},
+			Ports: []interface{}{"5432"},
+		}
+	},
+	"redis": func(s Service) ManifestEntry {
+		return ManifestEntry{
+			Image: "redis:3.2",
+			Environment: []interface{}{
+				"LINK_SCHEME=redis",
+				"LINK_PATH=/0",
+			},
+			Labels: []interface{}{
+				"convox.start.ready.command=redis-cli ping",
+			},
+			Ports: []interface{}{"6379"},
+		}
+	},
+	"s3": func(s Service) ManifestEntry {
+		return ManifestEntry{
+			Image:      "minio/minio",
+			Entrypoint: "sh",
+			Command:    []string{"-c", fmt.Sprintf("mkdir -p /data/%s && minio server /data", s.Name)},
+			Environment: []interface{}{
+				"MINIO_ACCESS_KEY=" + standInUser,
+				"MINIO_SECRET_KEY=" + standInPassword,
+				"LINK_SCHEME=s3",
+				"LINK_USERNAME=" + standInUser,
+				"LINK_PASSWORD=" + standInPassword,
+				"LINK_PATH=/" + s.Name,
+			},
+			Ports: []interface{}{"9000"},
+		}
+	},
+	"sqs": func(s Service) ManifestEntry {
+		return ManifestEntry{
+			Image: "softwaremill/elasticmq",
+			Environment: []interface{}{
+				"LINK_SCHEME=sqs",
+				"LINK_USERNAME=" + standInUser,
+				"LINK_PASSWORD=" + standInPassword,
+				"LINK_PATH=/queue/" + s.Name,
+			},
+			Ports: []interface{}{"9324"},
+		}
+	},
+}
+
+// ReadServices reads the services declared for local development in .convox/services,
+// one <name>: <type> per line. It returns nil if the file does not exist.
+func ReadServices(dir string) ([]Service, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, ".convox", "services"))
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	services := []Service{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := reProcfile.FindStringSubmatch(line)
+
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid service declaration: %s", line)
+		}
+
+		services = append(services, Service{Name: parts[1], Type: strings.TrimSpace(parts[2])})
+	}
+
+	return services, nil
+}
+
+// AddServices adds a local stand-in for each service and links every process to them.
+// Services without a stand-in are skipped with a warning.
+func (m *Manifest) AddServices(services []Service) error {
+	added := []string{}
+
+	for _, s := range services {
+		if _, ok := (*m)[s.Name]; ok {
+			return fmt.Errorf("service %s has the same name as a process", s.Name)
+		}
+
+		if _, ok := standIns[s.Type]; !ok {
+			fmt.Println(warning(fmt.Sprintf("WARNING: no local stand-in for %s service %s, skipping", s.Type, s.Name)))
+			continue
+		}
+
+		added = append(added, s.Name)
+	}
+
+	sort.Strings(added)
+
+	for name, me := range *m {
+		for _, s := range added {
+			if !me.linked(s) {
+				me.Links = append(me.Links, s)
+			}
+		}
+
+		(*m)[name] = me
+	}
+
+	for _, s := range services {
+		if standIn, ok := standIns[s.Type]; ok {
+			(*m)[s.Name] = standIn(s)
+		}
+	}
+
+	return nil
+}
+
+func (me ManifestEntry) linked(name string) bool {
+	for _, link := range me.Links {
+		if strings.Split(link, ":")[0] == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,598 @@
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Problem is an error or warning found while validating a manifest
+type Problem struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Level   string `json:"level"`
+	Process string `json:"process,omitempty"`
+	Message string `json:"message"`
+}
+
+type Problems []Problem
+
+var (
+	regexCronName   = regexp.MustCompile(`\A[a-zA-Z0-9]+\z`)
+	regexEnvName    = regexp.MustCompile(`\A[a-zA-Z_][a-zA-Z0-9_]*\z`)
+	regexYamlLine   = regexp.MustCompile(`line (\d+)`)
+	regexPortLabel  = regexp.MustCompile(`\Aconvox\.port\.([^.]+)\.(acme|protocol|proxy|secure)\z`)
+	regexStartLabel = regexp.MustCompile(`\Aconvox\.start\.(shift|restart|command-on-change|ready\.command|ready\.path|ready\.timeout)\z`)
+)
+
+func (p Problem) String() string {
+	s := fmt.Sprintf("%s: ", p.Level)
+
+	if p.Line > 0 {
+		s = fmt.Sprintf("%d:%d: %s", p.Line, p.Column, s)
+	}
+
+	if p.Process != "" {
+		s += fmt.Sprintf("%s: ", p.Process)
+	}
+
+	return s + p.Message
+}
+
+// Errors returns the number of problems that would stop the manifest from deploying
+func (pp Problems) Errors() int {
+	n := 0
+
+	for _, p := range pp {
+		if p.Level == "error" {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (pp Problems) Len() int      { return len(pp) }
+func (pp Problems) Swap(i, j int) { pp[i], pp[j] = pp[j], pp[i] }
+
+func (pp Problems) Less(i, j int) bool {
+	if pp[i].Line != pp[j].Line {
+		return pp[i].Line < pp[j].Line
+	}
+
+	return pp[i].Column < pp[j].Column
+}
+
+// validator walks the raw yaml of a manifest and records problems along with
+// their position, which yaml.v2 does not expose so it is found in the text
+type validator struct {
+	lines    []string
+	problems Problems
+	section  int
+}
+
+type validatedEntry struct {
+	name     string
+	balancer map[string]bool
+	labels   yaml.MapSlice
+	links    []string
+	depends  []string
+}
+
+// Validate checks a manifest for problems that would otherwise surface during
+// a build, a release or a CloudFormation update. Environment references are
+// checked against env unless it is nil.
+func Validate(data []byte, env map[string]string) Problems {
+	v := &validator{lines: strings.Split(string(data), "\n"), problems: Problems{}}
+
+	var raw yaml.MapSlice
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		line := 0
+
+		if m := regexYamlLine.FindStringSubmatch(err.Error()); len(m) == 2 {
+			line, _ = strconv.Atoi(m[1])
+		}
+
+		v.problems = append(v.problems, Problem{Line: line, Column: 1, Level: "error", Message: strings.TrimPrefix(err.Error(), "yaml: ")})
+		return v.problems
+	}
+
+	services := raw
+
+	if _, ok := lookup(raw, "version"); ok {
+		services = yaml.MapSlice{}
+
+		for _, item := range raw {
+			key := fmt.Sprintf("%v", item.Key)
+
+			switch key {
+			case "version", "networks":
+			case "services":
+				ss, ok := item.Value.(yaml.MapSlice)
+				if !ok {
+					v.errorf("", v.find("", "services:"), "services must be a map")
+					continue
+				}
+				services = ss
+				v.section = v.find("", "services:").line
+			default:
+				v.warnf("", v.find("", key+":"), "unknown key: %s", key)
+			}
+		}
+	}
+
+	if len(services) == 0 {
+		v.errorf("", position{1, 1}, "no processes defined")
+		return v.problems
+	}
+
+	entries := []validatedEntry{}
+
+	for _, item := range services {
+		entries = append(entries, v.entry(item, env))
+	}
+
+	names := map[string]bool{}
+
+	for _, e := range entries {
+		if names[e.name] {
+			v.errorf(e.name, v.find(e.name), "process is defined more than once")
+		}
+
+		names[e.name] = true
+	}
+
+	published := map[string]string{}
+
+	for _, e := range entries {
+		for _, link := range e.links {
+			if name := strings.SplitN(link, ":", 2)[0]; !names[name] {
+				v.errorf(e.name, v.find(e.name, "links:", link), "link to unknown process: %s", name)
+			}
+		}
+
+		for _, dep := range e.depends {
+			if !names[dep] {
+				v.errorf(e.name, v.find(e.name, "depends_on:", dep), "depends on unknown process: %s", dep)
+			}
+		}
+
+		ports := []string{}
+
+		for port := range e.balancer {
+			ports = append(ports, port)
+		}
+
+		sort.Strings(ports)
+
+		for _, port := range ports {
+			if other, ok := published[port]; ok {
+				v.warnf(e.name, v.find(e.name, "ports:", port+":"), "port %s is also published by %s and both can not run under convox start", port, other)
+				continue
+			}
+
+			published[port] = e.name
+		}
+
+		v.labels(e)
+	}
+
+	sort.Stable(v.problems)
+
+	return v.problems
+}
+
+func (v *validator) entry(item yaml.MapItem, env map[string]string) validatedEntry {
+	e := validatedEntry{name: fmt.Sprintf("%v", item.Key), balancer: map[string]bool{}}
+
+	if !regexValidProcessName.MatchString(e.name) {
+		v.errorf(e.name, v.find(e.name), "invalid process name, use only alphanumeric characters and dashes")
+	}
+
+	attrs, ok := item.Value.(yaml.MapSlice)
+	if !ok {
+		v.errorf(e.name, v.find(e.name), "process must be a map")
+		return e
+	}
+
+	_, build := lookup(attrs, "build")
+	_, image := lookup(attrs, "image")
+
+	if !build && !image {
+		v.errorf(e.name, v.find(e.name), "process needs a build or an image")
+	}
+
+	for _, attr := range attrs {
+		key := fmt.Sprintf("%v", attr.Key)
+		pos := v.find(e.name, key+":")
+
+		switch key {
+		case "build", "dockerfile", "entrypoint", "image":
+			if _, ok := attr.Value.(string); !ok {
+				v.errorf(e.name, pos, "%s must be a string", key)
+			}
+		case "command":
+			switch attr.Value.(type) {
+			case string, []interface{}:
+			default:
+				v.errorf(e.name, pos, "command must be a string or a list")
+			}
+		case "depends_on":
+			e.depends = v.stringList(e.name, pos, key, attr.Value)
+		case "environment":
+			v.environment(e.name, attr.Value, env)
+		case "labels":
+			labels, ok := pairs(attr.Value)
+			if !ok {
+				v.errorf(e.name, pos, "labels must be a map or a list")
+			}
+			e.labels = labels
+		case "links":
+			e.links = v.stringList(e.name, pos, key, attr.Value)
+		case "networks":
+			if _, ok := attr.Value.(yaml.MapSlice); !ok {
+				v.errorf(e.name, pos, "networks must be a map")
+			}
+		case "ports":
+			list, ok := attr.Value.([]interface{})
+			if !ok {
+				v.errorf(e.name, pos, "ports must be a list")
+				continue
+			}
+
+			for _, port := range list {
+				v.port(&e, fmt.Sprintf("%v", port))
+			}
+		case "privileged":
+			if _, ok := attr.Value.(bool); !ok {
+				v.errorf(e.name, pos, "privileged must be true or false")
+			}
+		case "volumes":
+			v.stringList(e.name, pos, key, attr.Value)
+		default:
+			v.warnf(e.name, pos, "unknown key: %s", key)
+		}
+	}
+
+	return e
+}
+
+func (v *validator) port(e *validatedEntry, port string) {
+	parts := strings.Split(port, ":")
+
+	if len(parts) > 2 {
+		v.errorf(e.name, v.find(e.name, "ports:", port), "invalid port: %s, use container or balancer:container", port)
+		return
+	}
+
+	for _, p := range parts {
+		if n, err := strconv.Atoi(p); err != nil || n < 1 || n > 65535 {
+			v.errorf(e.name, v.find(e.name, "ports:", port), "invalid port: %s", port)
+			return
+		}
+	}
+
+	if len(parts) == 2 {
+		if e.balancer[parts[0]] {
+			v.errorf(e.name, v.find(e.name, "ports:", port), "balancer port %s is listed more than once", parts[0])
+		}
+
+		e.balancer[parts[0]] = true
+	}
+}
+
+func (v *validator) environment(process string, value interface{}, env map[string]string) {
+	vars, ok := pairs(value)
+	if !ok {
+		v.errorf(process, v.find(process, "environment:"), "environment must be a map or a list")
+		return
+	}
+
+	for _, item := range vars {
+		name := fmt.Sprintf("%v", item.Key)
+		pos := v.find(process, "environment:", name)
+
+		if !regexEnvName.MatchString(name) {
+			v.errorf(process, pos, "invalid environment variable name: %s", name)
+			continue
+		}
+
+		if item.Value != nil || env == nil {
+			continue
+		}
+
+		if _, ok := env[name]; !ok {
+			v.warnf(process, pos, "%s has no default and is not set in the environment", name)
+		}
+	}
+}
+
+func (v *validator) labels(e validatedEntry) {
+	for _, item := range e.labels {
+		key := fmt.Sprintf("%v", item.Key)
+		value := ""
+
+		if item.Value != nil {
+			value = fmt.Sprintf("%v", item.Value)
+		}
+
+		if !strings.HasPrefix(key, "convox.") {
+			continue
+		}
+
+		pos := v.find(e.name, "labels:", key)
+
+		switch {
+		case strings.HasPrefix(key, "convox.cron."):
+			name := strings.TrimPrefix(key, "convox.cron.")
+
+			if !regexCronName.MatchString(name) {
+				v.errorf(e.name, pos, "invalid cron name: %s, use only alphanumeric characters", name)
+			}
+
+			if err := validateCron(value); err != nil {
+				v.errorf(e.name, pos, "invalid cron expression for %s: %s", name, err)
+			}
+		case regexPortLabel.MatchString(key):
+			m := regexPortLabel.FindStringSubmatch(key)
+
+			if !e.balancer[m[1]] {
+				v.warnf(e.name, pos, "%s refers to port %s which has no balancer", key, m[1])
+			}
+
+			switch m[2] {
+			case "acme":
+				if m[1] != "80" {
+					v.errorf(e.name, pos, "%s only works on port 80, where http-01 challenges arrive", key)
+				}
+
+				if value != "true" && value != "false" {
+					v.errorf(e.name, pos, "%s must be true or false", key)
+				}
+			case "protocol":
+				switch value {
+				case "http", "https", "tcp", "tls":
+				default:
+					v.errorf(e.name, pos, "invalid protocol: %s, use http, https, tcp or tls", value)
+				}
+			default:
+				if value != "true" && value != "false" {
+					v.errorf(e.name, pos, "%s must be true or false", key)
+				}
+			}
+		case regexStartLabel.MatchString(key):
+			switch key {
+			case "convox.start.shift", "convox.start.ready.timeout":
+				if _, err := strconv.Atoi(value); err != nil {
+					v.errorf(e.name, pos, "%s must be a number", key)
+				}
+			case "convox.start.restart":
+				if value != "true" && value != "false" {
+					v.errorf(e.name, pos, "%s must be true or false", key)
+				}
+			case "convox.start.ready.path":
+				if !strings.HasPrefix(value, "/") {
+					v.errorf(e.name, pos, "%s must start with /", key)
+				}
+			}
+		default:
+			v.warnf(e.name, pos, "unknown label: %s", key)
+		}
+	}
+}
+
+func (v *validator) stringList(process string, pos position, key string, value interface{}) []string {
+	list, ok := value.([]interface{})
+	if !ok {
+		v.errorf(process, pos, "%s must be a list", key)
+		return nil
+	}
+
+	ss := []string{}
+
+	for _, item := range list {
+		s, ok := item.(string)
+		if !ok {
+			v.errorf(process, pos, "%s must be a list of strings", key)
+			return nil
+		}
+
+		ss = append(ss, s)
+	}
+
+	return ss
+}
+
+func (v *validator) errorf(process string, pos position, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Line: pos.line, Column: pos.column, Level: "error", Process: process, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(process string, pos position, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Line: pos.line, Column: pos.column, Level: "warning", Process: process, Message: fmt.Sprintf(format, args...)})
+}
+
+type position struct {
+	line   int
+	column int
+}
+
+// find returns the position of the last of needles, each searched for after
+// the one before it inside the block for process, or at the top level when
+// process is empty. It falls back to the closest position found.
+func (v *validator) find(process string, needles ...string) position {
+	start, end, pos := v.block(process)
+
+	for _, needle := range needles {
+		found := false
+
+		for i := start; i < end; i++ {
+			if c := strings.Index(v.lines[i], needle); c >= 0 {
+				pos = position{i + 1, c + 1}
+				start = i
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			break
+		}
+	}
+
+	return pos
+}
+
+// block returns the range of lines that belong to process
+func (v *validator) block(process string) (int, int, position) {
+	if process == "" {
+		return 0, len(v.lines), position{1, 1}
+	}
+
+	for i := v.section; i < len(v.lines); i++ {
+		line := v.lines[i]
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		key := strings.Trim(strings.SplitN(strings.TrimSpace(line), ":", 2)[0], `"'`)
+
+		if key != process || !strings.Contains(line, ":") || (v.section == 0 && indent > 0) {
+			continue
+		}
+
+		end := i + 1
+
+		for ; end < len(v.lines); end++ {
+			next := v.lines[end]
+			trimmed := strings.TrimSpace(next)
+
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+
+			if len(next)-len(strings.TrimLeft(next, " ")) <= indent {
+				break
+			}
+		}
+
+		return i, end, position{i + 1, indent + 1}
+	}
+
+	return 0, 0, position{}
+}
+
+// validateCron checks a schedule of five fields followed by a command the
+// way CloudWatch Events will once the year field is added
+func validateCron(value string) error {
+	fields := strings.Fields(value)
+
+	if len(fields) < 6 {
+		return fmt.Errorf("expected minute, hour, day of month, month, day of week and a command")
+	}
+
+	specs := []struct {
+		name     string
+		min, max int
+		names    []string
+		special  *regexp.Regexp
+	}{
+		{"minute", 0, 59, nil, nil},
+		{"hour", 0, 23, nil, nil},
+		{"day of month", 1, 31, nil, regexp.MustCompile(`\A(\?|L|LW|\d{1,2}W)\z`)},
+		{"month", 1, 12, []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}, nil},
+		{"day of week", 1, 7, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}, regexp.MustCompile(`\A(\?|L|\dL|\d#\d)\z`)},
+	}
+
+	for i, spec := range specs {
+		for _, part := range strings.Split(fields[i], ",") {
+			if spec.special != nil && spec.special.MatchString(part) {
+				continue
+			}
+
+			if err := cronPart(part, spec.min, spec.max, spec.names); err != nil {
+				return fmt.Errorf("%s: %s", spec.name, err)
+			}
+		}
+	}
+
+	if fields[2] != "?" && fields[4] != "?" {
+		return fmt.Errorf("one of day of month or day of week must be ?")
+	}
+
+	return nil
+}
+
+func cronPart(part string, min, max int, names []string) error {
+	rs := strings.SplitN(part, "/", 2)
+
+	if len(rs) == 2 {
+		if n, err := strconv.Atoi(rs[1]); err != nil || n < 1 {
+			return fmt.Errorf("invalid increment: %s", part)
+		}
+	}
+
+	if rs[0] == "*" {
+		return nil
+	}
+
+	for _, bound := range strings.SplitN(rs[0], "-", 2) {
+		if cronValue(strings.ToUpper(bound), min, max, names) < 0 {
+			return fmt.Errorf("invalid value: %s", part)
+		}
+	}
+
+	return nil
+}
+
+func cronValue(s string, min, max int, names []string) int {
+	for i, name := range names {
+		if s == name {
+			return min + i
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+
+	if err != nil || n < min || n > max {
+		return -1
+	}
+
+	return n
+}
+
+func lookup(ms yaml.MapSlice, key string) (interface{}, bool) {
+	for _, item := range ms {
+		if fmt.Sprintf("%v", item.Key) == key {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// pairs reads a map, or a list of KEY=VALUE strings, into ordered pairs. A
+// list item without = has a nil value.
+func pairs(value interface{}) (yaml.MapSlice, bool) {
+	switch t := value.(type) {
+	case yaml.MapSlice:
+		return t, true
+	case []interface{}:
+		ms := yaml.MapSlice{}
+
+		for _, item := range t {
+			parts := strings.SplitN(fmt.Sprintf("%v", item), "=", 2)
+
+			if len(parts) == 2 {
+				ms = append(ms, yaml.MapItem{Key: parts[0], Value: parts[1]})
+			} else {
+				ms = append(ms, yaml.MapItem{Key: parts[0]})
+			}
+		}
+
+		return ms, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,132 @@
+package manifest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	data := []byte(`web:
+  build: .
+  environment:
+    - SECRET
+    - PORT=3000
+    - BAD-NAME=1
+  labels:
+    - convox.port.443.protocol=tls
+    - convox.port.8080.proxy=yes
+    - convox.cron.cleanup=0 3 * * * bin/cleanup
+    - convox.cron.report=*/5 * ? * MON-FRI bin/report
+    - convox.typo=true
+    - com.example.owner=me
+  links:
+    - database
+    - cache
+  ports:
+    - 80:3000
+    - 443:3000
+    - 80:abc
+  tough: true
+worker:
+  image: convox/worker
+  labels:
+    convox.cron.nightly: 0 3 * bin/nightly
+    convox.port.443.acme: true
+  ports:
+    - 443:5000
+database:
+  image: convox/postgres
+`)
+
+	problems := Validate(data, map[string]string{})
+
+	expected := []string{
+		"4:7: warning: web: SECRET has no default and is not set in the environment",
+		"6:7: error: web: invalid environment variable name: BAD-NAME",
+		"9:7: warning: web: convox.port.8080.proxy refers to port 8080 which has no balancer",
+		"9:7: error: web: convox.port.8080.proxy must be true or false",
+		"10:7: error: web: invalid cron expression for cleanup: one of day of month or day of week must be ?",
+		"12:7: warning: web: unknown label: convox.typo",
+		"16:7: error: web: link to unknown process: cache",
+		"20:7: error: web: invalid port: 80:abc",
+		"21:3: warning: web: unknown key: tough",
+		"25:5: error: worker: invalid cron expression for nightly: expected minute, hour, day of month, month, day of week and a command",
+		"26:5: error: worker: convox.port.443.acme only works on port 80, where http-01 challenges arrive",
+		"28:7: warning: worker: port 443 is also published by web and both can not run under convox start",
+	}
+
+	actual := []string{}
+
+	for _, p := range problems {
+		actual = append(actual, p.String())
+	}
+
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, 7, problems.Errors())
+}
+
+func TestValidateV2(t *testing.T) {
+	data := []byte(`version: "2"
+services:
+  web:
+    build: .
+    depends_on:
+      - queue
+  web_1:
+    image: nginx
+`)
+
+	problems := Validate(data, nil)
+
+	expected := []string{
+		"6:9: error: web: depends on unknown process: queue",
+		"7:3: error: web_1: invalid process name, use only alphanumeric characters and dashes",
+	}
+
+	actual := []string{}
+
+	for _, p := range problems {
+		actual = append(actual, p.String())
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestValidateSyntax(t *testing.T) {
+	problems := Validate([]byte("web:\n  build: .\n  ports: [80\n"), nil)
+
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, "error", problems[0].Level)
+		assert.Equal(t, 3, problems[0].Line)
+	}
+
+	assert.Equal(t, "1:1: error: no processes defined", Validate([]byte("# empty\n"), nil)[0].String())
+}
+
+func TestValidateCron(t *testing.T) {
+	valid := []string{
+		"0 3 * * ? bin/job",
+		"*/15 9-17 ? * MON-FRI bin/job",
+		"0 0 L * ? bin/job",
+		"30 12 ? JAN,JUL 2#1 bin/job",
+	}
+
+	for _, v := range valid {
+		assert.NoError(t, validateCron(v), v)
+	}
+
+	invalid := map[string]string{
+		"0 3 * * * bin/job":   "one of day of month or day of week must be ?",
+		"60 3 * * ? bin/job":  "minute: invalid value: 60",
+		"0 3 ? FOO * bin/job": "month: invalid value: FOO",
+		"0 */0 * * ? bin/job": "hour: invalid increment: */0",
+		"0 3 * * ?":           "expected minute, hour, day of month, month, day of week and a command",
+	}
+
+	for v, message := range invalid {
+		if err := validateCron(v); assert.Error(t, err, v) {
+			assert.Equal(t, message, err.Error())
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/builder/dockerignore"
+	"github.com/docker/docker/pkg/fileutils"
+)
+
+// fileChange is a local file that was written or removed
+type fileChange struct {
+	Path   string
+	Remove bool
+}
+
+// files matching the .dockerignore and .convoxignore of the app are not synced
+var (
+	syncIgnoreRoot     string
+	syncIgnorePatterns []string
+)
+
+// loadSyncIgnore reads the ignore patterns of the app in dir
+func loadSyncIgnore(dir string) error {
+	abs, err := filepath.Abs(dir)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := filepath.EvalSymlinks(abs)
+
+	if err != nil {
+		return err
+	}
+
+	patterns := []string{}
+
+	for _, name := range []string{".dockerignore", ".convoxignore"} {
+		fd, err := os.Open(filepath.Join(root, name))
+
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		p, err := dockerignore.ReadAll(fd)
+		fd.Close()
+
+		if err != nil {
+			return err
+		}
+
+		patterns = append(patterns, p...)
+	}
+
+	syncIgnoreRoot = root
+	syncIgnorePatterns = patterns
+
+	return nil
+}
+
+// syncIgnored returns true if a local path should not be synced. Directories are only
+// skipped as a whole when no pattern re-includes files with !.
+func syncIgnored(path string, dir bool) bool {
+	if len(syncIgnorePatterns) == 0 {
+		return false
+	}
+
+	rel, err := filepath.Rel(syncIgnoreRoot, path)
+
+	if err != nil || strings.HasPrefix(rel, "..") || rel == "." {
+		return false
+	}
+
+	if dir {
+		for _, p := range syncIgnorePatterns {
+			if strings.HasPrefix(p, "!") {
+				return false
+			}
+		}
+	}
+
+	match, err := fileutils.Matches(rel, syncIgnorePatterns)
+
+	return err == nil && match
+}
+
+// pollFiles walks a directory tree every second and reports changed modification times
+func pollFiles(root string, ch chan<- fileChange) {
+	files := Files{}
+
+	walk := func(report bool) {
+		filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if syncIgnored(path, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if info.IsDir() {
+				return nil
+			}
+
+			if files[path] != info.ModTime() {
+				files[path] = info.ModTime()
+
+				if report {
+					ch <- fileChange{Path: path}
+				}
+			}
+
+			return nil
+		})
+	}
+
+	walk(false)
+
+	for {
+		time.Sleep(900 * time.Millisecond)
+
+		walk(true)
+
+		for file := range files {
+			if _, err := os.Stat(file); os.IsNotExist(err) {
+				delete(files, file)
+				ch <- fileChange{Path: file, Remove: true}
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+// watchFiles reports changes under root using inotify, falling back to polling when
+// inotify is not available or runs out of watches
+func watchFiles(root string, ch chan<- fileChange) {
+	if err := inotifyFiles(root, ch); err != nil {
+		fmt.Println(warning(fmt.Sprintf("WARNING: watching %s by polling: %s", root, err)))
+		pollFiles(root, ch)
+	}
+}
+
+func inotifyFiles(root string, ch chan<- fileChange) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+
+	if err != nil {
+		return err
+	}
+
+	defer syscall.Close(fd)
+
+	watches := map[int32]string{}
+
+	// watch every directory of a tree, reporting the files in it when it is new
+	addTree := func(dir string, report bool) error {
+		return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+
+			if syncIgnored(path, info.IsDir()) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !info.IsDir() {
+				if report {
+					ch <- fileChange{Path: path}
+				}
+
+				return nil
+			}
+
+			wd, err := syscall.InotifyAddWatch(fd, path, inotifyMask)
+
+			if err != nil {
+				return err
+			}
+
+			watches[int32(wd)] = path
+
+			return nil
+		})
+	}
+
+	if err := addTree(root, false); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 64*1024)
+
+	for {
+		n, err := syscall.Read(fd, buf)
+
+		if err == syscall.EINTR {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			start := offset + syscall.SizeofInotifyEvent
+			offset = start + int(ev.Len)
+
+			if ev.Mask&syscall.IN_IGNORED != 0 {
+				delete(watches, ev.Wd)
+				continue
+			}
+
+			dir, ok := watches[ev.Wd]
+
+			if !ok || ev.Len == 0 {
+				continue
+			}
+
+			path := filepath.Join(dir, strings.TrimRight(string(buf[start:offset]), "\x00"))
+
+			switch {
+			case ev.Mask&syscall.IN_ISDIR != 0:
+				if ev.Mask&(syscall.IN_DELETE|syscall.IN_MOVED_FROM) != 0 {
+					// watches follow a moved directory so drop them rather than report
+					// its files under the old path; a move within the tree adds them back
+					for wd, dir := range watches {
+						if dir == path || strings.HasPrefix(dir, path+string(filepath.Separator)) {
+							syscall.InotifyRmWatch(fd, uint32(wd))
+							delete(watches, wd)
+						}
+					}
+
+					if !syncIgnored(path, true) {
+						ch <- fileChange{Path: path, Remove: true}
+					}
+				}
+
+				if ev.Mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) != 0 {
+					if err := addTree(path, true); err != nil {
+						return err
+					}
+				}
+			case syncIgnored(path, false):
+			case ev.Mask&(syscall.IN_CLOSE_WRITE|syscall.IN_MOVED_TO) != 0:
+				ch <- fileChange{Path: path}
+			case ev.Mask&(syscall.IN_DELETE|syscall.IN_MOVED_FROM) != 0:
+				ch <- fileChange{Path: path, Remove: true}
+			}
+		}
+	}
+}
@@ -0,0 +1,8 @@
+// +build !linux
+
+package manifest
+
+// watchFiles reports changes under root by polling
+func watchFiles(root string, ch chan<- fileChange) {
+	pollFiles(root, ch)
+}
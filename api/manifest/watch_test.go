@@ -0,0 +1,180 @@
+package manifest
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyncIgnored(t *testing.T) {
+	dir := mkFiles(t, []string{".dockerignore", ".convoxignore"}, map[string]string{
+		".dockerignore": "node_modules\n*.log\n",
+		".convoxignore": "tmp\n",
+	})
+	defer os.RemoveAll(dir)
+
+	if err := loadSyncIgnore(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { syncIgnorePatterns = nil }()
+
+	root := syncIgnoreRoot
+
+	cases := Cases{
+		{syncIgnored(filepath.Join(root, "node_modules"), true), true},
+		{syncIgnored(filepath.Join(root, "app.log"), false), true},
+		{syncIgnored(filepath.Join(root, "tmp"), true), true},
+		{syncIgnored(filepath.Join(root, "app.js"), false), false},
+		{syncIgnored(filepath.Join(root, "src"), true), false},
+	}
+
+	_assert(t, cases)
+}
+
+func TestWatchFiles(t *testing.T) {
+	testWatcher(t, watchFiles)
+}
+
+func TestPollFiles(t *testing.T) {
+	testWatcher(t, pollFiles)
+}
+
+func TestWatchFilesDirectoryMove(t *testing.T) {
+	dir := mkFiles(t, []string{".convoxignore", "src/app.js"}, map[string]string{".convoxignore": "*.log\n"})
+	defer os.RemoveAll(dir)
+
+	if err := loadSyncIgnore(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { syncIgnorePatterns = nil }()
+
+	root := syncIgnoreRoot
+
+	ch := make(chan fileChange, 10)
+
+	go watchFiles(root, ch)
+
+	time.Sleep(1100 * time.Millisecond)
+
+	// a directory moved somewhere that is not synced is removed and its files are
+	// no longer reported under the old path
+	if err := os.Rename(filepath.Join(root, "src"), filepath.Join(root, "old.log")); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	ioutil.WriteFile(filepath.Join(root, "old.log", "app.js"), []byte("changed"), 0644)
+
+	removed := false
+	timeout := time.After(2500 * time.Millisecond)
+
+	for done := false; !done; {
+		select {
+		case c := <-ch:
+			if !c.Remove {
+				t.Fatalf("unexpected change: %s", c.Path)
+			}
+
+			if c.Path == filepath.Join(root, "src") || c.Path == filepath.Join(root, "src", "app.js") {
+				removed = true
+			}
+		case <-timeout:
+			done = true
+		}
+	}
+
+	if !removed {
+		t.Fatal("moved directory was not removed")
+	}
+}
+
+func testWatcher(t *testing.T, watcher func(string, chan<- fileChange)) {
+	dir := mkFiles(t, []string{".convoxignore", "src/app.js"}, map[string]string{".convoxignore": "*.log\n"})
+	defer os.RemoveAll(dir)
+
+	if err := loadSyncIgnore(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { syncIgnorePatterns = nil }()
+
+	root := syncIgnoreRoot
+
+	ch := make(chan fileChange, 10)
+
+	go watcher(root, ch)
+
+	// give the watcher time to take its first look at the tree, file times are only
+	// accurate to the second on some filesystems
+	time.Sleep(1100 * time.Millisecond)
+
+	ioutil.WriteFile(filepath.Join(root, "debug.log"), []byte("ignored"), 0644)
+	ioutil.WriteFile(filepath.Join(root, "src", "app.js"), []byte("changed"), 0644)
+
+	select {
+	case c := <-ch:
+		_assert(t, Cases{{c, fileChange{Path: filepath.Join(root, "src", "app.js")}}})
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for change")
+	}
+
+	os.Remove(filepath.Join(root, "src", "app.js"))
+
+	select {
+	case c := <-ch:
+		_assert(t, Cases{{c, fileChange{Path: filepath.Join(root, "src", "app.js"), Remove: true}}})
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for remove")
+	}
+}
+
+func TestSyncChanged(t *testing.T) {
+	commands := []string{}
+
+	Execer = func(bin string, args ...string) *exec.Cmd {
+		commands = append(commands, bin+" "+strings.Join(args, " "))
+		return exec.Command("true")
+	}
+	defer func() { Execer = exec.Command }()
+
+	s := Sync{Container: "myapp-web", Prefix: "web |", Command: "npm run build"}
+
+	s.changed()
+
+	cases := Cases{
+		{commands, []string{"docker exec myapp-web sh -c npm run build"}},
+	}
+
+	_assert(t, cases)
+}
+
+func TestSyncChangedRestart(t *testing.T) {
+	commands := []string{}
+
+	Execer = func(bin string, args ...string) *exec.Cmd {
+		commands = append(commands, bin+" "+strings.Join(args, " "))
+		return exec.Command("true")
+	}
+	defer func() { Execer = exec.Command }()
+
+	s := Sync{Container: "myapp-web", Prefix: "web |", Restart: true}
+
+	s.changed()
+
+	since, ok := <-restartStarted("myapp-web")
+
+	_, pending := <-restartStarted("myapp-web")
+
+	cases := Cases{
+		{commands, []string{"docker restart -t 1 myapp-web"}},
+		{ok, true},
+		{since != "", true},
+		{pending, false},
+	}
+
+	_assert(t, cases)
+}
@@ -0,0 +1,534 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/convox/rack/api/acme"
+	"github.com/convox/rack/api/provider"
+)
+
+// settings keys for certificates issued through ACME
+const (
+	acmeCertificatesKey  = "acme-certificates.json"
+	acmeChallengesPrefix = "acme-challenges/"
+	acmeAccountKey       = "ACME_ACCOUNT_KEY"
+)
+
+// AcmeRenewBefore is how long before expiry a certificate is renewed
+var AcmeRenewBefore = 30 * 24 * time.Hour
+
+// AcmeDNSPropagation is how long to wait for a dns-01 record before validation
+var AcmeDNSPropagation = 30 * time.Second
+
+// AcmeCertificate is a certificate the rack issues and renews through an ACME
+// authority such as Let's Encrypt. Certificate is the id of the uploaded
+// certificate currently in use and Replaced holds older ones still to delete.
+type AcmeCertificate struct {
+	Id          string    `json:"id"`
+	Domains     []string  `json:"domains"`
+	Email       string    `json:"email"`
+	Directory   string    `json:"directory"`
+	Challenge   string    `json:"challenge"`
+	Dns         string    `json:"dns,omitempty"`
+	Certificate string    `json:"certificate"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	Expiration  time.Time `json:"expiration"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+	Replaced    []string  `json:"replaced,omitempty"`
+}
+
+type AcmeCertificates []AcmeCertificate
+
+func ListAcmeCertificates() (AcmeCertificates, error) {
+	return loadAcmeCertificates()
+}
+
+// CreateAcmeCertificate records a certificate to issue. dns-01 challenges go
+// through the named DNS provider, whose config is kept with the rack settings.
+func CreateAcmeCertificate(domains []string, email, directory, challenge, dns string, dnsConfig map[string]string) (*AcmeCertificate, error) {
+	if challenge == "" {
+		challenge = "http-01"
+	}
+
+	if directory == "" {
+		directory = acme.LetsEncrypt
+	}
+
+	if err := validateAcmeCertificate(domains, challenge, dns); err != nil {
+		return nil, fmt.Errorf("invalid certificate: %s", err)
+	}
+
+	if challenge == "http-01" {
+		apps, err := acmeBalancers()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkAcmeHTTPDomains(domains, NotificationHost, apps); err != nil {
+			return nil, fmt.Errorf("invalid certificate: %s", err)
+		}
+	}
+
+	c := AcmeCertificate{
+		Id:        generateId("E", 10),
+		Domains:   domains,
+		Email:     email,
+		Directory: directory,
+		Challenge: challenge,
+		Dns:       dns,
+		Status:    "issuing",
+		Created:   time.Now().UTC(),
+		Updated:   time.Now().UTC(),
+	}
+
+	if challenge == "dns-01" {
+		if _, err := acme.NewDNSProvider(dns, dnsConfig); err != nil {
+			return nil, fmt.Errorf("invalid certificate: %s", err)
+		}
+
+		settings, err := GetRackSettings()
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(dnsConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		settings[acmeDNSConfigKey(c.Id)] = string(data)
+
+		if err := PutRackSettings(settings); err != nil {
+			return nil, err
+		}
+	}
+
+	certs, err := loadAcmeCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveAcmeCertificates(append(certs, c)); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// DeleteAcmeCertificate stops managing a certificate. The uploaded certificate is kept.
+func DeleteAcmeCertificate(id string) (*AcmeCertificate, error) {
+	certs, err := loadAcmeCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, c := range certs {
+		if c.Id != id {
+			continue
+		}
+
+		if err := saveAcmeCertificates(append(certs[0:i], certs[i+1:]...)); err != nil {
+			return nil, err
+		}
+
+		if c.Challenge == "dns-01" {
+			settings, err := GetRackSettings()
+			if err != nil {
+				return nil, err
+			}
+
+			delete(settings, acmeDNSConfigKey(id))
+
+			if err := PutRackSettings(settings); err != nil {
+				return nil, err
+			}
+		}
+
+		return &c, nil
+	}
+
+	return nil, fmt.Errorf("no such certificate: %s", id)
+}
+
+// IssueAcmeCertificate runs an order for a certificate and uploads the result. When it
+// replaces an earlier certificate the balancers using that one are moved over.
+func IssueAcmeCertificate(id string) error {
+	c, err := GetAcmeCertificate(id)
+	if err != nil {
+		return err
+	}
+
+	err = c.issue()
+
+	c.Updated = time.Now().UTC()
+
+	if err != nil {
+		c.Status = "failed"
+		c.Error = err.Error()
+
+		NotifyError("certificate:issue", err, map[string]string{"id": c.Id, "domain": c.Domains[0]})
+	} else {
+		NotifySuccess("certificate:issue", map[string]string{"id": c.Id, "domain": c.Domains[0], "certificate": c.Certificate})
+	}
+
+	if serr := updateAcmeCertificate(*c); serr != nil {
+		return serr
+	}
+
+	return err
+}
+
+func GetAcmeCertificate(id string) (*AcmeCertificate, error) {
+	certs, err := loadAcmeCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range certs {
+		if c.Id == id {
+			return &c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no such certificate: %s", id)
+}
+
+// RetireReplacedCertificates moves balancers still using a replaced certificate to
+// the current one and deletes replaced certificates that are no longer used
+func RetireReplacedCertificates(id string) error {
+	c, err := GetAcmeCertificate(id)
+	if err != nil {
+		return err
+	}
+
+	if len(c.Replaced) == 0 || c.Certificate == "" {
+		return nil
+	}
+
+	remaining := []string{}
+
+	for _, old := range c.Replaced {
+		pending, err := replaceCertificate(old, c.Certificate)
+
+		if err == nil && pending == 0 {
+			err = provider.CertificateDelete(old)
+		}
+
+		if err != nil || pending > 0 {
+			remaining = append(remaining, old)
+		}
+	}
+
+	c.Replaced = remaining
+
+	return updateAcmeCertificate(*c)
+}
+
+// AcmeChallenge returns the key authorization for an http-01 challenge token
+func AcmeChallenge(token string) (string, error) {
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), acmeChallengesPrefix+token)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// Due reports whether the certificate needs issuing now. Failed and stuck orders
+// are retried after an hour.
+func (c AcmeCertificate) Due(now time.Time) bool {
+	switch c.Status {
+	case "issued":
+		return c.Expiration.Sub(now) < AcmeRenewBefore
+	case "failed", "issuing":
+		return now.Sub(c.Updated) > 1*time.Hour
+	}
+
+	return false
+}
+
+func (c *AcmeCertificate) issue() error {
+	client, err := acmeClient(c.Directory, c.Email)
+	if err != nil {
+		return err
+	}
+
+	var solver acme.Solver = acmeHTTPSolver{}
+
+	if c.Challenge == "http-01" {
+		apps, err := acmeBalancers()
+		if err != nil {
+			return err
+		}
+
+		if err := checkAcmeHTTPDomains(c.Domains, NotificationHost, apps); err != nil {
+			return err
+		}
+	}
+
+	if c.Challenge == "dns-01" {
+		settings, err := GetRackSettings()
+		if err != nil {
+			return err
+		}
+
+		config := map[string]string{}
+
+		if err := json.Unmarshal([]byte(settings[acmeDNSConfigKey(c.Id)]), &config); err != nil {
+			return fmt.Errorf("could not read dns provider config: %s", err)
+		}
+
+		p, err := acme.NewDNSProvider(c.Dns, config)
+		if err != nil {
+			return err
+		}
+
+		solver = acme.DNSSolver{Provider: p, Propagation: AcmeDNSPropagation}
+	}
+
+	pub, chain, key, err := client.Obtain(c.Domains, solver)
+	if err != nil {
+		return err
+	}
+
+	cert, err := provider.CertificateCreate(string(pub), string(key), string(chain))
+	if err != nil {
+		return err
+	}
+
+	old := c.Certificate
+
+	c.Certificate = cert.Id
+	c.Expiration = cert.Expiration
+	c.Status = "issued"
+	c.Error = ""
+
+	if old != "" {
+		c.Replaced = append(c.Replaced, old)
+
+		if _, err := replaceCertificate(old, cert.Id); err != nil {
+			fmt.Printf("ns=kernel at=acme.issue id=%s replace=%s err=%q\n", c.Id, old, err)
+		}
+	}
+
+	return nil
+}
+
+// acmeClient registers the rack account key with a directory, creating the key
+// on first use and keeping it encrypted with the rack settings
+func acmeClient(directory, email string) (*acme.Client, error) {
+	settings, err := GetRackSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	var client *acme.Client
+
+	if data := settings[acmeAccountKey]; data != "" {
+		key, err := acme.DecodeKey([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+
+		client = acme.NewClient(directory, key)
+	} else {
+		key, err := acme.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := acme.EncodeKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		settings[acmeAccountKey] = string(data)
+
+		if err := PutRackSettings(settings); err != nil {
+			return nil, err
+		}
+
+		client = acme.NewClient(directory, key)
+	}
+
+	if err := client.Register(email); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// lookupHost resolves a host name to addresses and is replaced in tests
+var lookupHost = net.LookupHost
+
+// acmeBalancers returns the app balancers that answer http-01 challenges
+// through an acmeproxy container, see the convox.port.80.acme label
+func acmeBalancers() ([]string, error) {
+	apps, err := ListApps()
+	if err != nil {
+		return nil, err
+	}
+
+	balancers := []string{}
+
+	for _, a := range apps {
+		for k, v := range a.Outputs {
+			if strings.HasPrefix(k, "Balancer") && strings.HasSuffix(k, "Acme") && v != "" {
+				balancers = append(balancers, v)
+			}
+		}
+	}
+
+	sort.Strings(balancers)
+
+	return balancers, nil
+}
+
+// checkAcmeHTTPDomains makes sure every domain resolves to a balancer that
+// answers http-01 challenges, either the rack balancer or an app balancer
+// with an acmeproxy container in front of port 80.
+func checkAcmeHTTPDomains(domains []string, rack string, apps []string) error {
+	if rack == "" {
+		return fmt.Errorf("rack balancer is unknown, use a dns-01 challenge")
+	}
+
+	addrs, err := lookupHost(rack)
+	if err != nil {
+		return err
+	}
+
+	balancer := map[string]bool{}
+
+	for _, a := range addrs {
+		balancer[a] = true
+	}
+
+	for _, host := range apps {
+		addrs, err := lookupHost(host)
+		if err != nil {
+			fmt.Printf("ns=kernel at=checkAcmeHTTPDomains balancer=%q err=%q\n", host, err)
+			continue
+		}
+
+		for _, a := range addrs {
+			balancer[a] = true
+		}
+	}
+
+	for _, d := range domains {
+		resolved, err := lookupHost(d)
+		if err != nil {
+			return fmt.Errorf("%s does not resolve, http-01 challenges need the domain to point at the rack balancer %s or an app balancer", d, rack)
+		}
+
+		found := false
+
+		for _, a := range resolved {
+			if balancer[a] {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s does not resolve to the rack balancer or an app balancer with convox.port.80.acme=true, label the app process or use a dns-01 challenge", d)
+		}
+	}
+
+	return nil
+}
+
+// acmeHTTPSolver answers http-01 challenges from the settings bucket through
+// GET /.well-known/acme-challenge/{token} on the rack balancer, which app
+// balancers reach through their acmeproxy container
+type acmeHTTPSolver struct{}
+
+func (acmeHTTPSolver) Type() string {
+	return "http-01"
+}
+
+func (acmeHTTPSolver) Present(domain, token, keyAuth string) error {
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), acmeChallengesPrefix+token, []byte(keyAuth), false)
+}
+
+func (acmeHTTPSolver) CleanUp(domain, token, keyAuth string) error {
+	return s3Delete(os.Getenv("SETTINGS_BUCKET"), acmeChallengesPrefix+token)
+}
+
+func validateAcmeCertificate(domains []string, challenge, dns string) error {
+	if len(domains) < 1 || domains[0] == "" {
+		return fmt.Errorf("must specify at least one domain")
+	}
+
+	switch challenge {
+	case "http-01":
+		for _, d := range domains {
+			if strings.HasPrefix(d, "*.") {
+				return fmt.Errorf("wildcard domains need a dns-01 challenge: %s", d)
+			}
+		}
+	case "dns-01":
+		if dns == "" {
+			return fmt.Errorf("dns-01 challenges need a dns provider, one of %s", strings.Join(acme.DNSProviders(), ", "))
+		}
+	default:
+		return fmt.Errorf("invalid challenge: %s, must be http-01 or dns-01", challenge)
+	}
+
+	return nil
+}
+
+func acmeDNSConfigKey(id string) string {
+	return fmt.Sprintf("ACME_DNS_%s", id)
+}
+
+func updateAcmeCertificate(c AcmeCertificate) error {
+	certs, err := loadAcmeCertificates()
+	if err != nil {
+		return err
+	}
+
+	for i := range certs {
+		if certs[i].Id == c.Id {
+			certs[i] = c
+			return saveAcmeCertificates(certs)
+		}
+	}
+
+	return fmt.Errorf("no such certificate: %s", c.Id)
+}
+
+func loadAcmeCertificates() (AcmeCertificates, error) {
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), acmeCertificatesKey)
+	if awsError(err) == "NoSuchKey" {
+		return AcmeCertificates{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var certs AcmeCertificates
+
+	if err := json.Unmarshal(data, &certs); err != nil {
+		return nil, err
+	}
+
+	return certs, nil
+}
+
+func saveAcmeCertificates(certs AcmeCertificates) error {
+	data, err := json.Marshal(certs)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), acmeCertificatesKey, data, false)
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAcmeCertificate(t *testing.T) {
+	assert.Nil(t, validateAcmeCertificate([]string{"example.org", "www.example.org"}, "http-01", ""))
+	assert.Nil(t, validateAcmeCertificate([]string{"*.example.org"}, "dns-01", "webhook"))
+
+	assert.EqualError(t, validateAcmeCertificate([]string{""}, "http-01", ""), "must specify at least one domain")
+	assert.EqualError(t, validateAcmeCertificate([]string{"*.example.org"}, "http-01", ""), "wildcard domains need a dns-01 challenge: *.example.org")
+	assert.EqualError(t, validateAcmeCertificate([]string{"example.org"}, "dns-01", ""), "dns-01 challenges need a dns provider, one of webhook")
+	assert.EqualError(t, validateAcmeCertificate([]string{"example.org"}, "tls-sni-01", ""), "invalid challenge: tls-sni-01, must be http-01 or dns-01")
+}
+
+func TestAcmeCertificateDue(t *testing.T) {
+	now := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.False(t, AcmeCertificate{Status: "issued", Expiration: now.Add(60 * 24 * time.Hour)}.Due(now))
+	assert.True(t, AcmeCertificate{Status: "issued", Expiration: now.Add(29 * 24 * time.Hour)}.Due(now))
+	assert.False(t, AcmeCertificate{Status: "failed", Updated: now.Add(-10 * time.Minute)}.Due(now))
+	assert.True(t, AcmeCertificate{Status: "failed", Updated: now.Add(-2 * time.Hour)}.Due(now))
+	assert.True(t, AcmeCertificate{Status: "issuing", Updated: now.Add(-2 * time.Hour)}.Due(now))
+}
+
+func TestCheckAcmeHTTPDomains(t *testing.T) {
+	defer func() { lookupHost = net.LookupHost }()
+
+	lookupHost = func(host string) ([]string, error) {
+		switch host {
+		case "rack.elb.amazonaws.com", "api.example.org":
+			return []string{"10.0.0.1", "10.0.0.2"}, nil
+		case "httpd.elb.amazonaws.com", "www.example.org":
+			return []string{"10.0.2.1"}, nil
+		case "myapp.example.org":
+			return []string{"10.0.1.1"}, nil
+		}
+
+		return nil, fmt.Errorf("no such host")
+	}
+
+	apps := []string{"httpd.elb.amazonaws.com", "gone.elb.amazonaws.com"}
+
+	assert.Nil(t, checkAcmeHTTPDomains([]string{"api.example.org"}, "rack.elb.amazonaws.com", nil))
+	assert.Nil(t, checkAcmeHTTPDomains([]string{"api.example.org", "www.example.org"}, "rack.elb.amazonaws.com", apps))
+
+	assert.EqualError(t, checkAcmeHTTPDomains([]string{"www.example.org"}, "rack.elb.amazonaws.com", nil), "www.example.org does not resolve to the rack balancer or an app balancer with convox.port.80.acme=true, label the app process or use a dns-01 challenge")
+	assert.EqualError(t, checkAcmeHTTPDomains([]string{"api.example.org", "myapp.example.org"}, "rack.elb.amazonaws.com", apps), "myapp.example.org does not resolve to the rack balancer or an app balancer with convox.port.80.acme=true, label the app process or use a dns-01 challenge")
+	assert.EqualError(t, checkAcmeHTTPDomains([]string{"missing.example.org"}, "rack.elb.amazonaws.com", apps), "missing.example.org does not resolve, http-01 challenges need the domain to point at the rack balancer rack.elb.amazonaws.com or an app balancer")
+	assert.EqualError(t, checkAcmeHTTPDomains([]string{"api.example.org"}, "", apps), "rack balancer is unknown, use a dns-01 challenge")
+}
@@ -0,0 +1,253 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// settings keys for alert rules and their evaluation state
+const (
+	alertsKey     = "alerts.json"
+	alertStateKey = "alerts-state.json"
+)
+
+// Alert is a rule evaluated by the monitor against the metrics of an app or the rack
+type Alert struct {
+	Id        string        `json:"id"`
+	App       string        `json:"app"`
+	Metric    string        `json:"metric"`
+	Process   string        `json:"process"`
+	Threshold float64       `json:"threshold"`
+	Window    time.Duration `json:"window"`
+	Created   time.Time     `json:"created"`
+
+	SilencedUntil time.Time `json:"silenced-until"`
+
+	State AlertState `json:"state"`
+}
+
+type Alerts []Alert
+
+// AlertState is reported by the alert worker
+type AlertState struct {
+	// Status is ok, pending or firing
+	Status string `json:"status"`
+
+	// Since is when the current status began
+	Since time.Time `json:"since"`
+
+	Message  string    `json:"message"`
+	Notified bool      `json:"notified"`
+	Updated  time.Time `json:"updated"`
+	Value    float64   `json:"value"`
+}
+
+// AlertMetric describes what an alert rule can watch
+type AlertMetric struct {
+	Description string
+
+	// App and Rack are true if the metric can watch an app or the whole rack
+	App  bool
+	Rack bool
+
+	// Threshold is false for metrics where only the window applies
+	Threshold bool
+}
+
+var AlertMetrics = map[string]AlertMetric{
+	"cpu":        {"average cpu percent of a process over the window is above the threshold", true, false, true},
+	"deployment": {"a deployment has not converged within the window", true, true, false},
+	"instances":  {"the rack instance count is at or above the threshold", false, true, true},
+	"memory":     {"average memory of a process over the window is above the threshold percent of its reservation", true, false, true},
+	"restarts":   {"a process restarted more than the threshold times within the window", true, false, true},
+}
+
+// ListAlerts returns every alert rule with its current state
+func ListAlerts() (Alerts, error) {
+	alerts, err := loadAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	state := map[string]AlertState{}
+
+	if data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), alertStateKey); err == nil {
+		json.Unmarshal(data, &state)
+	}
+
+	for i := range alerts {
+		alerts[i].State = state[alerts[i].Id]
+
+		if alerts[i].State.Status == "" {
+			alerts[i].State.Status = "ok"
+		}
+	}
+
+	return alerts, nil
+}
+
+// CreateAlert adds an alert rule. Alerts without an app watch the rack.
+func CreateAlert(a Alert) (*Alert, error) {
+	if err := validateAlert(a); err != nil {
+		return nil, err
+	}
+
+	alerts, err := loadAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	a.Id = generateId("A", 10)
+	a.Created = time.Now().UTC()
+	a.State = AlertState{}
+
+	err = saveAlerts(append(alerts, a))
+	if err != nil {
+		return nil, err
+	}
+
+	NotifySuccess("alert:create", map[string]string{"app": a.App, "id": a.Id, "metric": a.Metric})
+
+	return &a, nil
+}
+
+// DeleteAlert removes an alert rule
+func DeleteAlert(id string) (*Alert, error) {
+	alerts, err := loadAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, a := range alerts {
+		if a.Id == id {
+			err := saveAlerts(append(alerts[0:i], alerts[i+1:]...))
+			if err != nil {
+				return nil, err
+			}
+
+			NotifySuccess("alert:delete", map[string]string{"app": a.App, "id": id})
+
+			return &a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no such alert: %s", id)
+}
+
+// SilenceAlert stops notifications for an alert until a time. A zero time removes the silence.
+func SilenceAlert(id string, until time.Time) (*Alert, error) {
+	alerts, err := loadAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range alerts {
+		if alerts[i].Id == id {
+			alerts[i].SilencedUntil = until
+
+			if err := saveAlerts(alerts); err != nil {
+				return nil, err
+			}
+
+			return &alerts[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no such alert: %s", id)
+}
+
+// SaveAlertState stores the evaluation state of each alert by id
+func SaveAlertState(state map[string]AlertState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), alertStateKey, data, false)
+}
+
+// Silenced returns true if notifications for the alert are currently suppressed
+func (a *Alert) Silenced(now time.Time) bool {
+	return now.Before(a.SilencedUntil)
+}
+
+// Subject describes what the alert watches, e.g. "restarts of myapp/web"
+func (a *Alert) Subject() string {
+	switch {
+	case a.App == "":
+		return fmt.Sprintf("%s of rack %s", a.Metric, os.Getenv("RACK"))
+	case a.Process == "":
+		return fmt.Sprintf("%s of %s", a.Metric, a.App)
+	default:
+		return fmt.Sprintf("%s of %s/%s", a.Metric, a.App, a.Process)
+	}
+}
+
+func validateAlert(a Alert) error {
+	m, ok := AlertMetrics[a.Metric]
+	if !ok {
+		names := []string{}
+
+		for name := range AlertMetrics {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return fmt.Errorf("invalid alert metric: must be one of %s", strings.Join(names, ", "))
+	}
+
+	if !m.Rack && a.App == "" {
+		return fmt.Errorf("invalid alert: %s alerts require an app", a.Metric)
+	}
+
+	if !m.App && a.App != "" {
+		return fmt.Errorf("invalid alert: %s alerts apply to the rack and can not have an app", a.Metric)
+	}
+
+	if a.Process != "" && a.App == "" {
+		return fmt.Errorf("invalid alert: a process requires an app")
+	}
+
+	if m.Threshold && a.Threshold <= 0 {
+		return fmt.Errorf("invalid alert: %s alerts require a threshold", a.Metric)
+	}
+
+	if a.Window < time.Minute {
+		return fmt.Errorf("invalid alert: window must be at least 1m")
+	}
+
+	return nil
+}
+
+func loadAlerts() (Alerts, error) {
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), alertsKey)
+	if awsError(err) == "NoSuchKey" {
+		return Alerts{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts Alerts
+
+	err = json.Unmarshal(data, &alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func saveAlerts(alerts Alerts) error {
+	data, err := json.Marshal(alerts)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), alertsKey, data, false)
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAlert(t *testing.T) {
+	assert.Nil(t, validateAlert(Alert{App: "myapp", Metric: "restarts", Process: "web", Threshold: 3, Window: 5 * time.Minute}))
+	assert.Nil(t, validateAlert(Alert{Metric: "deployment", Window: 10 * time.Minute}))
+	assert.Nil(t, validateAlert(Alert{App: "myapp", Metric: "deployment", Window: 10 * time.Minute}))
+	assert.Nil(t, validateAlert(Alert{Metric: "instances", Threshold: 10, Window: 5 * time.Minute}))
+
+	assert.EqualError(t, validateAlert(Alert{Metric: "disk", Window: 5 * time.Minute}), "invalid alert metric: must be one of cpu, deployment, instances, memory, restarts")
+	assert.EqualError(t, validateAlert(Alert{Metric: "memory", Threshold: 90, Window: 5 * time.Minute}), "invalid alert: memory alerts require an app")
+	assert.EqualError(t, validateAlert(Alert{App: "myapp", Metric: "instances", Threshold: 10, Window: 5 * time.Minute}), "invalid alert: instances alerts apply to the rack and can not have an app")
+	assert.EqualError(t, validateAlert(Alert{App: "myapp", Metric: "cpu", Window: 5 * time.Minute}), "invalid alert: cpu alerts require a threshold")
+	assert.EqualError(t, validateAlert(Alert{App: "myapp", Metric: "cpu", Threshold: 80, Window: 10 * time.Second}), "invalid alert: window must be at least 1m")
+}
+
+func TestAlertSilenced(t *testing.T) {
+	now := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	a := Alert{SilencedUntil: now.Add(1 * time.Hour)}
+
+	assert.True(t, a.Silenced(now))
+	assert.False(t, a.Silenced(now.Add(2*time.Hour)))
+	assert.False(t, (&Alert{}).Silenced(now))
+}
@@ -32,6 +32,8 @@ type App struct {
 	Release string `json:"release"`
 	Status  string `json:"status"`
 
+	Review *AppReview `json:"review,omitempty"`
+
 	Outputs    map[string]string `json:"-"`
 	Parameters map[string]string `json:"-"`
 	Tags       map[string]string `json:"-"`
@@ -164,6 +166,12 @@ func (a *App) Create() error {
 		"Name":   a.Name,
 	}
 
+	for key, value := range a.Tags {
+		if _, ok := tags[key]; !ok {
+			tags[key] = value
+		}
+	}
+
 	req := &cloudformation.CreateStackInput{
 		Capabilities: []*string{aws.String("CAPABILITY_IAM")},
 		StackName:    aws.String(a.StackName()),
@@ -225,6 +233,8 @@ func (a *App) Cleanup() error {
 		provider.ReleaseDelete(a.Name, release.Id)
 	}
 
+	s3Delete(os.Getenv("SETTINGS_BUCKET"), stoppedKey(a.Name))
+
 	// monitor and stack deletion state for up to 10 minutes
 	// retry once if DELETE_FAILED to automate around transient errors
 	// send delete success event only when stack is gone
@@ -462,7 +472,8 @@ func (a *App) ExecAttached(pid, command string, height, width int, rw io.ReadWri
 	return nil
 }
 
-func (a *App) RunAttached(process, command, releaseId string, height, width int, rw io.ReadWriter) error {
+// RunAttached runs a one-off process connected to rw and writes its exit code to rw when it exits
+func (a *App) RunAttached(process, command, releaseId string, height, width int, opts RunOptions, rw io.ReadWriter) error {
 	resources, err := a.Resources()
 
 	if err != nil {
@@ -608,7 +619,9 @@ func (a *App) RunAttached(process, command, releaseId string, height, width int,
 			},
 		},
 		HostConfig: &docker.HostConfig{
-			Binds: binds,
+			Binds:     binds,
+			CPUShares: opts.Cpu,
+			Memory:    opts.Memory * 1024 * 1024,
 		},
 	})
 
@@ -619,20 +632,30 @@ func (a *App) RunAttached(process, command, releaseId string, height, width int,
 	ir, iw := io.Pipe()
 	or, ow := io.Pipe()
 
-	go d.AttachToContainer(docker.AttachToContainerOptions{
-		Container:    res.ID,
-		InputStream:  ir,
-		OutputStream: ow,
-		ErrorStream:  ow,
-		Stream:       true,
-		Stdin:        true,
-		Stdout:       true,
-		Stderr:       true,
-		RawTerminal:  true,
-	})
+	go func() {
+		d.AttachToContainer(docker.AttachToContainerOptions{
+			Container:    res.ID,
+			InputStream:  ir,
+			OutputStream: ow,
+			ErrorStream:  ow,
+			Stream:       true,
+			Stdin:        true,
+			Stdout:       true,
+			Stderr:       true,
+			RawTerminal:  true,
+		})
+
+		ow.Close()
+	}()
+
+	copied := make(chan bool)
 
 	go io.Copy(iw, rw)
-	go io.Copy(rw, or)
+
+	go func() {
+		io.Copy(rw, or)
+		close(copied)
+	}()
 
 	// hacky
 	time.Sleep(100 * time.Millisecond)
@@ -649,12 +672,35 @@ func (a *App) RunAttached(process, command, releaseId string, height, width int,
 		return err
 	}
 
+	timedOut := make(chan bool, 1)
+
+	if opts.Timeout > 0 {
+		timer := time.AfterFunc(opts.Timeout, func() {
+			timedOut <- true
+			d.StopContainer(res.ID, 10)
+		})
+
+		defer timer.Stop()
+	}
+
 	code, err := d.WaitContainer(res.ID)
 
 	if err != nil {
 		return err
 	}
 
+	// the exit code must follow all of the output or the client will stop reading early
+	select {
+	case <-copied:
+	case <-time.After(5 * time.Second):
+	}
+
+	select {
+	case <-timedOut:
+		rw.Write([]byte(fmt.Sprintf("\r\nTimed out after %s\r\n", opts.Timeout)))
+	default:
+	}
+
 	_, err = rw.Write([]byte(fmt.Sprintf("%s%d\n", StatusCodePrefix, code)))
 
 	if err != nil {
@@ -664,18 +710,30 @@ func (a *App) RunAttached(process, command, releaseId string, height, width int,
 	return nil
 }
 
-func (a *App) RunDetached(process, command, releaseId string) error {
+// RunDetached starts a one-off process in the background and records it as a run
+func (a *App) RunDetached(process, command, releaseId string, opts RunOptions) (*Run, error) {
 	resources, err := a.Resources()
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	td, release, err := runTaskDefinition(resources[UpperName(process)+"ECSTaskDefinition"].Id, process, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// detached processes run the task definition of the service, which is the current release
+	if releaseId != "" && releaseId != release {
+		return nil, fmt.Errorf("can not run release %s detached, only the current release %s can run detached", releaseId, release)
 	}
 
 	req := &ecs.RunTaskInput{
 		Cluster:        aws.String(os.Getenv("CLUSTER")),
 		Count:          aws.Int64(1),
 		StartedBy:      aws.String("convox"),
-		TaskDefinition: aws.String(resources[UpperName(process)+"ECSTaskDefinition"].Id),
+		TaskDefinition: aws.String(td),
 	}
 
 	if command != "" {
@@ -693,9 +751,46 @@ func (a *App) RunDetached(process, command, releaseId string) error {
 		}
 	}
 
-	_, err = ECS().RunTask(req)
+	res, err := ECS().RunTask(req)
 
-	return err
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Failures) > 0 {
+		return nil, fmt.Errorf("could not start process: %s", aws.StringValue(res.Failures[0].Reason))
+	}
+
+	if len(res.Tasks) < 1 {
+		return nil, fmt.Errorf("could not start process")
+	}
+
+	task := res.Tasks[0]
+
+	run := Run{
+		Id:          taskId(aws.StringValue(task.TaskArn)),
+		App:         a.Name,
+		Process:     process,
+		Command:     command,
+		Release:     release,
+		ClaimedUser: opts.ClaimedUser,
+		Cpu:         opts.Cpu,
+		Memory:      opts.Memory,
+		Timeout:     int64(opts.Timeout / time.Second),
+		Status:      "running",
+		Started:     time.Now().UTC(),
+		Task:        aws.StringValue(task.TaskArn),
+	}
+
+	err = SaveRun(run)
+
+	if err != nil {
+		return nil, err
+	}
+
+	run.Logs = run.logsCommand()
+
+	return &run, nil
 }
 
 func (a *App) TaskDefinitionFamily() string {
@@ -753,6 +848,7 @@ func appFromStack(stack *cloudformation.Stack) *App {
 		Name:       name,
 		Release:    stackParameters(stack)["Release"],
 		Status:     humanStatus(*stack.StackStatus),
+		Review:     appReview(tags),
 		Outputs:    stackOutputs(stack),
 		Parameters: stackParameters(stack),
 		Tags:       tags,
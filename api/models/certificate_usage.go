@@ -0,0 +1,289 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+)
+
+// DefaultCertificateExpiryWarnings are the days before expiry a certificate is warned about
+var DefaultCertificateExpiryWarnings = []int{30, 14, 7, 1}
+
+// settings key for the last expiry warning sent per certificate
+const certificateWarningsKey = "certificate-warnings.json"
+
+var regexpCertificateParameter = regexp.MustCompile(`^(\w+)Port(\d+)Certificate$`)
+
+// ListCertificates returns the rack certificates with the balancer ports using each.
+// Certificates in use that the provider does not list are included from the balancers.
+func ListCertificates() (structs.Certificates, error) {
+	certs, err := provider.CertificateList()
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := ListApps()
+	if err != nil {
+		return nil, err
+	}
+
+	index := map[string]int{}
+
+	for i, c := range certs {
+		index[c.Id] = i
+	}
+
+	for _, a := range apps {
+		ssls, err := ListSSLs(a.Name)
+		if err != nil {
+			fmt.Printf("ns=kernel at=certificates.list app=%s err=%q\n", a.Name, err)
+			continue
+		}
+
+		for _, ssl := range ssls {
+			i, ok := index[ssl.Certificate]
+
+			if !ok {
+				certs = append(certs, structs.Certificate{Id: ssl.Certificate, Domain: ssl.Domain, Expiration: ssl.Expiration})
+				i = len(certs) - 1
+				index[ssl.Certificate] = i
+			}
+
+			certs[i].Balancers = append(certs[i].Balancers, structs.CertificateBalancer{App: a.Name, Process: ssl.Process, Port: ssl.Port})
+		}
+	}
+
+	for _, c := range certs {
+		sort.Sort(c.Balancers)
+	}
+
+	sort.Sort(certs)
+
+	return certs, nil
+}
+
+// ReplaceCertificate points every balancer port using a certificate at another one.
+// Each app takes a single stack update, so all apps using the certificate must be running.
+func ReplaceCertificate(old, id string) (structs.CertificateBalancers, error) {
+	if old == id {
+		return nil, fmt.Errorf("invalid replacement: %s is the certificate in use", id)
+	}
+
+	apps, err := ListApps()
+	if err != nil {
+		return nil, err
+	}
+
+	using := Apps{}
+
+	for _, a := range apps {
+		if len(certificateBalancers(a.Parameters, old)) == 0 {
+			continue
+		}
+
+		if a.Status != "running" {
+			return nil, fmt.Errorf("can not update app %s with status: %s", a.Name, a.Status)
+		}
+
+		using = append(using, a)
+	}
+
+	if len(using) == 0 {
+		return nil, fmt.Errorf("no balancers use certificate: %s", old)
+	}
+
+	arn, err := certificateArn(id)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := structs.CertificateBalancers{}
+
+	for _, a := range using {
+		balancers, err := updateCertificate(a, old, arn)
+		if err != nil {
+			return replaced, err
+		}
+
+		replaced = append(replaced, balancers...)
+	}
+
+	NotifySuccess("cert:replace", map[string]string{"id": old, "certificate": id})
+
+	return replaced, nil
+}
+
+// CertificateExpiryWarnings parses a comma separated list of days before expiry to
+// warn at, largest first
+func CertificateExpiryWarnings(s string) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultCertificateExpiryWarnings, nil
+	}
+
+	days := []int{}
+
+	for _, part := range strings.Split(s, ",") {
+		d, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid certificate expiry warning: %s", part)
+		}
+
+		days = append(days, d)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(days)))
+
+	return days, nil
+}
+
+// CertificateDays returns the whole days left before a certificate expires
+func CertificateDays(expiration, now time.Time) int {
+	return int(math.Floor(expiration.Sub(now).Hours() / 24))
+}
+
+// CertificateExpiryWarning returns the smallest warning threshold a certificate
+// with the given days left has crossed
+func CertificateExpiryWarning(days int, warnings []int) (int, bool) {
+	threshold, crossed := 0, false
+
+	for _, w := range warnings {
+		if days <= w && (!crossed || w < threshold) {
+			threshold, crossed = w, true
+		}
+	}
+
+	return threshold, crossed
+}
+
+// LoadCertificateWarnings returns the smallest expiry warning threshold sent per certificate id
+func LoadCertificateWarnings() (map[string]int, error) {
+	warned := map[string]int{}
+
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), certificateWarningsKey)
+	if awsError(err) == "NoSuchKey" {
+		return warned, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &warned); err != nil {
+		return nil, err
+	}
+
+	return warned, nil
+}
+
+// SaveCertificateWarnings stores the expiry warning threshold sent per certificate id
+func SaveCertificateWarnings(warned map[string]int) error {
+	data, err := json.Marshal(warned)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), certificateWarningsKey, data, false)
+}
+
+// replaceCertificate points balancers using one certificate at another and returns
+// how many apps could not be updated yet, e.g. because they are updating
+func replaceCertificate(old, id string) (int, error) {
+	arn, err := certificateArn(id)
+	if err != nil {
+		return 0, err
+	}
+
+	apps, err := ListApps()
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+
+	for _, a := range apps {
+		if len(certificateBalancers(a.Parameters, old)) == 0 {
+			continue
+		}
+
+		if _, err := updateCertificate(a, old, arn); err != nil {
+			fmt.Printf("ns=kernel at=certificates.replace app=%s id=%s err=%q\n", a.Name, old, err)
+			pending++
+		}
+	}
+
+	return pending, nil
+}
+
+// updateCertificate sets every certificate parameter of an app using a certificate
+// to a new ARN in one stack update
+func updateCertificate(a App, old, arn string) (structs.CertificateBalancers, error) {
+	if a.Status != "running" {
+		return nil, fmt.Errorf("can not update app %s with status: %s", a.Name, a.Status)
+	}
+
+	balancers := structs.CertificateBalancers{}
+
+	params := a.Parameters
+
+	for _, name := range certificateBalancers(a.Parameters, old) {
+		m := regexpCertificateParameter.FindStringSubmatch(name)
+		port, _ := strconv.Atoi(m[2])
+
+		params[name] = arn
+		balancers = append(balancers, structs.CertificateBalancer{App: a.Name, Process: DashName(m[1]), Port: port})
+	}
+
+	req := &cloudformation.UpdateStackInput{
+		StackName:           aws.String(a.StackName()),
+		Capabilities:        []*string{aws.String("CAPABILITY_IAM")},
+		UsePreviousTemplate: aws.Bool(true),
+	}
+
+	for key, val := range params {
+		req.Parameters = append(req.Parameters, &cloudformation.Parameter{
+			ParameterKey:   aws.String(key),
+			ParameterValue: aws.String(val),
+		})
+	}
+
+	if _, err := UpdateStack(req); err != nil {
+		return nil, err
+	}
+
+	return balancers, nil
+}
+
+// certificateBalancers returns the certificate parameters set to a certificate id
+func certificateBalancers(params map[string]string, id string) []string {
+	names := []string{}
+
+	for key, value := range params {
+		if regexpCertificateParameter.MatchString(key) && value != "" && certificateId(value) == id {
+			names = append(names, key)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// certificateId returns the certificate id for an ARN, the IAM certificate name or acm-<uuid>
+func certificateId(arn string) string {
+	if strings.HasPrefix(arn, "arn:aws:acm:") {
+		parts := strings.Split(arn, "-")
+		return fmt.Sprintf("acm-%s", parts[len(parts)-1])
+	}
+
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
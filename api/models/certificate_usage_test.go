@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateBalancers(t *testing.T) {
+	params := map[string]string{
+		"WebPort443Certificate":  "arn:aws:iam::123456789012:server-certificate/cert-1234",
+		"WebPort8443Certificate": "arn:aws:iam::123456789012:server-certificate/cert-1234",
+		"ApiPort443Certificate":  "arn:aws:acm:us-east-1:123456789012:certificate/8a6b3e2c-1f2d-4c5e-9a7b-0123456789ab",
+		"ApiPort80Certificate":   "",
+		"WebPort443Balancer":     "cert-1234",
+	}
+
+	assert.Equal(t, []string{"WebPort443Certificate", "WebPort8443Certificate"}, certificateBalancers(params, "cert-1234"))
+	assert.Equal(t, []string{"ApiPort443Certificate"}, certificateBalancers(params, "acm-0123456789ab"))
+	assert.Equal(t, []string{}, certificateBalancers(params, "cert-0000"))
+}
+
+func TestCertificateExpiryWarnings(t *testing.T) {
+	warnings, err := CertificateExpiryWarnings("")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{30, 14, 7, 1}, warnings)
+
+	warnings, err = CertificateExpiryWarnings("7, 60,21")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{60, 21, 7}, warnings)
+
+	_, err = CertificateExpiryWarnings("30,soon")
+	assert.EqualError(t, err, "invalid certificate expiry warning: soon")
+}
+
+func TestCertificateExpiryWarning(t *testing.T) {
+	now := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 29, CertificateDays(now.Add(29*24*time.Hour+time.Hour), now))
+	assert.Equal(t, -1, CertificateDays(now.Add(-time.Hour), now))
+
+	warnings := []int{30, 14, 7, 1}
+
+	_, crossed := CertificateExpiryWarning(31, warnings)
+	assert.False(t, crossed)
+
+	threshold, crossed := CertificateExpiryWarning(30, warnings)
+	assert.True(t, crossed)
+	assert.Equal(t, 30, threshold)
+
+	threshold, _ = CertificateExpiryWarning(10, warnings)
+	assert.Equal(t, 14, threshold)
+
+	threshold, _ = CertificateExpiryWarning(-3, warnings)
+	assert.Equal(t, 1, threshold)
+}
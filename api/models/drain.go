@@ -0,0 +1,343 @@
+package models
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/convox/rack/api/structs"
+)
+
+// settings keys for drain configuration and delivery status
+const (
+	drainsKey      = "drains.json"
+	drainStatusKey = "drains-status.json"
+)
+
+// Drain forwards the logs of an app, or of every app when App is empty, to a syslog or https endpoint
+type Drain struct {
+	Id      string    `json:"id"`
+	App     string    `json:"app"`
+	Url     string    `json:"url"`
+	Format  string    `json:"format"`
+	Created time.Time `json:"created"`
+
+	Status DrainStatus `json:"status"`
+}
+
+type Drains []Drain
+
+// DrainStatus is reported by the drain worker
+type DrainStatus struct {
+	Delivered     int64     `json:"delivered"`
+	Dropped       int64     `json:"dropped"`
+	Failed        int64     `json:"failed"`
+	Queued        int       `json:"queued"`
+	Retries       int64     `json:"retries"`
+	LastDelivered time.Time `json:"last-delivered"`
+	LastError     string    `json:"last-error"`
+	Updated       time.Time `json:"updated"`
+}
+
+var drainFormats = []string{"rfc5424", "json", "logfmt"}
+
+const drainTimeout = 30 * time.Second
+
+var drainClient = &http.Client{Timeout: drainTimeout}
+
+// ListDrains returns the drains that apply to an app including rack-wide drains.
+// An empty app returns every drain.
+func ListDrains(app string) (Drains, error) {
+	drains, err := loadDrains()
+	if err != nil {
+		return nil, err
+	}
+
+	status := map[string]DrainStatus{}
+
+	if data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), drainStatusKey); err == nil {
+		json.Unmarshal(data, &status)
+	}
+
+	matched := Drains{}
+
+	for _, d := range drains {
+		if app == "" || d.App == "" || d.App == app {
+			d.Status = status[d.Id]
+			matched = append(matched, d)
+		}
+	}
+
+	return matched, nil
+}
+
+// CreateDrain adds a drain for an app, or for every app when app is empty
+func CreateDrain(app, endpoint, format string) (*Drain, error) {
+	if format == "" {
+		format = "rfc5424"
+	}
+
+	if err := validateDrain(endpoint, format); err != nil {
+		return nil, err
+	}
+
+	drains, err := loadDrains()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range drains {
+		if d.App == app && d.Url == endpoint {
+			return nil, fmt.Errorf("drain already exists: %s", d.Id)
+		}
+	}
+
+	d := Drain{
+		Id:      generateId("D", 10),
+		App:     app,
+		Url:     endpoint,
+		Format:  format,
+		Created: time.Now().UTC(),
+	}
+
+	err = saveDrains(append(drains, d))
+	if err != nil {
+		return nil, err
+	}
+
+	NotifySuccess("drain:create", map[string]string{"app": app, "id": d.Id})
+
+	return &d, nil
+}
+
+// DeleteDrain removes a drain. The app must match the one the drain was created for.
+func DeleteDrain(app, id string) (*Drain, error) {
+	drains, err := loadDrains()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, d := range drains {
+		if d.Id == id && d.App == app {
+			err := saveDrains(append(drains[0:i], drains[i+1:]...))
+			if err != nil {
+				return nil, err
+			}
+
+			NotifySuccess("drain:delete", map[string]string{"app": app, "id": id})
+
+			return &d, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no such drain: %s", id)
+}
+
+// SaveDrainStatus stores the delivery status of each drain by id
+func SaveDrainStatus(status map[string]DrainStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), drainStatusKey, data, false)
+}
+
+// Line renders a log record in the format of this drain
+func (d *Drain) Line(app string, r structs.LogRecord) string {
+	switch d.Format {
+	case "json":
+		data, _ := json.Marshal(struct {
+			App string `json:"app"`
+			structs.LogRecord
+		}{app, r})
+
+		return string(data)
+	case "logfmt":
+		fields := []string{
+			fmt.Sprintf("time=%s", r.Timestamp.UTC().Format(time.RFC3339Nano)),
+			fmt.Sprintf("app=%s", app),
+		}
+
+		for _, f := range [][2]string{{"process", r.Process}, {"release", r.Release}, {"container", r.Container}, {"instance", r.Instance}} {
+			if f[1] != "" {
+				fields = append(fields, fmt.Sprintf("%s=%s", f[0], f[1]))
+			}
+		}
+
+		return strings.Join(append(fields, fmt.Sprintf("msg=%s", strconv.Quote(r.Message))), " ")
+	default:
+		// <user.info>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG
+		proc := "-"
+
+		if r.Process != "" {
+			proc = fmt.Sprintf("%s.%s", r.Process, r.Container)
+		}
+
+		return fmt.Sprintf("<14>1 %s %s %s %s - - %s", r.Timestamp.UTC().Format(time.RFC3339Nano), os.Getenv("RACK"), app, proc, r.Message)
+	}
+}
+
+// Masked returns a copy of the drain with the password and query string values
+// of its url redacted so credentials are not shown when drains are listed
+func (d Drain) Masked() Drain {
+	u, err := url.Parse(d.Url)
+	if err != nil {
+		d.Url = ""
+		return d
+	}
+
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "redacted")
+		} else {
+			u.User = url.User("redacted")
+		}
+	}
+
+	if u.RawQuery != "" {
+		q := u.Query()
+
+		for k := range q {
+			q.Set(k, "redacted")
+		}
+
+		u.RawQuery = q.Encode()
+	}
+
+	d.Url = u.String()
+
+	return d
+}
+
+// Send delivers a batch of formatted lines to the drain endpoint
+func (d *Drain) Send(lines []string) error {
+	u, err := url.Parse(d.Url)
+	if err != nil {
+		return err
+	}
+
+	switch u.Scheme {
+	case "https":
+		contentType := "text/plain"
+
+		if d.Format == "json" {
+			contentType = "application/x-ndjson"
+		}
+
+		res, err := drainClient.Post(d.Url, contentType, strings.NewReader(strings.Join(lines, "\n")+"\n"))
+		if err != nil {
+			return err
+		}
+
+		defer res.Body.Close()
+
+		if res.StatusCode >= 300 {
+			return fmt.Errorf("drain responded with %s", res.Status)
+		}
+
+		return nil
+	case "syslog+udp":
+		conn, err := net.DialTimeout("udp", u.Host, drainTimeout)
+		if err != nil {
+			return err
+		}
+
+		defer conn.Close()
+
+		// one message per datagram
+		for _, line := range lines {
+			if _, err := conn.Write([]byte(line)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		var conn net.Conn
+
+		dialer := &net.Dialer{Timeout: drainTimeout}
+
+		if u.Scheme == "syslog+tls" {
+			conn, err = tls.DialWithDialer(dialer, "tcp", u.Host, &tls.Config{})
+		} else {
+			conn, err = dialer.Dial("tcp", u.Host)
+		}
+		if err != nil {
+			return err
+		}
+
+		defer conn.Close()
+
+		conn.SetDeadline(time.Now().Add(drainTimeout))
+
+		// octet counting framing from RFC 6587
+		for _, line := range lines {
+			if _, err := fmt.Fprintf(conn, "%d %s", len(line), line); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func validateDrain(endpoint, format string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid drain url: %s", endpoint)
+	}
+
+	switch u.Scheme {
+	case "https":
+	case "syslog+tcp", "syslog+tls", "syslog+udp":
+		if _, _, err := net.SplitHostPort(u.Host); err != nil {
+			return fmt.Errorf("invalid drain url: syslog drains require a host and port: %s", endpoint)
+		}
+	default:
+		return fmt.Errorf("invalid drain url: must be https, syslog+tcp, syslog+tls or syslog+udp: %s", endpoint)
+	}
+
+	for _, f := range drainFormats {
+		if f == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid drain format: must be one of %s", strings.Join(drainFormats, ", "))
+}
+
+func loadDrains() (Drains, error) {
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), drainsKey)
+	if awsError(err) == "NoSuchKey" {
+		return Drains{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var drains Drains
+
+	err = json.Unmarshal(data, &drains)
+	if err != nil {
+		return nil, err
+	}
+
+	return drains, nil
+}
+
+func saveDrains(drains Drains) error {
+	data, err := json.Marshal(drains)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), drainsKey, data, false)
+}
@@ -213,3 +213,29 @@ func (e Environment) Raw() string {
 
 	return strings.Join(lines, "\n")
 }
+
+// Includes reports whether any value contains the given string
+func (e Environment) Includes(value string) bool {
+	for _, v := range e {
+		if strings.Contains(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Replace substitutes every occurrence of old with next in all values and
+// returns the number of values that changed
+func (e Environment) Replace(old, next string) int {
+	changed := 0
+
+	for key, value := range e {
+		if replaced := strings.Replace(value, old, next, -1); replaced != value {
+			e[key] = replaced
+			changed++
+		}
+	}
+
+	return changed
+}
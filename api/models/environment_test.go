@@ -0,0 +1,26 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/convox/rack/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentReplace(t *testing.T) {
+	env := models.Environment{
+		"DATABASE_URL": "postgres://postgres:old@db:5432/app",
+		"READER_URL":   "postgres://postgres:old@db:5432/app?sslmode=require",
+		"OTHER":        "value",
+	}
+
+	assert.True(t, env.Includes("postgres://postgres:old@db:5432/app"))
+	assert.False(t, env.Includes("postgres://postgres:new@db:5432/app"))
+
+	changed := env.Replace("postgres://postgres:old@db:5432/app", "postgres://postgres:new@db:5432/app")
+
+	assert.Equal(t, 2, changed)
+	assert.Equal(t, "postgres://postgres:new@db:5432/app", env["DATABASE_URL"])
+	assert.Equal(t, "postgres://postgres:new@db:5432/app?sslmode=require", env["READER_URL"])
+	assert.Equal(t, "value", env["OTHER"])
+}
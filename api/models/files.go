@@ -0,0 +1,164 @@
+package models
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+)
+
+// errStatDone stops a download once the first archive header has been read
+var errStatDone = errors.New("stat done")
+
+// CopyFrom writes a tar archive of a file or directory in a process to w
+func (a *App) CopyFrom(pid, src string, w io.Writer) error {
+	ps, d, err := a.processDocker(pid)
+	if err != nil {
+		return err
+	}
+
+	return d.DownloadFromContainer(ps.containerId, docker.DownloadFromContainerOptions{
+		OutputStream: w,
+		Path:         src,
+	})
+}
+
+// CopyTo extracts a tar archive read from r into a process. If dst is an existing
+// directory the archive is extracted inside it, otherwise the top level entry of the
+// archive is renamed to dst.
+func (a *App) CopyTo(pid, dst string, r io.Reader) error {
+	ps, d, err := a.processDocker(pid)
+	if err != nil {
+		return err
+	}
+
+	dir, err := containerDir(d, ps.containerId, dst)
+	if err != nil {
+		return err
+	}
+
+	target := dst
+	rename := ""
+
+	if !dir {
+		target = path.Dir(dst)
+		rename = path.Base(dst)
+	}
+
+	pr, pw := io.Pipe()
+
+	// re-encode the archive so the upload ends at the end of the archive rather than
+	// when the connection closes
+	go func() {
+		pw.CloseWithError(retar(r, pw, rename))
+	}()
+
+	err = d.UploadToContainer(ps.containerId, docker.UploadToContainerOptions{
+		InputStream: pr,
+		Path:        target,
+	})
+
+	pr.Close()
+
+	return err
+}
+
+func (a *App) processDocker(pid string) (*Process, *docker.Client, error) {
+	pss, err := ListProcesses(a.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, p := range pss {
+		if p.Id == pid {
+			d, err := p.Docker()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return p, d, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no such process id: %s", pid)
+}
+
+// containerDir returns true if a path in a container is a directory. Docker has no
+// stat call in this client so the first header of an archive of the path is used.
+func containerDir(d *docker.Client, id, p string) (bool, error) {
+	var buf bytes.Buffer
+
+	err := d.DownloadFromContainer(id, docker.DownloadFromContainerOptions{
+		OutputStream: &headerWriter{buf: &buf},
+		Path:         p,
+	})
+
+	if de, ok := err.(*docker.Error); ok && de.Status == 404 {
+		return false, nil
+	}
+
+	if err != nil && err != errStatDone {
+		return false, err
+	}
+
+	h, err := tar.NewReader(&buf).Next()
+	if err != nil {
+		return false, nil
+	}
+
+	return h.Typeflag == tar.TypeDir, nil
+}
+
+// headerWriter buffers the first tar block written to it and then fails with errStatDone
+type headerWriter struct {
+	buf *bytes.Buffer
+}
+
+func (w *headerWriter) Write(p []byte) (int, error) {
+	need := 512 - w.buf.Len()
+
+	if len(p) < need {
+		return w.buf.Write(p)
+	}
+
+	w.buf.Write(p[0:need])
+
+	return need, errStatDone
+}
+
+// retar copies a tar archive from r to w, renaming its top level entry if rename is set
+func retar(r io.Reader, w io.Writer, rename string) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if rename != "" {
+			parts := strings.SplitN(strings.TrimPrefix(h.Name, "./"), "/", 2)
+			parts[0] = rename
+			h.Name = strings.Join(parts, "/")
+		}
+
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
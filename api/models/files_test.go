@@ -0,0 +1,52 @@
+package models
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetarRename(t *testing.T) {
+	var in bytes.Buffer
+
+	tw := tar.NewWriter(&in)
+
+	for _, name := range []string{"data/", "data/file.txt"} {
+		h := &tar.Header{Name: name, Mode: 0644}
+
+		if name == "data/" {
+			h.Typeflag = tar.TypeDir
+		} else {
+			h.Size = 5
+		}
+
+		assert.NoError(t, tw.WriteHeader(h))
+
+		if h.Size > 0 {
+			tw.Write([]byte("hello"))
+		}
+	}
+
+	assert.NoError(t, tw.Close())
+
+	var out bytes.Buffer
+
+	assert.NoError(t, retar(&in, &out, "config"))
+
+	names := []string{}
+
+	tr := tar.NewReader(&out)
+
+	for {
+		h, err := tr.Next()
+		if err != nil {
+			break
+		}
+
+		names = append(names, h.Name)
+	}
+
+	assert.Equal(t, []string{"config/", "config/file.txt"}, names)
+}
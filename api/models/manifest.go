@@ -39,6 +39,7 @@ type ManifestEntry struct {
 }
 
 type ManifestPort struct {
+	Acme      bool
 	Balancer  string
 	Container string
 	Public    bool
@@ -499,6 +500,7 @@ func (me ManifestEntry) PortMappings() []ManifestPort {
 			})
 		case 2:
 			mappings = append(mappings, ManifestPort{
+				Acme:      parts[0] == "80" && me.Label("convox.port.80.acme") == "true",
 				Balancer:  parts[0],
 				Container: parts[1],
 				Public:    true,
@@ -509,6 +511,23 @@ func (me ManifestEntry) PortMappings() []ManifestPort {
 	return mappings
 }
 
+// AcmeMapping returns the balancer port that answers http-01 challenges
+// through an acmeproxy container, if the entry has one
+func (me ManifestEntry) AcmeMapping() *ManifestPort {
+	for _, mapping := range me.PortMappings() {
+		if mapping.Acme {
+			return &mapping
+		}
+	}
+
+	return nil
+}
+
+// RackHost is where the acmeproxy container fetches http-01 challenges from
+func (me ManifestEntry) RackHost() string {
+	return NotificationHost
+}
+
 func (me ManifestEntry) ExternalPorts() []string {
 	ext := []string{}
 
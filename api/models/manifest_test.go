@@ -102,6 +102,7 @@ func TestManifestFixtures(t *testing.T) {
 	assertFixture(t, "complex_environment", "")
 	assertFixture(t, "balancer_labels", "")
 	assertFixture(t, "environment_map", "")
+	assertFixture(t, "acme_balancer", "")
 	ManifestRandomPorts = true
 }
 
@@ -30,6 +30,8 @@ type Process struct {
 
 	binds       []string `json:"-"`
 	containerId string   `json:"-"`
+	networkRx   uint64   `json:"-"`
+	networkTx   uint64   `json:"-"`
 	taskArn     string   `json:"-"`
 }
 
@@ -573,9 +575,17 @@ func (p *Process) FetchStats() error {
 		p.Memory = truncate(float64(stat.MemoryStats.Usage)/float64(stat.MemoryStats.Limit), 4)
 	}
 
+	p.networkRx = stat.Network.RxBytes
+	p.networkTx = stat.Network.TxBytes
+
 	return nil
 }
 
+// Network returns the total bytes received and sent by the container as of the last FetchStats
+func (p *Process) Network() (uint64, uint64) {
+	return p.networkRx, p.networkTx
+}
+
 func (p *Process) Stop() error {
 	// Stop ECS Task
 	if p.taskArn != "" {
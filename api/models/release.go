@@ -129,17 +129,62 @@ func (r *Release) Promote() error {
 		return err
 	}
 
-	formation, err := r.Formation()
+	formation, err := r.promoteFormation(app)
+
+	if err != nil {
+		return err
+	}
+
+	values, err := r.promoteParameters(app, formation, false)
+
+	if err != nil {
+		return err
+	}
+
+	params := []*cloudformation.Parameter{}
+
+	for key, value := range values {
+		params = append(params, &cloudformation.Parameter{ParameterKey: aws.String(key), ParameterValue: aws.String(value)})
+	}
+
+	err = S3Put(app.Outputs["Settings"], fmt.Sprintf("templates/%s", r.Id), []byte(formation), false)
 
 	if err != nil {
 		return err
 	}
 
+	url := fmt.Sprintf("https://s3.amazonaws.com/%s/templates/%s", app.Outputs["Settings"], r.Id)
+
+	req := &cloudformation.UpdateStackInput{
+		Capabilities: []*string{aws.String("CAPABILITY_IAM")},
+		StackName:    aws.String(app.StackName()),
+		TemplateURL:  aws.String(url),
+		Parameters:   params,
+	}
+
+	_, err = UpdateStack(req)
+
+	NotifySuccess("release:promote", map[string]string{
+		"app": r.App,
+		"id":  r.Id,
+	})
+
+	return err
+}
+
+// promoteFormation returns the template a promote of the release deploys
+func (r *Release) promoteFormation(app *App) (string, error) {
+	formation, err := r.Formation()
+
+	if err != nil {
+		return "", err
+	}
+
 	// If release formation was saved in S3, get that instead
 	f, err := s3Get(app.Outputs["Settings"], fmt.Sprintf("templates/%s", r.Id))
 
 	if err != nil && awserrCode(err) != "NoSuchKey" {
-		return err
+		return "", err
 	}
 
 	if err == nil {
@@ -148,19 +193,31 @@ func (r *Release) Promote() error {
 
 	fmt.Printf("ns=kernel at=release.promote at=s3Get found=%t\n", err == nil)
 
+	return formation, nil
+}
+
+// promoteParameters returns the stack parameters a promote of the release sets. When
+// dryRun is true certificates that would be generated are not uploaded.
+func (r *Release) promoteParameters(app *App, formation string, dryRun bool) (map[string]string, error) {
 	existing, err := formationParameters(formation)
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	parameters := map[string]string{}
+
+	for key, value := range app.Parameters {
+		parameters[key] = value
 	}
 
-	app.Parameters["Environment"] = r.EnvironmentUrl()
-	app.Parameters["Kernel"] = CustomTopic
-	app.Parameters["Release"] = r.Id
-	app.Parameters["Version"] = os.Getenv("RELEASE")
+	parameters["Environment"] = r.EnvironmentUrl()
+	parameters["Kernel"] = CustomTopic
+	parameters["Release"] = r.Id
+	parameters["Version"] = os.Getenv("RELEASE")
 
 	if os.Getenv("ENCRYPTION_KEY") != "" {
-		app.Parameters["Key"] = os.Getenv("ENCRYPTION_KEY")
+		parameters["Key"] = os.Getenv("ENCRYPTION_KEY")
 	}
 
 	// SubnetsPrivate is a List<AWS::EC2::Subnet::Id> and can not be empty
@@ -170,12 +227,12 @@ func (r *Release) Promote() error {
 		subnetsPrivate = os.Getenv("SUBNETS")
 	}
 
-	app.Parameters["SubnetsPrivate"] = subnetsPrivate
+	parameters["SubnetsPrivate"] = subnetsPrivate
 
 	manifest, err := LoadManifest(r.Manifest, app)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, entry := range manifest {
@@ -185,40 +242,45 @@ func (r *Release) Promote() error {
 			proxyParam := fmt.Sprintf("%sPort%sProxy", UpperName(entry.Name), mapping.Balancer)
 			secureParam := fmt.Sprintf("%sPort%sSecure", UpperName(entry.Name), mapping.Balancer)
 
-			app.Parameters[protoParam] = entry.Label(fmt.Sprintf("convox.port.%s.protocol", mapping.Balancer))
+			parameters[protoParam] = entry.Label(fmt.Sprintf("convox.port.%s.protocol", mapping.Balancer))
 
 			// default protocol is tcp, or tls if they have a certificate set
-			if app.Parameters[protoParam] == "" {
-				if app.Parameters[certParam] == "" {
-					app.Parameters[protoParam] = "tcp"
+			if parameters[protoParam] == "" {
+				if parameters[certParam] == "" {
+					parameters[protoParam] = "tcp"
 				} else {
-					app.Parameters[protoParam] = "tls"
+					parameters[protoParam] = "tls"
 				}
 			}
 
 			if entry.Label(fmt.Sprintf("convox.port.%s.proxy", mapping.Balancer)) == "true" {
-				app.Parameters[proxyParam] = "Yes"
+				parameters[proxyParam] = "Yes"
 			} else {
-				app.Parameters[proxyParam] = "No"
+				parameters[proxyParam] = "No"
 			}
 
 			// only change the secure parameter if a label is set for backwards compat
 			switch entry.Label(fmt.Sprintf("convox.port.%s.secure", mapping.Balancer)) {
 			case "true":
-				app.Parameters[secureParam] = "Yes"
+				parameters[secureParam] = "Yes"
 			case "false":
-				app.Parameters[secureParam] = "No"
+				parameters[secureParam] = "No"
 			}
 
-			switch app.Parameters[protoParam] {
+			switch parameters[protoParam] {
 			case "https", "tls":
-				if app.Parameters[certParam] == "" {
+				if parameters[certParam] == "" {
+					if dryRun {
+						parameters[certParam] = "(new self-signed certificate)"
+						continue
+					}
+
 					name := fmt.Sprintf("cert-%d", time.Now().Unix())
 
 					body, key, err := GenerateSelfSignedCertificate("*.*.elb.amazonaws.com")
 
 					if err != nil {
-						return err
+						return nil, err
 					}
 
 					input := &iam.UploadServerCertificateInput{
@@ -231,46 +293,24 @@ func (r *Release) Promote() error {
 					res, err := IAM().UploadServerCertificate(input)
 
 					if err != nil {
-						return err
+						return nil, err
 					}
 
-					app.Parameters[certParam] = *res.ServerCertificateMetadata.Arn
+					parameters[certParam] = *res.ServerCertificateMetadata.Arn
 				}
 			}
 		}
 	}
 
-	params := []*cloudformation.Parameter{}
+	params := map[string]string{}
 
-	for key, value := range app.Parameters {
+	for key, value := range parameters {
 		if _, ok := existing[key]; ok {
-			params = append(params, &cloudformation.Parameter{ParameterKey: aws.String(key), ParameterValue: aws.String(value)})
+			params[key] = value
 		}
 	}
 
-	err = S3Put(app.Outputs["Settings"], fmt.Sprintf("templates/%s", r.Id), []byte(formation), false)
-
-	if err != nil {
-		return err
-	}
-
-	url := fmt.Sprintf("https://s3.amazonaws.com/%s/templates/%s", app.Outputs["Settings"], r.Id)
-
-	req := &cloudformation.UpdateStackInput{
-		Capabilities: []*string{aws.String("CAPABILITY_IAM")},
-		StackName:    aws.String(app.StackName()),
-		TemplateURL:  aws.String(url),
-		Parameters:   params,
-	}
-
-	_, err = UpdateStack(req)
-
-	NotifySuccess("release:promote", map[string]string{
-		"app": r.App,
-		"id":  r.Id,
-	})
-
-	return err
+	return params, nil
 }
 
 func (r *Release) EnvironmentUrl() string {
@@ -0,0 +1,237 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// stack parameters whose values are never shown in a diff
+var secretParameters = map[string]bool{
+	"Key": true,
+}
+
+// ReleaseChange is one difference between two releases. Env values are left out and
+// secret parameters are hidden.
+type ReleaseChange struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+type ReleaseChanges []ReleaseChange
+
+func (cs ReleaseChanges) Len() int           { return len(cs) }
+func (cs ReleaseChanges) Less(i, j int) bool { return cs[i].Name < cs[j].Name }
+func (cs ReleaseChanges) Swap(i, j int)      { cs[i], cs[j] = cs[j], cs[i] }
+
+// ProcessChange is the difference in the manifest for one process
+type ProcessChange struct {
+	Process string         `json:"process"`
+	Action  string         `json:"action"`
+	Changes ReleaseChanges `json:"changes"`
+}
+
+// ReleaseDiff is what changes when moving from one release of an app to another
+type ReleaseDiff struct {
+	App  string `json:"app"`
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	Build     *ReleaseChange  `json:"build"`
+	Env       ReleaseChanges  `json:"env"`
+	Processes []ProcessChange `json:"processes"`
+
+	// Parameters are the stack parameters that promoting To would change on the app as it runs now
+	Parameters ReleaseChanges `json:"parameters"`
+}
+
+// DiffReleases compares two releases of an app
+func DiffReleases(app string, from, to *Release) (*ReleaseDiff, error) {
+	a, err := GetApp(app)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ReleaseDiff{
+		App:  app,
+		From: from.Id,
+		To:   to.Id,
+		Env:  diffEnv(LoadEnvironment([]byte(from.Env)), LoadEnvironment([]byte(to.Env))),
+	}
+
+	if from.Build != to.Build {
+		d.Build = &ReleaseChange{Name: "build", Action: "changed", From: from.Build, To: to.Build}
+	}
+
+	fm, err := LoadManifest(from.Manifest, a)
+	if err != nil {
+		return nil, err
+	}
+
+	tm, err := LoadManifest(to.Manifest, a)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Processes = diffManifests(processFields(fm, a, from.Build), processFields(tm, a, to.Build))
+
+	formation, err := to.promoteFormation(a)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := to.promoteParameters(a, formation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Parameters = diffParameters(a.Parameters, params)
+
+	return d, nil
+}
+
+func diffEnv(from, to Environment) ReleaseChanges {
+	changes := ReleaseChanges{}
+
+	for key, value := range to {
+		old, ok := from[key]
+
+		switch {
+		case !ok:
+			changes = append(changes, ReleaseChange{Name: key, Action: "added"})
+		case old != value:
+			changes = append(changes, ReleaseChange{Name: key, Action: "changed"})
+		}
+	}
+
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			changes = append(changes, ReleaseChange{Name: key, Action: "removed"})
+		}
+	}
+
+	sort.Sort(changes)
+
+	return changes
+}
+
+// processFields flattens the parts of each process that are compared
+func processFields(m Manifest, app *App, build string) map[string]map[string]string {
+	processes := map[string]map[string]string{}
+
+	for _, me := range m {
+		fields := map[string]string{
+			"command": me.CommandString(),
+			"ports":   strings.Join(me.Ports, " "),
+		}
+
+		if me.Image != "" {
+			fields["image"] = me.Image
+		} else {
+			fields["image"] = me.RegistryImage(app, build)
+		}
+
+		if cmd := me.CommandArray(); fields["command"] == "" && len(cmd) > 0 {
+			fields["command"] = strings.Join(cmd, " ")
+		}
+
+		for key, value := range me.LabelsByPrefix("") {
+			fields[fmt.Sprintf("label %s", key)] = value
+		}
+
+		processes[me.Name] = fields
+	}
+
+	return processes
+}
+
+func diffManifests(from, to map[string]map[string]string) []ProcessChange {
+	changes := []ProcessChange{}
+
+	names := map[string]bool{}
+
+	for name := range from {
+		names[name] = true
+	}
+
+	for name := range to {
+		names[name] = true
+	}
+
+	sorted := []string{}
+
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		f, inFrom := from[name]
+		t, inTo := to[name]
+
+		pc := ProcessChange{Process: name, Action: "changed", Changes: diffValues(f, t)}
+
+		switch {
+		case !inFrom:
+			pc.Action = "added"
+		case !inTo:
+			pc.Action = "removed"
+		case len(pc.Changes) == 0:
+			continue
+		}
+
+		changes = append(changes, pc)
+	}
+
+	return changes
+}
+
+func diffParameters(from, to map[string]string) ReleaseChanges {
+	changes := diffValues(from, to)
+
+	for i, c := range changes {
+		if secretParameters[c.Name] {
+			changes[i].From = hideValue(c.From)
+			changes[i].To = hideValue(c.To)
+		}
+	}
+
+	return changes
+}
+
+func hideValue(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return "(hidden)"
+}
+
+// diffValues compares two maps and returns the changed keys in order
+func diffValues(from, to map[string]string) ReleaseChanges {
+	changes := ReleaseChanges{}
+
+	for key, value := range to {
+		old, ok := from[key]
+
+		switch {
+		case !ok:
+			changes = append(changes, ReleaseChange{Name: key, Action: "added", To: value})
+		case old != value:
+			changes = append(changes, ReleaseChange{Name: key, Action: "changed", From: old, To: value})
+		}
+	}
+
+	for key, value := range from {
+		if _, ok := to[key]; !ok {
+			changes = append(changes, ReleaseChange{Name: key, Action: "removed", From: value})
+		}
+	}
+
+	sort.Sort(changes)
+
+	return changes
+}
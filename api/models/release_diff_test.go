@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffEnv(t *testing.T) {
+	from := LoadEnvironment([]byte("FOO=bar\nSECRET=one\nOLD=gone\n"))
+	to := LoadEnvironment([]byte("FOO=bar\nSECRET=two\nNEW=here\n"))
+
+	assert.Equal(t, ReleaseChanges{
+		{Name: "NEW", Action: "added"},
+		{Name: "OLD", Action: "removed"},
+		{Name: "SECRET", Action: "changed"},
+	}, diffEnv(from, to))
+}
+
+func TestDiffManifests(t *testing.T) {
+	app := &App{Name: "myapp", Outputs: map[string]string{}}
+
+	from, err := LoadManifest("web:\n  image: httpd\n  ports:\n    - 80:80\nworker:\n  image: worker\n", app)
+	assert.NoError(t, err)
+
+	to, err := LoadManifest("web:\n  image: httpd\n  command: bin/web\n  labels:\n    - convox.port.443.protocol=tls\n  ports:\n    - 80:80\n    - 443:80\nclock:\n  image: clock\n", app)
+	assert.NoError(t, err)
+
+	changes := diffManifests(processFields(from, app, "B1"), processFields(to, app, "B2"))
+
+	assert.Equal(t, []ProcessChange{
+		{Process: "clock", Action: "added", Changes: ReleaseChanges{
+			{Name: "command", Action: "added"},
+			{Name: "image", Action: "added", To: "clock"},
+			{Name: "ports", Action: "added"},
+		}},
+		{Process: "web", Action: "changed", Changes: ReleaseChanges{
+			{Name: "command", Action: "changed", To: "bin/web"},
+			{Name: "label convox.port.443.protocol", Action: "added", To: "tls"},
+			{Name: "ports", Action: "changed", From: "80:80", To: "80:80 443:80"},
+		}},
+		{Process: "worker", Action: "removed", Changes: ReleaseChanges{
+			{Name: "command", Action: "removed"},
+			{Name: "image", Action: "removed", From: "worker"},
+			{Name: "ports", Action: "removed"},
+		}},
+	}, changes)
+}
+
+func TestDiffParametersHidesSecrets(t *testing.T) {
+	changes := diffParameters(
+		map[string]string{"Key": "old", "Release": "R1", "Stale": "x"},
+		map[string]string{"Key": "new", "Release": "R2"},
+	)
+
+	assert.Equal(t, ReleaseChanges{
+		{Name: "Key", Action: "changed", From: "(hidden)", To: "(hidden)"},
+		{Name: "Release", Action: "changed", From: "R1", To: "R2"},
+		{Name: "Stale", Action: "removed", From: "x"},
+	}, changes)
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ReleaseTemplate is the stack template and parameters a promote of a release sends
+// to CloudFormation. Secret parameters are hidden.
+type ReleaseTemplate struct {
+	App        string            `json:"app"`
+	Release    string            `json:"release"`
+	Template   string            `json:"template"`
+	Parameters map[string]string `json:"parameters"`
+
+	// TemplateDiff and ParameterChanges compare against the stack as it runs now
+	// and are only set when a diff is asked for
+	TemplateDiff     string         `json:"template-diff,omitempty"`
+	ParameterChanges ReleaseChanges `json:"parameter-changes,omitempty"`
+}
+
+// Template renders the template and parameters a promote of the release would send
+// without applying them
+func (r *Release) Template(diff bool) (*ReleaseTemplate, error) {
+	app, err := GetApp(r.App)
+	if err != nil {
+		return nil, err
+	}
+
+	formation, err := r.promoteFormation(app)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.template(app, formation, diff)
+}
+
+// ManifestTemplate renders the template the current release of an app would have
+// with a different manifest. Images come from the build of the current release.
+func ManifestTemplate(app, manifest string, diff bool) (*ReleaseTemplate, error) {
+	a, err := GetApp(app)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Release == "" {
+		return nil, fmt.Errorf("app has no releases: %s", app)
+	}
+
+	r, err := GetRelease(app, a.Release)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Manifest = manifest
+
+	formation, err := r.Formation()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.template(a, formation, diff)
+}
+
+func (r *Release) template(app *App, formation string, diff bool) (*ReleaseTemplate, error) {
+	params, err := r.promoteParameters(app, formation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &ReleaseTemplate{
+		App:        r.App,
+		Release:    r.Id,
+		Template:   formation,
+		Parameters: map[string]string{},
+	}
+
+	for key, value := range params {
+		if secretParameters[key] {
+			value = hideValue(value)
+		}
+
+		t.Parameters[key] = value
+	}
+
+	if !diff {
+		return t, nil
+	}
+
+	res, err := CloudFormation().GetTemplate(&cloudformation.GetTemplateInput{
+		StackName: aws.String(app.StackName()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.TemplateDiff, err = diffTemplates(*res.TemplateBody, formation, r.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	t.ParameterChanges = diffParameters(app.Parameters, params)
+
+	return t, nil
+}
+
+// diffTemplates returns a unified diff of two templates after formatting both the same way
+func diffTemplates(deployed, rendered, release string) (string, error) {
+	from, err := prettyJson(deployed)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := prettyJson(rendered)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSpace(from) + "\n"),
+		B:        difflib.SplitLines(strings.TrimSpace(to) + "\n"),
+		FromFile: "deployed",
+		ToFile:   release,
+		Context:  3,
+	})
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTemplates(t *testing.T) {
+	deployed := `{"Resources":{"Web":{"Type":"AWS::ECS::Service","Properties":{"DesiredCount":1}}}}`
+	rendered := `{"Resources":{"Web":{"Type":"AWS::ECS::Service","Properties":{"DesiredCount":2}}}}`
+
+	diff, err := diffTemplates(deployed, rendered, "RNEW")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, `--- deployed
++++ RNEW
+@@ -2,7 +2,7 @@
+   "Resources": {
+     "Web": {
+       "Properties": {
+-        "DesiredCount": 1
++        "DesiredCount": 2
+       },
+       "Type": "AWS::ECS::Service"
+     }
+`, diff)
+	}
+
+	diff, err = diffTemplates(deployed, deployed, "RNEW")
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, "", diff)
+	}
+
+	_, err = diffTemplates("{", rendered, "RNEW")
+	assert.Error(t, err)
+}
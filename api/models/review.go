@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// AppReview describes a temporary app built from a branch of another app
+type AppReview struct {
+	Branch  string     `json:"branch"`
+	Expires *time.Time `json:"expires,omitempty"`
+	Source  string     `json:"source"`
+}
+
+// ReviewTags returns the stack tags that mark an app as a review app.
+// A zero ttl means the app only expires when its branch is deleted.
+func ReviewTags(source, branch string, ttl time.Duration) map[string]string {
+	tags := map[string]string{
+		"ReviewBranch": branch,
+		"ReviewSource": source,
+	}
+
+	if ttl > 0 {
+		tags["ReviewExpires"] = time.Now().Add(ttl).UTC().Format(time.RFC3339)
+	}
+
+	return tags
+}
+
+func appReview(tags map[string]string) *AppReview {
+	if tags["ReviewBranch"] == "" {
+		return nil
+	}
+
+	r := &AppReview{
+		Branch: tags["ReviewBranch"],
+		Source: tags["ReviewSource"],
+	}
+
+	if e, err := time.Parse(time.RFC3339, tags["ReviewExpires"]); err == nil {
+		r.Expires = &e
+	}
+
+	return r
+}
+
+// ReviewExpired returns a reason if a review app should be removed
+func (a *App) ReviewExpired() (string, error) {
+	if a.Review == nil {
+		return "", nil
+	}
+
+	if a.Review.Expires != nil && time.Now().After(*a.Review.Expires) {
+		return fmt.Sprintf("ttl expired at %s", a.Review.Expires.Format(time.RFC3339)), nil
+	}
+
+	repo := a.Parameters["Repository"]
+
+	if repo == "" {
+		return "", nil
+	}
+
+	exists, err := branchExists(repo, a.Review.Branch)
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		return fmt.Sprintf("branch %s deleted", a.Review.Branch), nil
+	}
+
+	return "", nil
+}
+
+// branchExists checks a remote git repository for a branch. Repositories
+// with embedded ssh keys are assumed to still have the branch.
+func branchExists(repo, branch string) (bool, error) {
+	u, err := url.Parse(repo)
+	if err != nil {
+		return false, err
+	}
+
+	if u.Scheme == "ssh" {
+		return true, nil
+	}
+
+	u.Fragment = ""
+
+	out, err := exec.Command("git", "ls-remote", "--heads", u.String(), branch).CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("git ls-remote: %s", strings.TrimSpace(string(out)))
+	}
+
+	return strings.TrimSpace(string(out)) != "", nil
+}
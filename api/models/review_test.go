@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewTags(t *testing.T) {
+	tags := ReviewTags("myapp", "feature-x", time.Hour)
+
+	r := appReview(tags)
+
+	if assert.NotNil(t, r) && assert.NotNil(t, r.Expires) {
+		assert.Equal(t, "feature-x", r.Branch)
+		assert.Equal(t, "myapp", r.Source)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *r.Expires, time.Minute)
+	}
+
+	assert.Nil(t, appReview(map[string]string{"Name": "myapp"}))
+	assert.Nil(t, appReview(ReviewTags("myapp", "feature-x", 0)).Expires)
+}
+
+func TestReviewExpired(t *testing.T) {
+	expired := time.Now().Add(-1 * time.Minute)
+
+	a := App{
+		Name:   "myapp-feature-x",
+		Review: &AppReview{Branch: "feature-x", Expires: &expired, Source: "myapp"},
+	}
+
+	reason, err := a.ReviewExpired()
+
+	assert.Nil(t, err)
+	assert.Contains(t, reason, "ttl expired")
+
+	reason, err = (&App{Name: "myapp"}).ReviewExpired()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", reason)
+}
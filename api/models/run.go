@@ -0,0 +1,313 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/convox/rack/api/cache"
+)
+
+const (
+	// number of detached runs kept per app
+	runHistory = 100
+
+	// docker label on resized run task definitions with the definition they were made from
+	runSourceLabel = "convox.run.source"
+)
+
+// RunOptions size and limit a one-off process. Zero values use the settings of the
+// process in the manifest and no timeout.
+type RunOptions struct {
+	Cpu     int64
+	Memory  int64
+	Timeout time.Duration
+
+	// ClaimedUser is who the client says started the run, it is not authenticated
+	ClaimedUser string
+}
+
+// Run is a record of a detached one-off process
+type Run struct {
+	Id      string `json:"id"`
+	App     string `json:"app"`
+	Process string `json:"process"`
+	Command string `json:"command"`
+	Release string `json:"release"`
+
+	// ClaimedUser is set by the client and is not authenticated
+	ClaimedUser string `json:"claimed-user"`
+
+	Cpu    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+
+	// Timeout is in seconds, 0 means the run is never stopped
+	Timeout int64 `json:"timeout"`
+
+	// Status is running, exited, timeout or failed
+	Status string `json:"status"`
+
+	Started  time.Time `json:"started"`
+	Ended    time.Time `json:"ended"`
+	ExitCode *int64    `json:"exit-code"`
+	Reason   string    `json:"reason"`
+
+	// Logs is the command that shows the output of the run
+	Logs string `json:"logs"`
+
+	Task string `json:"task"`
+}
+
+type Runs []Run
+
+func (rs Runs) Len() int           { return len(rs) }
+func (rs Runs) Less(i, j int) bool { return rs[i].Started.After(rs[j].Started) }
+func (rs Runs) Swap(i, j int)      { rs[i], rs[j] = rs[j], rs[i] }
+
+// ListRuns returns the detached runs of an app, newest first. Runs past the
+// history limit are removed.
+func ListRuns(app string) (Runs, error) {
+	runs := Runs{}
+	keys := []string{}
+
+	err := S3().ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(os.Getenv("SETTINGS_BUCKET")),
+		Prefix: aws.String(runsPrefix(app)),
+	}, func(res *s3.ListObjectsOutput, last bool) bool {
+		for _, o := range res.Contents {
+			keys = append(keys, *o.Key)
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), key)
+
+		// removed since it was listed
+		if awsError(err) == "NoSuchKey" {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var r Run
+
+		err = json.Unmarshal(data, &r)
+		if err != nil {
+			return nil, err
+		}
+
+		r.Logs = r.logsCommand()
+
+		runs = append(runs, r)
+	}
+
+	sort.Sort(runs)
+
+	if len(runs) > runHistory {
+		for _, r := range runs[runHistory:] {
+			if err := s3Delete(os.Getenv("SETTINGS_BUCKET"), runKey(app, r.Id)); err != nil {
+				fmt.Printf("ns=kernel at=runs.trim app=%s run=%s err=%q\n", app, r.Id, err)
+			}
+		}
+
+		runs = runs[0:runHistory]
+	}
+
+	return runs, nil
+}
+
+// SaveRun adds or replaces a run in the history of its app. Each run is stored on
+// its own so the api and the runs worker can update different runs at the same time.
+func SaveRun(r Run) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), runKey(r.App, r.Id), data, false)
+}
+
+// Expired returns true if a running process has passed its timeout
+func (r *Run) Expired(now time.Time) bool {
+	return r.Status == "running" && r.Timeout > 0 && now.Sub(r.Started) > time.Duration(r.Timeout)*time.Second
+}
+
+// Update records the exit of the task of a run once ECS has stopped it
+func (r *Run) Update(task *ecs.Task) {
+	if aws.StringValue(task.LastStatus) != "STOPPED" {
+		return
+	}
+
+	r.Ended = aws.TimeValue(task.StoppedAt)
+
+	for _, c := range task.Containers {
+		if aws.StringValue(c.Name) == r.Process {
+			r.ExitCode = c.ExitCode
+			r.Reason = aws.StringValue(c.Reason)
+		}
+	}
+
+	if r.Reason == "" {
+		r.Reason = aws.StringValue(task.StoppedReason)
+	}
+
+	switch {
+	case r.Status == "timeout":
+	case r.ExitCode == nil:
+		r.Status = "failed"
+	default:
+		r.Status = "exited"
+	}
+}
+
+func (r *Run) logsCommand() string {
+	cmd := fmt.Sprintf("convox logs --app %s --process %s --from %s", r.App, r.Process, r.Started.UTC().Format(time.RFC3339))
+
+	if r.Release != "" {
+		cmd += fmt.Sprintf(" --release %s", r.Release)
+	}
+
+	if !r.Ended.IsZero() {
+		cmd += fmt.Sprintf(" --to %s", r.Ended.Add(10*time.Second).UTC().Format(time.RFC3339))
+	}
+
+	return cmd
+}
+
+// runTaskDefinition returns a task definition for a one-off process with the cpu and
+// memory of its container replaced, along with the release it runs. Resized definitions
+// are registered in a separate family so the definitions used by services are left alone.
+// The latest resized revision is reused when it was made from the same definition with
+// the same size.
+func runTaskDefinition(arn, process string, opts RunOptions) (string, string, error) {
+	td, err := describeTaskDefinition(arn)
+	if err != nil {
+		return "", "", err
+	}
+
+	release := ""
+
+	for _, cd := range td.ContainerDefinitions {
+		if aws.StringValue(cd.Name) == process {
+			for _, env := range cd.Environment {
+				if aws.StringValue(env.Name) == "RELEASE" {
+					release = aws.StringValue(env.Value)
+				}
+			}
+		}
+	}
+
+	if opts.Cpu == 0 && opts.Memory == 0 {
+		return arn, release, nil
+	}
+
+	key := fmt.Sprintf("%s/%s/%d/%d", arn, process, opts.Cpu, opts.Memory)
+
+	if sized, ok := cache.Get("runTaskDefinition", key).(string); ok {
+		return sized, release, nil
+	}
+
+	family := fmt.Sprintf("%s-run", aws.StringValue(td.Family))
+
+	// the latest revision of the family was likely made for the previous run
+	res, err := ECS().DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(family),
+	})
+	if err == nil && runTaskDefinitionMatches(res.TaskDefinition, arn, process, opts) {
+		sized := aws.StringValue(res.TaskDefinition.TaskDefinitionArn)
+		cache.Set("runTaskDefinition", key, sized, taskDefinitionCache)
+		return sized, release, nil
+	}
+
+	cds := []*ecs.ContainerDefinition{}
+
+	for _, cd := range td.ContainerDefinitions {
+		c := *cd
+
+		if aws.StringValue(c.Name) == process {
+			if opts.Cpu > 0 {
+				c.Cpu = aws.Int64(opts.Cpu)
+			}
+
+			if opts.Memory > 0 {
+				c.Memory = aws.Int64(opts.Memory)
+			}
+
+			c.DockerLabels = map[string]*string{}
+
+			for k, v := range cd.DockerLabels {
+				c.DockerLabels[k] = v
+			}
+
+			c.DockerLabels[runSourceLabel] = aws.String(arn)
+		}
+
+		cds = append(cds, &c)
+	}
+
+	rres, err := ECS().RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+		ContainerDefinitions: cds,
+		Family:               aws.String(family),
+		Volumes:              td.Volumes,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	sized := aws.StringValue(rres.TaskDefinition.TaskDefinitionArn)
+
+	cache.Set("runTaskDefinition", key, sized, taskDefinitionCache)
+
+	return sized, release, nil
+}
+
+// runTaskDefinitionMatches returns true if a resized task definition was made from the
+// definition arn with the size in opts
+func runTaskDefinitionMatches(td *ecs.TaskDefinition, arn, process string, opts RunOptions) bool {
+	for _, cd := range td.ContainerDefinitions {
+		if aws.StringValue(cd.Name) != process {
+			continue
+		}
+
+		if aws.StringValue(cd.DockerLabels[runSourceLabel]) != arn {
+			return false
+		}
+
+		if opts.Cpu > 0 && aws.Int64Value(cd.Cpu) != opts.Cpu {
+			return false
+		}
+
+		if opts.Memory > 0 && aws.Int64Value(cd.Memory) != opts.Memory {
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func runsPrefix(app string) string {
+	return fmt.Sprintf("runs/%s/", app)
+}
+
+func runKey(app, id string) string {
+	return fmt.Sprintf("%s%s.json", runsPrefix(app), id)
+}
+
+func taskId(arn string) string {
+	parts := strings.Split(arn, "/")
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunExpired(t *testing.T) {
+	started := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	r := Run{Status: "running", Started: started, Timeout: 1800}
+
+	assert.False(t, r.Expired(started.Add(29*time.Minute)))
+	assert.True(t, r.Expired(started.Add(31*time.Minute)))
+
+	r.Timeout = 0
+	assert.False(t, r.Expired(started.Add(24*time.Hour)))
+}
+
+func TestRunUpdate(t *testing.T) {
+	started := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	r := Run{Id: "abc", App: "myapp", Process: "worker", Release: "RABCDEFGHI", Status: "running", Started: started}
+
+	r.Update(&ecs.Task{LastStatus: aws.String("RUNNING")})
+	assert.Equal(t, "running", r.Status)
+
+	r.Update(&ecs.Task{
+		LastStatus: aws.String("STOPPED"),
+		StoppedAt:  aws.Time(started.Add(5 * time.Minute)),
+		Containers: []*ecs.Container{
+			&ecs.Container{Name: aws.String("worker"), ExitCode: aws.Int64(2)},
+		},
+		StoppedReason: aws.String("Essential container in task exited"),
+	})
+
+	assert.Equal(t, "exited", r.Status)
+	assert.Equal(t, int64(2), *r.ExitCode)
+	assert.Equal(t, "Essential container in task exited", r.Reason)
+	assert.Equal(t, "convox logs --app myapp --process worker --from 2016-09-01T10:00:00Z --release RABCDEFGHI --to 2016-09-01T10:05:10Z", r.logsCommand())
+}
@@ -0,0 +1,622 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+const serviceRotationsPrefix = "service-rotations/"
+
+// ServiceRotation moves the apps linked to a database between the master user and a
+// secondary user. The user the apps move to gets a new password first and the user
+// they move from is only reset after every app runs with the new credentials and the
+// grace period has passed, so both credentials are valid for the whole rollout.
+//
+// Steps run in order: credentials, apps, grace, retire, then complete or failed.
+type ServiceRotation struct {
+	Service  string            `json:"service"`
+	Step     string            `json:"step"`
+	Waiting  bool              `json:"waiting"`
+	User     string            `json:"user"`
+	Previous string            `json:"previous"`
+	Active   string            `json:"active"`
+	Apps     []string          `json:"apps"`
+	Releases map[string]string `json:"releases,omitempty"`
+	Grace    int64             `json:"grace"`
+	Until    time.Time         `json:"until,omitempty"`
+	Task     string            `json:"task,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Started  time.Time         `json:"started"`
+	Updated  time.Time         `json:"updated"`
+}
+
+type ServiceRotations []ServiceRotation
+
+// Rotate starts a credential rotation for the service. The rotation itself is
+// driven by the monitor through AdvanceServiceRotation.
+func (s *Service) Rotate(grace time.Duration) (*ServiceRotation, error) {
+	master, err := s.masterUser()
+	if err != nil {
+		return nil, err
+	}
+
+	last, err := GetServiceRotation(s.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	active := master
+
+	if last != nil {
+		if !last.Done() {
+			return nil, fmt.Errorf("can not rotate: rotation already in progress for %s", s.Name)
+		}
+
+		active = last.Active
+	}
+
+	current, err := s.userURL(active)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := linkedApps(current)
+	if err != nil {
+		return nil, err
+	}
+
+	other := rotationUser(master, active)
+
+	otherURL, err := s.userURL(other)
+	if err != nil {
+		return nil, err
+	}
+
+	otherApps, err := linkedApps(otherURL)
+	if err != nil {
+		return nil, err
+	}
+
+	active, apps, err = rotationActive(active, other, apps, otherApps)
+	if err != nil {
+		return nil, err
+	}
+
+	r := ServiceRotation{
+		Service:  s.Name,
+		Step:     "credentials",
+		User:     rotationUser(master, active),
+		Previous: active,
+		Active:   active,
+		Apps:     apps,
+		Grace:    int64(grace / time.Second),
+		Started:  time.Now().UTC(),
+		Updated:  time.Now().UTC(),
+	}
+
+	if err := saveServiceRotation(r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// rotationActive returns the user the apps are on and the apps to move. A rotation
+// that failed after switching apps leaves them on the other user, whose password
+// must not be reset while they use it.
+func rotationActive(active, other string, apps, otherApps []string) (string, []string, error) {
+	switch {
+	case len(otherApps) == 0:
+		return active, apps, nil
+	case len(apps) == 0:
+		return other, otherApps, nil
+	}
+
+	return "", nil, fmt.Errorf("can not rotate: apps %s use %s and apps %s use %s, link every app to the same user first", strings.Join(apps, ", "), active, strings.Join(otherApps, ", "), other)
+}
+
+// Done reports whether the rotation has finished, successfully or not
+func (r ServiceRotation) Done() bool {
+	return r.Step == "complete" || r.Step == "failed"
+}
+
+func GetServiceRotation(service string) (*ServiceRotation, error) {
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), serviceRotationsPrefix+service+".json")
+	if awsError(err) == "NoSuchKey" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var r ServiceRotation
+
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// ListServiceRotations returns the rotations that have not finished
+func ListServiceRotations() (ServiceRotations, error) {
+	services, err := ListServices()
+	if err != nil {
+		return nil, err
+	}
+
+	rotations := ServiceRotations{}
+
+	for _, s := range services {
+		r, err := GetServiceRotation(s.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if r != nil && !r.Done() {
+			rotations = append(rotations, *r)
+		}
+	}
+
+	return rotations, nil
+}
+
+// AdvanceServiceRotation runs the next step of a rotation if it is ready and saves
+// its state so a restarted monitor picks up where it left off
+func AdvanceServiceRotation(r *ServiceRotation) error {
+	s, err := GetService(r.Service)
+	if err != nil {
+		return err
+	}
+
+	if err := r.advance(s); err != nil {
+		r.Step = "failed"
+		r.Error = err.Error()
+
+		NotifyError("service:rotate", err, map[string]string{"name": s.Name, "type": s.Type})
+	}
+
+	r.Updated = time.Now().UTC()
+
+	return saveServiceRotation(*r)
+}
+
+func (r *ServiceRotation) advance(s *Service) error {
+	if r.Waiting {
+		done, err := r.wait(s)
+		if err != nil || !done {
+			return err
+		}
+
+		r.Waiting = false
+		r.Task = ""
+
+		return r.next()
+	}
+
+	switch r.Step {
+	case "credentials":
+		return r.setPassword(s, r.User)
+	case "apps":
+		return r.promoteApps(s)
+	case "grace":
+		if time.Now().After(r.Until) {
+			r.Step = "retire"
+		}
+		return nil
+	case "retire":
+		return r.setPassword(s, r.Previous)
+	}
+
+	return fmt.Errorf("unknown rotation step: %s", r.Step)
+}
+
+// next moves on once the current step has finished waiting
+func (r *ServiceRotation) next() error {
+	switch r.Step {
+	case "credentials":
+		r.Step = "apps"
+	case "apps":
+		r.Until = time.Now().UTC().Add(time.Duration(r.Grace) * time.Second)
+		r.Step = "grace"
+	case "retire":
+		r.Step = "complete"
+
+		NotifySuccess("service:rotate", map[string]string{
+			"name": r.Service,
+			"user": r.User,
+			"apps": strings.Join(r.Apps, ","),
+		})
+	}
+
+	return nil
+}
+
+// setPassword gives a user a new password. The master password is a parameter of
+// the service stack and the secondary user is changed through the master user.
+func (r *ServiceRotation) setPassword(s *Service, user string) error {
+	master, err := s.masterUser()
+	if err != nil {
+		return err
+	}
+
+	password := generateId("", 30)
+
+	if user == master {
+		if err := s.Update(map[string]string{"Password": password}); err != nil {
+			return err
+		}
+
+		r.Waiting = true
+
+		return nil
+	}
+
+	settings, err := GetRackSettings()
+	if err != nil {
+		return err
+	}
+
+	settings[serviceUserKey(s.Name)] = password
+
+	if err := PutRackSettings(settings); err != nil {
+		return err
+	}
+
+	task, err := runServiceAdmin(s, serviceUserSQL(s.Type, user, master, s.Outputs[serviceOutput(s.Type, "Database")], password))
+	if err != nil {
+		return err
+	}
+
+	r.Task = task
+	r.Waiting = true
+
+	return nil
+}
+
+// promoteApps points linked apps at the new user and promotes a release for each
+func (r *ServiceRotation) promoteApps(s *Service) error {
+	old, err := s.userURL(r.Previous)
+	if err != nil {
+		return err
+	}
+
+	next, err := s.userURL(r.User)
+	if err != nil {
+		return err
+	}
+
+	r.Releases = map[string]string{}
+
+	for _, app := range r.Apps {
+		id, err := rotateAppEnvironment(app, old, next)
+		if err != nil {
+			return fmt.Errorf("app %s: %s", app, err)
+		}
+
+		if id != "" {
+			r.Releases[app] = id
+		}
+	}
+
+	// the apps use the new user from here on even if a release fails to promote
+	r.Active = r.User
+	r.Waiting = true
+
+	return nil
+}
+
+// wait reports whether the work started by the current step has finished
+func (r *ServiceRotation) wait(s *Service) (bool, error) {
+	if r.Task != "" {
+		return serviceAdminDone(r.Task)
+	}
+
+	switch r.Step {
+	case "credentials", "retire":
+		switch s.Status {
+		case "running":
+			return true, nil
+		case "updating":
+			return false, nil
+		}
+
+		return false, fmt.Errorf("service update failed with status: %s", s.Status)
+	case "apps":
+		for app, release := range r.Releases {
+			a, err := GetApp(app)
+			if err != nil {
+				return false, err
+			}
+
+			if a.Status != "running" {
+				return false, nil
+			}
+
+			if a.Release != release {
+				return false, fmt.Errorf("app %s did not promote release %s", app, release)
+			}
+		}
+
+		return true, nil
+	}
+
+	return true, nil
+}
+
+func (s *Service) masterUser() (string, error) {
+	switch s.Type {
+	case "mysql", "postgres":
+		return s.Outputs[serviceOutput(s.Type, "Username")], nil
+	}
+
+	return "", fmt.Errorf("can not rotate credentials for service type: %s", s.Type)
+}
+
+// userURL returns the service URL for one of the two users
+func (s *Service) userURL(user string) (string, error) {
+	master, err := s.masterUser()
+	if err != nil {
+		return "", err
+	}
+
+	password := s.Outputs[serviceOutput(s.Type, "Password")]
+
+	if user != master {
+		settings, err := GetRackSettings()
+		if err != nil {
+			return "", err
+		}
+
+		password = settings[serviceUserKey(s.Name)]
+	}
+
+	return serviceURL(s.Exports["URL"], user, password), nil
+}
+
+// linkedApps returns the names of apps that reference a service URL in their environment
+func linkedApps(url string) ([]string, error) {
+	apps, err := ListApps()
+	if err != nil {
+		return nil, err
+	}
+
+	linked := []string{}
+
+	for _, app := range apps {
+		if app.Status == "creating" {
+			continue
+		}
+
+		env, err := GetEnvironment(app.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if env.Includes(url) {
+			linked = append(linked, app.Name)
+		}
+	}
+
+	sort.Strings(linked)
+
+	return linked, nil
+}
+
+// rotateAppEnvironment replaces a service URL in an app environment and promotes the
+// resulting release. It returns the id of the promoted release, if any.
+func rotateAppEnvironment(app, old, next string) (string, error) {
+	env, err := GetEnvironment(app)
+	if err != nil {
+		return "", err
+	}
+
+	if env.Replace(old, next) == 0 {
+		return "", nil
+	}
+
+	id, err := PutEnvironment(app, env)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := GetRelease(app, id)
+	if err != nil {
+		return "", err
+	}
+
+	// nothing to promote until the app has been built
+	if r.Build == "" {
+		return "", nil
+	}
+
+	if err := r.Promote(); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// runServiceAdmin runs SQL against a service as the master user in a one-off task
+// on the rack cluster, which can reach the database inside the VPC
+func runServiceAdmin(s *Service, sql string) (string, error) {
+	td, err := serviceAdminTaskDefinition(s.Type)
+	if err != nil {
+		return "", err
+	}
+
+	env := map[string]string{
+		"HOST":     s.Outputs[serviceOutput(s.Type, "Addr")],
+		"PORT":     s.Outputs[serviceOutput(s.Type, "Port")],
+		"USER":     s.Outputs[serviceOutput(s.Type, "Username")],
+		"PASSWORD": s.Outputs[serviceOutput(s.Type, "Password")],
+		"DATABASE": s.Outputs[serviceOutput(s.Type, "Database")],
+		"SQL":      sql,
+	}
+
+	override := &ecs.ContainerOverride{Name: aws.String("admin")}
+
+	for key, value := range env {
+		override.Environment = append(override.Environment, &ecs.KeyValuePair{Name: aws.String(key), Value: aws.String(value)})
+	}
+
+	res, err := ECS().RunTask(&ecs.RunTaskInput{
+		Cluster:        aws.String(os.Getenv("CLUSTER")),
+		Count:          aws.Int64(1),
+		StartedBy:      aws.String("convox"),
+		TaskDefinition: aws.String(td),
+		Overrides:      &ecs.TaskOverride{ContainerOverrides: []*ecs.ContainerOverride{override}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(res.Failures) > 0 {
+		return "", fmt.Errorf("could not start admin task: %s", aws.StringValue(res.Failures[0].Reason))
+	}
+
+	if len(res.Tasks) < 1 {
+		return "", fmt.Errorf("could not start admin task")
+	}
+
+	return aws.StringValue(res.Tasks[0].TaskArn), nil
+}
+
+// serviceAdminDone reports whether an admin task has stopped and fails if it did not exit cleanly
+func serviceAdminDone(task string) (bool, error) {
+	res, err := ECS().DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(os.Getenv("CLUSTER")),
+		Tasks:   []*string{aws.String(task)},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if len(res.Tasks) < 1 {
+		return false, fmt.Errorf("admin task not found: %s", task)
+	}
+
+	t := res.Tasks[0]
+
+	if aws.StringValue(t.LastStatus) != "STOPPED" {
+		return false, nil
+	}
+
+	for _, c := range t.Containers {
+		if c.ExitCode == nil || *c.ExitCode != 0 {
+			return false, fmt.Errorf("admin task failed: %s", aws.StringValue(c.Reason))
+		}
+	}
+
+	return true, nil
+}
+
+// serviceAdminTaskDefinition returns the admin task definition for a service type,
+// registering it only when it does not exist yet
+func serviceAdminTaskDefinition(kind string) (string, error) {
+	image, command := serviceAdminImage(kind)
+	family := fmt.Sprintf("%s-%s-admin", os.Getenv("RACK"), kind)
+
+	res, err := ECS().DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(family),
+	})
+	if err == nil && len(res.TaskDefinition.ContainerDefinitions) == 1 && aws.StringValue(res.TaskDefinition.ContainerDefinitions[0].Image) == image {
+		return aws.StringValue(res.TaskDefinition.TaskDefinitionArn), nil
+	}
+
+	rres, err := ECS().RegisterTaskDefinition(&ecs.RegisterTaskDefinitionInput{
+		Family: aws.String(family),
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			&ecs.ContainerDefinition{
+				Name:      aws.String("admin"),
+				Image:     aws.String(image),
+				Memory:    aws.Int64(64),
+				Essential: aws.Bool(true),
+				Command:   []*string{aws.String("sh"), aws.String("-c"), aws.String(command)},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(rres.TaskDefinition.TaskDefinitionArn), nil
+}
+
+func serviceAdminImage(kind string) (string, string) {
+	if kind == "mysql" {
+		return "mysql:5.6", `printf '%s' "$SQL" | MYSQL_PWD="$PASSWORD" mysql -h "$HOST" -P "$PORT" -u "$USER" "$DATABASE"`
+	}
+
+	return "postgres:9.4", `printf '%s' "$SQL" | PGPASSWORD="$PASSWORD" psql -v ON_ERROR_STOP=1 -h "$HOST" -p "$PORT" -U "$USER" "$DATABASE"`
+}
+
+// serviceUserSQL creates the secondary user or resets its password. The postgres user
+// acts as the master role so objects it creates belong to the master user.
+func serviceUserSQL(kind, user, master, database, password string) string {
+	if kind == "mysql" {
+		return fmt.Sprintf("GRANT ALL PRIVILEGES ON `%s`.* TO '%s'@'%%' IDENTIFIED BY '%s';", database, user, password)
+	}
+
+	return strings.Join([]string{
+		fmt.Sprintf(`DO $$ BEGIN IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = '%s') THEN CREATE ROLE "%s" LOGIN IN ROLE "%s"; END IF; END $$;`, user, user, master),
+		fmt.Sprintf(`ALTER ROLE "%s" WITH LOGIN PASSWORD '%s';`, user, password),
+		fmt.Sprintf(`ALTER ROLE "%s" SET role TO "%s";`, user, master),
+	}, "\n")
+}
+
+// rotationUser returns the user apps move to, the one of the pair they are not using
+func rotationUser(master, active string) string {
+	if active == master {
+		return master + "_b"
+	}
+
+	return master
+}
+
+// serviceURL returns a service URL with different credentials
+func serviceURL(base, user, password string) string {
+	i := strings.Index(base, "://")
+	j := strings.LastIndex(base, "@")
+
+	if i < 0 || j < i {
+		return base
+	}
+
+	return fmt.Sprintf("%s%s:%s%s", base[:i+3], user, password, base[j:])
+}
+
+func serviceOutput(kind, name string) string {
+	switch name {
+	case "Addr", "Port":
+		port := "5432"
+
+		if kind == "mysql" {
+			port = "3306"
+		}
+
+		return fmt.Sprintf("Port%sTcp%s", port, name)
+	}
+
+	return fmt.Sprintf("Env%s%s", UpperName(kind), name)
+}
+
+func serviceUserKey(service string) string {
+	return fmt.Sprintf("SERVICE_%s_PASSWORD", strings.ToUpper(strings.Replace(service, "-", "_", -1)))
+}
+
+func saveServiceRotation(r ServiceRotation) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), serviceRotationsPrefix+r.Service+".json", data, false)
+}
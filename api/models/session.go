@@ -0,0 +1,307 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const (
+	// settings key that turns session recording on
+	sessionRecordingKey = "session-recording"
+
+	// how often recorded output is written to the settings bucket
+	sessionFlushInterval = 2 * time.Second
+)
+
+// Session is a recorded exec, run or ssh session. Recordings are stored in the settings
+// bucket as asciicast v2 events split into chunks so they can be watched while live.
+type Session struct {
+	Id      string `json:"id"`
+	Kind    string `json:"kind"`
+	App     string `json:"app"`
+	Target  string `json:"target"`
+	Command string `json:"command"`
+
+	// ClaimedUser is the identity the client reported in its User header. Every client
+	// shares the rack password so it is not authenticated and is only a hint. Remote is
+	// the address the client connected from.
+	ClaimedUser string `json:"claimed-user"`
+	Remote      string `json:"remote"`
+
+	Height int `json:"height"`
+	Width  int `json:"width"`
+
+	Started  time.Time `json:"started"`
+	Ended    time.Time `json:"ended"`
+	ExitCode *int      `json:"exit-code"`
+
+	Chunks int `json:"chunks"`
+}
+
+type Sessions []Session
+
+func (ss Sessions) Len() int           { return len(ss) }
+func (ss Sessions) Less(i, j int) bool { return ss[i].Started.After(ss[j].Started) }
+func (ss Sessions) Swap(i, j int)      { ss[i], ss[j] = ss[j], ss[i] }
+
+// SessionRecording returns true if interactive sessions are being recorded
+func SessionRecording() (bool, error) {
+	value, err := SettingGet(sessionRecordingKey)
+	if err != nil {
+		return false, err
+	}
+
+	return value == "true", nil
+}
+
+// SetSessionRecording turns recording of interactive sessions on or off
+func SetSessionRecording(enabled bool) error {
+	return SettingSet(sessionRecordingKey, fmt.Sprintf("%t", enabled))
+}
+
+// ListSessions returns every recorded session, newest first
+func ListSessions() (Sessions, error) {
+	sessions := Sessions{}
+	ids := []string{}
+
+	err := S3().ListObjectsPages(&s3.ListObjectsInput{
+		Bucket:    aws.String(os.Getenv("SETTINGS_BUCKET")),
+		Delimiter: aws.String("/"),
+		Prefix:    aws.String("sessions/"),
+	}, func(res *s3.ListObjectsOutput, last bool) bool {
+		for _, p := range res.CommonPrefixes {
+			ids = append(ids, strings.TrimSuffix(strings.TrimPrefix(*p.Prefix, "sessions/"), "/"))
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		s, err := GetSession(id)
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, *s)
+	}
+
+	sort.Sort(sessions)
+
+	return sessions, nil
+}
+
+func GetSession(id string) (*Session, error) {
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), sessionKey(id, "session.json"))
+	if awsError(err) == "NoSuchKey" {
+		return nil, fmt.Errorf("no such session: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s Session
+
+	err = json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// Live returns true if the session has not ended
+func (s *Session) Live() bool {
+	return s.Ended.IsZero()
+}
+
+// Chunk returns the asciicast events recorded in one chunk of the session
+func (s *Session) Chunk(n int) ([]byte, error) {
+	return s3Get(os.Getenv("SETTINGS_BUCKET"), sessionKey(s.Id, fmt.Sprintf("%05d.cast", n)))
+}
+
+// Cast writes the recording as an asciicast v2 file
+func (s *Session) Cast(w io.Writer) error {
+	header, err := json.Marshal(map[string]interface{}{
+		"version":   2,
+		"width":     s.Width,
+		"height":    s.Height,
+		"timestamp": s.Started.Unix(),
+		"command":   s.Command,
+		"title":     fmt.Sprintf("%s %s %s claimed by %s", s.Kind, s.App, s.Target, s.ClaimedUser),
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(append(header, '\n')); err != nil {
+		return err
+	}
+
+	for i := 0; i < s.Chunks; i++ {
+		data, err := s.Chunk(i)
+		if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Session) save() error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return S3Put(os.Getenv("SETTINGS_BUCKET"), sessionKey(s.Id, "session.json"), data, false)
+}
+
+// SessionRecorder records the input and output passing through an interactive session
+type SessionRecorder struct {
+	rw      io.ReadWriter
+	session Session
+
+	buffer bytes.Buffer
+	done   chan bool
+	lock   sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// RecordSession starts recording a session that reads and writes rw. Close must be
+// called when the session ends.
+func RecordSession(s Session, rw io.ReadWriter) (*SessionRecorder, error) {
+	s.Id = generateId("S", 10)
+	s.Started = time.Now().UTC()
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+
+	r := &SessionRecorder{
+		rw:      rw,
+		session: s,
+		done:    make(chan bool),
+	}
+
+	r.wg.Add(1)
+	go r.flushLoop()
+
+	return r, nil
+}
+
+func (r *SessionRecorder) Read(p []byte) (int, error) {
+	n, err := r.rw.Read(p)
+
+	if n > 0 {
+		r.event("i", p[0:n])
+	}
+
+	return n, err
+}
+
+func (r *SessionRecorder) Write(p []byte) (int, error) {
+	// the exit code trailer is kept as metadata rather than output
+	if bytes.HasPrefix(p, []byte(StatusCodePrefix)) {
+		if code, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(string(p), StatusCodePrefix))); err == nil {
+			r.lock.Lock()
+			r.session.ExitCode = &code
+			r.lock.Unlock()
+		}
+
+		return r.rw.Write(p)
+	}
+
+	r.event("o", p)
+
+	return r.rw.Write(p)
+}
+
+// Close writes the remaining events and marks the session as ended
+func (r *SessionRecorder) Close() error {
+	close(r.done)
+	r.wg.Wait()
+
+	r.lock.Lock()
+	r.session.Ended = time.Now().UTC()
+	r.lock.Unlock()
+
+	return r.flush()
+}
+
+// event records data as an asciicast v2 event: [elapsed seconds, "i" or "o", data]
+func (r *SessionRecorder) event(kind string, data []byte) {
+	line, err := json.Marshal([]interface{}{time.Since(r.session.Started).Seconds(), kind, string(data)})
+	if err != nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.buffer.Write(line)
+	r.buffer.WriteByte('\n')
+}
+
+func (r *SessionRecorder) flushLoop() {
+	defer r.wg.Done()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-time.After(sessionFlushInterval):
+			if err := r.flush(); err != nil {
+				fmt.Printf("ns=kernel at=session.flush id=%s error=%q\n", r.session.Id, err)
+			}
+		}
+	}
+}
+
+func (r *SessionRecorder) flush() error {
+	r.lock.Lock()
+	data := make([]byte, r.buffer.Len())
+	copy(data, r.buffer.Bytes())
+	s := r.session
+	r.lock.Unlock()
+
+	if len(data) > 0 {
+		// events stay buffered until they are stored so a failed put is retried
+		err := S3Put(os.Getenv("SETTINGS_BUCKET"), sessionKey(s.Id, fmt.Sprintf("%05d.cast", s.Chunks)), data, false)
+		if err != nil {
+			return err
+		}
+
+		s.Chunks++
+
+		r.lock.Lock()
+		r.buffer.Next(len(data))
+		r.session.Chunks = s.Chunks
+		r.lock.Unlock()
+	} else if s.Live() {
+		return nil
+	}
+
+	return s.save()
+}
+
+func sessionKey(id, name string) string {
+	return fmt.Sprintf("sessions/%s/%s", id, name)
+}
@@ -127,47 +127,9 @@ func UpdateSSL(app, process string, port int, id string) (*SSL, error) {
 		return nil, fmt.Errorf("Process and port combination unknown")
 	}
 
-	arn := ""
-
-	if strings.HasPrefix(id, "acm-") {
-		uuid := id[4:]
-
-		res, err := ACM().ListCertificates(nil)
-
-		if err != nil {
-			return nil, err
-		}
-
-		for _, cert := range res.CertificateSummaryList {
-			parts := strings.Split(*cert.CertificateArn, "-")
-
-			if parts[len(parts)-1] == uuid {
-				res, err := ACM().DescribeCertificate(&acm.DescribeCertificateInput{
-					CertificateArn: cert.CertificateArn,
-				})
-
-				if err != nil {
-					return nil, err
-				}
-
-				if *res.Certificate.Status == "PENDING_VALIDATION" {
-					return nil, fmt.Errorf("%s is still pending validation", id)
-				}
-
-				arn = *cert.CertificateArn
-				break
-			}
-		}
-	} else {
-		res, err := IAM().GetServerCertificate(&iam.GetServerCertificateInput{
-			ServerCertificateName: aws.String(id),
-		})
-
-		if err != nil {
-			return nil, err
-		}
-
-		arn = *res.ServerCertificate.ServerCertificateMetadata.Arn
+	arn, err := certificateArn(id)
+	if err != nil {
+		return nil, err
 	}
 
 	// update cloudformation
@@ -220,3 +182,51 @@ func certName(app, process string, port int) string {
 
 	return slice[len(slice)-1]
 }
+
+// certificateArn resolves a certificate id, an IAM certificate name or acm-<uuid>, to its ARN
+func certificateArn(id string) (string, error) {
+	arn := ""
+
+	if strings.HasPrefix(id, "acm-") {
+		uuid := id[4:]
+
+		res, err := ACM().ListCertificates(nil)
+
+		if err != nil {
+			return "", err
+		}
+
+		for _, cert := range res.CertificateSummaryList {
+			parts := strings.Split(*cert.CertificateArn, "-")
+
+			if parts[len(parts)-1] == uuid {
+				res, err := ACM().DescribeCertificate(&acm.DescribeCertificateInput{
+					CertificateArn: cert.CertificateArn,
+				})
+
+				if err != nil {
+					return "", err
+				}
+
+				if *res.Certificate.Status == "PENDING_VALIDATION" {
+					return "", fmt.Errorf("%s is still pending validation", id)
+				}
+
+				arn = *cert.CertificateArn
+				break
+			}
+		}
+	} else {
+		res, err := IAM().GetServerCertificate(&iam.GetServerCertificateInput{
+			ServerCertificateName: aws.String(id),
+		})
+
+		if err != nil {
+			return "", err
+		}
+
+		arn = *res.ServerCertificate.ServerCertificateMetadata.Arn
+	}
+
+	return arn, nil
+}
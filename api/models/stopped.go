@@ -0,0 +1,416 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/convox/rack/api/cache"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+	"github.com/fsouza/go-dockerclient"
+)
+
+const (
+	// number of stopped processes kept per app
+	stoppedHistory = 50
+
+	// number of log lines kept from before a process stopped
+	stoppedLogLines = 20
+
+	// task definitions do not change so they are kept for as long as ECS keeps stopped tasks
+	taskDefinitionCache = 1 * time.Hour
+)
+
+// StoppedProcess is a container of an app that exited or was stopped by ECS
+type StoppedProcess struct {
+	// Id is the docker container id shown by convox ps while the process ran. It is
+	// the end of the ECS container arn if the container was removed before it was recorded.
+	Id      string `json:"id"`
+	App     string `json:"app"`
+	Name    string `json:"name"`
+	Release string `json:"release"`
+
+	// Task is the arn of the ECS task the process ran in
+	Task string `json:"task"`
+
+	// Crashed is true if the container exited with an error or ran out of memory
+	Crashed bool `json:"crashed"`
+
+	ExitCode  *int64 `json:"exit-code"`
+	OOMKilled bool   `json:"oom-killed"`
+
+	// Reason is why ECS stopped the task and ContainerReason is why the container exited
+	Reason          string `json:"reason"`
+	ContainerReason string `json:"container-reason"`
+
+	// Description summarizes why the process stopped
+	Description string `json:"description"`
+
+	Started time.Time `json:"started"`
+	Stopped time.Time `json:"stopped"`
+
+	// Logs are the last lines logged by the process and release before it stopped
+	Logs []string `json:"logs"`
+
+	instanceArn string
+}
+
+type StoppedProcesses []StoppedProcess
+
+func (ps StoppedProcesses) Len() int           { return len(ps) }
+func (ps StoppedProcesses) Less(i, j int) bool { return ps[i].Stopped.After(ps[j].Stopped) }
+func (ps StoppedProcesses) Swap(i, j int)      { ps[i], ps[j] = ps[j], ps[i] }
+
+// ListStoppedProcesses returns the stopped processes of an app, newest first. Processes
+// that ECS stopped recently but that have not been recorded yet are included without logs.
+func ListStoppedProcesses(app string) (StoppedProcesses, error) {
+	history, err := loadStoppedProcesses(app)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := FetchStoppedProcesses(app)
+	if err != nil {
+		return nil, err
+	}
+
+	added := newStoppedProcesses(history, recent)
+
+	resolveStoppedIds(added)
+
+	ps := append(history, added...)
+
+	sort.Sort(ps)
+
+	return ps, nil
+}
+
+// FetchStoppedProcesses asks ECS for the stopped tasks of the services of an app.
+// ECS only keeps stopped tasks for about an hour.
+func FetchStoppedProcesses(app string) (StoppedProcesses, error) {
+	services, err := GetAppServices(app)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := StoppedProcesses{}
+
+	for _, s := range services {
+		lres, err := ECS().ListTasks(&ecs.ListTasksInput{
+			Cluster:       aws.String(os.Getenv("CLUSTER")),
+			DesiredStatus: aws.String("STOPPED"),
+			ServiceName:   s.ServiceName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(lres.TaskArns) == 0 {
+			continue
+		}
+
+		dres, err := ECS().DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(os.Getenv("CLUSTER")),
+			Tasks:   lres.TaskArns,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range dres.Tasks {
+			// tasks that are still shutting down have no exit codes yet
+			if aws.StringValue(task.LastStatus) != "STOPPED" {
+				continue
+			}
+
+			td, err := describeTaskDefinition(*task.TaskDefinitionArn)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, c := range task.Containers {
+				release := ""
+
+				for _, cd := range td.ContainerDefinitions {
+					if *cd.Name == *c.Name {
+						for _, env := range cd.Environment {
+							if *env.Name == "RELEASE" {
+								release = *env.Value
+							}
+						}
+					}
+				}
+
+				ps = append(ps, newStoppedProcess(app, release, task, c))
+			}
+		}
+	}
+
+	sort.Sort(ps)
+
+	return ps, nil
+}
+
+// RecordStoppedProcesses adds processes that are not yet in the history of an app along with
+// their last log lines and returns the ones that were added
+func RecordStoppedProcesses(app string, ps StoppedProcesses) (StoppedProcesses, error) {
+	history, err := loadStoppedProcesses(app)
+	if err != nil {
+		return nil, err
+	}
+
+	added := newStoppedProcesses(history, ps)
+
+	if len(added) == 0 {
+		return added, nil
+	}
+
+	resolveStoppedIds(added)
+
+	for i := range added {
+		if err := added[i].FetchLogs(); err != nil {
+			fmt.Printf("ns=kernel at=stopped.logs app=%s id=%s error=%q\n", app, added[i].Id, err)
+		}
+	}
+
+	history = append(history, added...)
+
+	sort.Sort(history)
+
+	if len(history) > stoppedHistory {
+		history = history[0:stoppedHistory]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+
+	err = S3Put(os.Getenv("SETTINGS_BUCKET"), stoppedKey(app), data, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// FetchLogs keeps the last lines logged by the process and release in the two minutes before it stopped
+func (p *StoppedProcess) FetchLogs() error {
+	records, err := provider.LogQuery(p.App, structs.LogStreamOptions{
+		End:     p.Stopped.Add(10 * time.Second),
+		Process: p.Name,
+		Release: p.Release,
+		Start:   p.Stopped.Add(-2 * time.Minute),
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(records) > stoppedLogLines {
+		records = records[len(records)-stoppedLogLines:]
+	}
+
+	p.Logs = []string{}
+
+	for _, r := range records {
+		p.Logs = append(p.Logs, r.Message)
+	}
+
+	return nil
+}
+
+// describeStopped summarizes why a process stopped
+func describeStopped(p StoppedProcess) string {
+	switch {
+	case p.OOMKilled:
+		return "out of memory"
+	case p.ExitCode != nil && p.ContainerReason != "":
+		return fmt.Sprintf("exit %d: %s", *p.ExitCode, p.ContainerReason)
+	case p.ExitCode != nil:
+		return fmt.Sprintf("exit %d: %s", *p.ExitCode, p.Reason)
+	}
+
+	return p.Reason
+}
+
+func newStoppedProcess(app, release string, task *ecs.Task, c *ecs.Container) StoppedProcess {
+	idp := strings.Split(*c.ContainerArn, "-")
+
+	p := StoppedProcess{
+		Id:              idp[len(idp)-1],
+		App:             app,
+		Name:            aws.StringValue(c.Name),
+		Release:         release,
+		Task:            aws.StringValue(task.TaskArn),
+		ExitCode:        c.ExitCode,
+		Reason:          aws.StringValue(task.StoppedReason),
+		ContainerReason: aws.StringValue(c.Reason),
+		Started:         aws.TimeValue(task.StartedAt),
+		Stopped:         aws.TimeValue(task.StoppedAt),
+	}
+
+	p.OOMKilled = strings.HasPrefix(p.ContainerReason, "OutOfMemoryError")
+
+	// tasks stopped for deployments, scaling or by a user stop with a reason other than an exit
+	exited := strings.HasPrefix(p.Reason, "Essential container in task exited")
+
+	p.Crashed = p.OOMKilled || (exited && p.ExitCode != nil && *p.ExitCode != 0)
+	p.Description = describeStopped(p)
+
+	p.instanceArn = aws.StringValue(task.ContainerInstanceArn)
+
+	return p
+}
+
+// newStoppedProcesses returns the processes in ps that are not in history. Processes are
+// matched by task and name because ids are replaced once the container is found.
+// Processes recorded without a task are matched by id.
+func newStoppedProcesses(history, ps StoppedProcesses) StoppedProcesses {
+	seen := map[string]bool{}
+
+	for _, p := range history {
+		if p.Task != "" {
+			seen[p.Task+"/"+p.Name] = true
+		} else {
+			seen[p.Id] = true
+		}
+	}
+
+	added := StoppedProcesses{}
+
+	for _, p := range ps {
+		if !seen[p.Task+"/"+p.Name] && !seen[p.Id] {
+			added = append(added, p)
+		}
+	}
+
+	return added
+}
+
+// describeTaskDefinition returns a task definition, which never changes once registered
+func describeTaskDefinition(arn string) (*ecs.TaskDefinition, error) {
+	if td, ok := cache.Get("describeTaskDefinition", arn).(*ecs.TaskDefinition); ok {
+		return td, nil
+	}
+
+	res, err := ECS().DescribeTaskDefinition(&ecs.DescribeTaskDefinitionInput{
+		TaskDefinition: aws.String(arn),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cache.Set("describeTaskDefinition", arn, res.TaskDefinition, taskDefinitionCache); err != nil {
+		return nil, err
+	}
+
+	return res.TaskDefinition, nil
+}
+
+// resolveStoppedIds replaces the ECS ids of stopped processes with the docker container
+// ids that convox ps showed while they ran. ECS removes stopped containers after a few
+// hours so processes whose container is gone keep their ECS id.
+func resolveStoppedIds(ps StoppedProcesses) {
+	pending := map[string][]int{}
+
+	for i, p := range ps {
+		if p.instanceArn != "" {
+			pending[p.instanceArn] = append(pending[p.instanceArn], i)
+		}
+	}
+
+	if len(pending) == 0 {
+		return
+	}
+
+	arns := []*string{}
+
+	for arn := range pending {
+		arns = append(arns, aws.String(arn))
+	}
+
+	cres, err := ECS().DescribeContainerInstances(&ecs.DescribeContainerInstancesInput{
+		Cluster:            aws.String(os.Getenv("CLUSTER")),
+		ContainerInstances: arns,
+	})
+	if err != nil {
+		fmt.Printf("ns=kernel at=stopped.ids error=%q\n", err)
+		return
+	}
+
+	instances, err := provider.InstanceList()
+	if err != nil {
+		fmt.Printf("ns=kernel at=stopped.ids error=%q\n", err)
+		return
+	}
+
+	for _, ci := range cres.ContainerInstances {
+		for _, instance := range instances {
+			if instance.Id != aws.StringValue(ci.Ec2InstanceId) {
+				continue
+			}
+
+			d, err := instance.DockerClient()
+			if err != nil {
+				fmt.Printf("ns=kernel at=stopped.ids instance=%s error=%q\n", instance.Id, err)
+				continue
+			}
+
+			for _, i := range pending[aws.StringValue(ci.ContainerInstanceArn)] {
+				containers, err := d.ListContainers(docker.ListContainersOptions{
+					All: true,
+					Filters: map[string][]string{
+						"label": []string{
+							fmt.Sprintf("com.amazonaws.ecs.task-arn=%s", ps[i].Task),
+							fmt.Sprintf("com.amazonaws.ecs.container-name=%s", ps[i].Name),
+						},
+					},
+				})
+				if err != nil {
+					fmt.Printf("ns=kernel at=stopped.ids instance=%s error=%q\n", instance.Id, err)
+					break
+				}
+
+				if len(containers) == 1 {
+					ps[i].Id = containers[0].ID[0:12]
+				}
+			}
+		}
+	}
+}
+
+func loadStoppedProcesses(app string) (StoppedProcesses, error) {
+	data, err := s3Get(os.Getenv("SETTINGS_BUCKET"), stoppedKey(app))
+	if awsError(err) == "NoSuchKey" {
+		return StoppedProcesses{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ps StoppedProcesses
+
+	err = json.Unmarshal(data, &ps)
+	if err != nil {
+		return nil, err
+	}
+
+	// processes recorded before descriptions were stored
+	for i := range ps {
+		if ps[i].Description == "" {
+			ps[i].Description = describeStopped(ps[i])
+		}
+	}
+
+	return ps, nil
+}
+
+func stoppedKey(app string) string {
+	return fmt.Sprintf("processes-stopped/%s.json", app)
+}
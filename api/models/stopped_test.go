@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStoppedProcess(t *testing.T) {
+	stopped := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	task := &ecs.Task{
+		StartedAt:     aws.Time(stopped.Add(-5 * time.Minute)),
+		StoppedAt:     aws.Time(stopped),
+		StoppedReason: aws.String("Essential container in task exited"),
+	}
+
+	p := newStoppedProcess("myapp", "RABCDEFGHI", task, &ecs.Container{
+		ContainerArn: aws.String("arn:aws:ecs:us-east-1:778743527532:container/e126c67d-fa95-4b09-8b4a-3723932cd2aa"),
+		ExitCode:     aws.Int64(137),
+		Name:         aws.String("web"),
+		Reason:       aws.String("OutOfMemoryError: Container killed due to memory usage"),
+	})
+
+	assert.Equal(t, "3723932cd2aa", p.Id)
+	assert.Equal(t, "web", p.Name)
+	assert.Equal(t, "RABCDEFGHI", p.Release)
+	assert.Equal(t, stopped, p.Stopped)
+	assert.True(t, p.OOMKilled)
+	assert.True(t, p.Crashed)
+	assert.Equal(t, "out of memory", p.Description)
+
+	p = newStoppedProcess("myapp", "RABCDEFGHI", task, &ecs.Container{
+		ContainerArn: aws.String("arn:aws:ecs:us-east-1:778743527532:container/e126c67d-fa95-4b09-8b4a-3723932cd2ab"),
+		ExitCode:     aws.Int64(1),
+		Name:         aws.String("web"),
+	})
+
+	assert.False(t, p.OOMKilled)
+	assert.True(t, p.Crashed)
+	assert.Equal(t, "exit 1: Essential container in task exited", p.Description)
+
+	task.StoppedReason = aws.String("Scaling activity initiated by (deployment ecs-svc/9223370563468342331)")
+
+	p = newStoppedProcess("myapp", "RABCDEFGHI", task, &ecs.Container{
+		ContainerArn: aws.String("arn:aws:ecs:us-east-1:778743527532:container/e126c67d-fa95-4b09-8b4a-3723932cd2ac"),
+		ExitCode:     aws.Int64(143),
+		Name:         aws.String("web"),
+	})
+
+	assert.False(t, p.Crashed)
+}
+
+func TestNewStoppedProcesses(t *testing.T) {
+	history := StoppedProcesses{{Id: "a"}, {Id: "b"}}
+
+	added := newStoppedProcesses(history, StoppedProcesses{{Id: "b"}, {Id: "c"}})
+
+	if assert.Equal(t, 1, len(added)) {
+		assert.Equal(t, "c", added[0].Id)
+	}
+
+	// recorded processes keep their docker id and are matched by task
+	history = StoppedProcesses{{Id: "0123456789ab", Task: "arn:aws:ecs:us-east-1:778743527532:task/1", Name: "web"}}
+
+	added = newStoppedProcesses(history, StoppedProcesses{
+		{Id: "3723932cd2aa", Task: "arn:aws:ecs:us-east-1:778743527532:task/1", Name: "web"},
+		{Id: "3723932cd2ab", Task: "arn:aws:ecs:us-east-1:778743527532:task/2", Name: "web"},
+	})
+
+	if assert.Equal(t, 1, len(added)) {
+		assert.Equal(t, "3723932cd2ab", added[0].Id)
+	}
+}
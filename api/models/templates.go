@@ -76,7 +76,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesAppTmpl = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x7c\xff\x73\xdb\xb6\x92\xf8\xef\xfd\x2b\x30\x9c\xce\xc8\x6d\x25\xd9\x4e\xde\xeb\xe7\x7d\xd4\xcb\x9b\x71\x64\xa5\x71\xeb\xd8\x3e\x49\xc9\x9b\x6b\x93\xc9\xd0\x14\x64\xb1\xa6\x48\x95\x20\x6d\xab\x1e\xfd\xef\xb7\x8b\x2f\x24\x00\x82\x22\x2d\x2b\xb9\xbb\xb9\xf3\x7b\x6d\x6d\x62\xb1\x58\x2c\x16\xfb\x0d\x0b\x3c\x3e\x92\x19\x9d\x87\x31\x25\x9e\xbf\x5a\x79\x64\xb3\xf9\x86\x90\x47\xf8\x87\x10\xef\xe4\x5f\x93\x29\x5d\xae\x22\x3f\xa3\x6f\x92\x74\xe9\x67\x1f\x68\xca\xc2\x24\xf6\xc8\x80\x78\x2f\x8e\x8e\x8f\x7a\x47\xff\x1f\xfe\xef\x75\x05\xf8\x30\x89\x67\x61\x06\xed\xcc\x1b\x48\x14\x80\xea\x91\x64\x12\x07\xf1\xae\xfd\xc8\x8f\x03\x9a\xf6\x82\x12\x94\xf4\xc5\x98\x15\xe0\x55\x9a\x04\x94\xb1\x56\xb0\x29\xbd\x09\x59\x96\xae\xeb\x80\xbd\xab\x34\xbc\x03\x48\x24\x8c\x78\x6f\xe2\xc1\x60\xf4\x67\xee\x47\x48\xe8\xef\xf8\x65\x4c\xe7\xf0\x6b\x01\x45\x36\x5d\xe2\xfd\x07\x05\x1c\x9f\x88\x40\xb1\x91\x73\xbc\xf2\x53\x7f\x49\x33\xe0\x03\x32\x61\xfb\x24\x57\x08\xdb\x62\x82\x06\x9c\x22\x78\x18\xe5\x0c\x86\xd1\x38\x09\x1f\xa7\xeb\x15\xe5\xcc\x9f\x64\x69\x18\xdf\x48\xc6\xf3\xa6\x53\x3a\xf7\xf3\x28\xe3\xad\xe6\x77\x16\xa4\xe1\x2a\x53\xcb\xe6\xc9\xa6\x4d\xb7\x18\x69\x95\x3b\x46\x01\xd0\x8b\x7c\x79\x0d\x14\x38\x06\xe1\xcb\x7f\x54\x37\x0c\xb4\x0e\xaf\xde\x13\xb6\xf0\x53\xca\x48\x32\x27\xd4\x0f\x16\x44\xce\xb6\x3a\xfe\x29\x5d\x45\xc9\x7a\x49\xe3\xec\x9d\xff\x10\x2e\xf3\xe5\xde\xa9\x91\x78\xc9\x8a\xa6\x01\x0c\xe3\xdf\x50\xa4\x4a\x12\x04\x24\x66\x09\xb9\xa5\x74\x45\xd2\x3c\x8e\x81\xad\xe4\x7e\x11\x46\x14\xb6\x05\xd2\x85\x6c\xde\x46\x72\x18\xef\x48\xf2\xf1\x76\x92\x05\xde\xfd\x91\x3c\x8a\xef\xc2\x34\x89\x91\x66\x37\xb1\xf5\x22\xb5\x45\xa2\x9c\x02\xf5\x2b\x5d\x7f\xe9\x21\xb4\xed\xdc\x6e\x18\x53\x3a\x53\x8a\x1b\x30\x8c\x61\x83\xc5\x7e\x44\xa2\xc4\x9f\x11\xb5\x6d\x19\x7c\x07\x46\x73\xfc\x84\xe5\xd7\x31\xcd\x58\x0d\xc9\x17\x89\xde\x70\x12\x45\xc9\x3d\x9d\x7d\xf0\xa3\x9c\x0a\xc5\xc2\x55\x48\x97\xc3\x91\x4f\x95\x39\x8c\x69\x44\x7d\xe6\x9a\xc3\xbe\x77\xf8\x98\xae\x12\x16\x66\x49\xea\x5a\x97\xe7\x0d\x36\x49\x72\x10\x50\x12\x24\x33\x4a\xd2\x72\x98\x0a\x09\x13\xc9\x48\xf7\xf8\xe7\xa0\xbc\xff\x0d\xac\x0d\xa8\xe5\xe1\x8b\xc1\x40\x00\x0f\x06\x67\xb3\x7f\xee\x42\xd3\x87\xab\xa1\x5a\x38\x32\x4f\x52\x92\x2d\x42\x46\xd0\xb8\xd5\x51\x55\x2f\x4d\x5f\x86\x38\x4b\xba\x1a\x88\x54\x46\xd7\xa0\xce\x92\xe8\x83\xf1\xe8\xdf\xdf\x9f\x8d\x47\xa7\xdf\x91\x73\x7f\x79\x3d\xf3\xc9\x10\xcc\x47\xb2\x9c\x26\xab\x30\x20\x6f\xfd\x78\x16\xd1\x94\x48\x89\x23\x0a\xa3\x46\x26\xe8\x9b\x73\x1a\xdf\x64\x0b\x4e\xe4\xb1\xde\x64\x6d\xab\x2a\x7d\x57\xc3\x1a\xce\x95\x4c\x03\x18\xe4\xd8\xae\x0c\x73\x32\xc8\xb0\xcb\xef\xa0\x05\xa8\xd3\x05\xcc\xbb\x4a\xd2\xec\x2a\x4d\xb2\x24\x48\x22\x93\xc2\x45\x96\xad\x84\x2b\x80\x8b\x4b\x63\x9a\x6a\x70\xde\xdb\xe9\xf4\x0a\xb7\xed\x59\xcc\x32\x54\x09\xae\xb6\x09\x0d\xf2\x94\xd6\x41\x4c\xbc\x92\x3f\x72\x38\xb6\x7d\xbc\xc9\xb3\x07\x34\x46\xcc\x82\x2d\xf3\x9b\x0e\x6b\xa7\x27\x9b\xea\x07\x9b\x4c\xce\xed\xa1\xa2\x2d\x53\x43\xf0\xe7\x0d\x45\x36\xce\xf5\x1e\x53\xc6\x35\x8f\xb1\xe0\x9a\xcc\x8f\x93\xa8\xc6\x3a\x70\xa1\x3c\x3b\x79\x37\x18\x70\x18\x6d\x26\x30\x38\x98\xdb\x2c\xa4\xa6\x9a\x42\xd5\xce\x58\xbe\xa4\x08\x7f\x95\x44\x61\xb0\x3e\x4d\x82\xbc\x62\x49\xad\xcd\x8a\x0e\xf2\x8b\x1e\xf8\xc8\xc7\xff\x4f\x1b\x84\x03\x4d\x32\xd8\xfd\xb2\xff\xef\x46\x13\xb1\xf0\x71\xf0\xd1\x7c\x4e\x03\x6e\x70\xb8\x89\xb1\xb0\x49\xd2\xc3\x38\x08\x57\x7e\x24\x96\x62\x42\xd3\xbb\x30\xa0\xc2\x08\x45\x5c\x21\xf4\xfd\xa5\xff\x57\x12\xfb\xf7\xac\x1f\x24\x4b\xee\xd8\x3a\xf0\x9c\x04\x52\xa3\x40\x3f\x96\xb1\x41\x39\xf1\xd2\x82\xa9\x9f\x8d\xf1\xb7\xde\x6a\x60\x06\x97\x19\xb4\x0a\x10\x7f\xe8\x99\x9f\x91\x93\x82\xd7\x26\x0f\x6c\x0e\x08\xc8\xf5\x05\xf8\xdd\x9c\x07\xb3\x25\xf8\x46\xe0\xec\xfb\x60\x69\x2a\xbc\xf0\x1a\x16\x88\xc3\xb4\x59\x24\x0e\x68\x2c\x14\x32\xb6\xb2\x14\x1a\xcb\xbc\xef\xf1\x4f\x25\x98\xe2\x03\xd9\x34\xb0\x4d\xff\xab\x84\xdc\x54\x9d\xf4\x52\xb4\xb7\x88\xb5\xd0\xfd\x83\xc1\x9b\x3c\x16\x54\xb5\x92\xee\x21\x18\xef\xaa\x24\x4f\x5e\xbe\xce\x83\x5b\x9a\x95\x21\xd3\x2f\x49\x28\x45\xa3\x07\x33\x85\xff\x40\xb0\x75\x97\x3c\xc0\xef\x65\x04\xc5\xc9\x18\x43\x34\x86\xda\x1b\x26\x5f\x95\x33\x40\x2c\x1d\x44\x1b\xab\x40\x9a\x0a\x23\x75\x68\xa0\x2d\x02\x4f\x0c\xcc\x0e\xe7\x3c\x18\x85\xbf\xfb\x7f\x85\x2b\x4f\x0c\x52\x2b\x7e\xd2\xf8\x21\x16\xb9\x13\xe8\x83\xf0\xfb\x4c\x79\x7c\x47\x97\xe0\xb9\x4c\xc2\xbf\x38\x3b\x8f\x5f\xfc\xc3\x6c\x56\x0a\x45\x10\xfd\x33\xcd\x4e\x32\x21\x15\x15\xad\x83\x32\x91\xc6\x95\x1d\xe6\x8d\xf3\x38\x0b\x85\x0c\xc7\xc0\xf1\x3f\x98\x39\xc0\x14\xda\x92\x9c\xcb\xd6\xcb\x23\xaf\x5e\x14\xdc\xb1\x66\x5a\xe8\xc3\xda\x70\x33\x00\xf7\xff\x8f\xe4\xba\x0d\xa8\x8a\x4c\x9f\x00\xca\x84\xca\x69\x13\xa3\xdb\x58\x5d\xc0\x0c\xcd\x42\x98\xad\xbd\x1a\x64\x2c\x13\x71\xba\x69\x15\x2e\xf3\x6c\x95\x67\xcd\xe9\x87\x44\xc2\x35\xce\xaa\x11\xb0\x98\x94\x09\x59\x38\x98\x34\xcb\x2c\xb7\x04\xf5\x0f\x86\x08\x42\x98\xa4\x7c\x17\x70\xb6\xb9\xfb\x06\xff\x81\x11\x69\x3c\xe3\x78\xb5\x74\x8d\x2b\x3f\x22\xb2\x37\x8f\x24\xf5\x63\x88\x18\xbf\xbd\x25\x83\x57\xa4\x3f\x8a\x81\x40\x54\x9f\x4c\x4d\xc2\x7b\x0d\x8c\xb8\x05\xb8\x7c\x05\x3a\x01\xe1\x36\x9b\xd2\x66\x54\x33\x24\xb8\x35\x3d\x4d\xa2\xbd\xd3\x90\xf9\xd7\x11\x9d\x99\x28\xc0\x75\x0b\x53\x3a\x1b\x26\x79\xac\xa9\x8d\x8b\x84\x6f\x14\x47\xde\x45\xcd\x7e\x0b\x16\xdc\xf0\xbd\x63\x1c\x59\x0e\x5e\xf2\x62\x3b\x57\x54\x52\xc5\xe2\x08\xe5\x1c\x29\xf8\x50\x8e\x4c\xfb\xc8\x21\x68\x10\x7a\x40\x77\x2b\x6a\xc2\x79\x23\xff\xf0\xf7\x1f\xf5\xef\x66\x28\xff\x1a\x43\xf7\xf1\xc9\x3b\x0c\xda\x41\xf6\x81\xcf\xd4\x33\xc4\xd6\x41\x85\xcd\x4a\x83\x16\xb2\x85\x18\xd3\x7d\xaf\x78\xd3\xd3\x05\x25\x31\xef\x8a\x44\x85\xdc\xed\xca\x42\xae\x4c\x79\xaa\x26\x83\x76\xc9\x42\x24\x17\xa4\x1c\xa2\x3b\xd0\xa0\x66\xb6\x21\x89\xc9\x1a\xb6\x30\x09\x64\x92\xaa\x18\x0e\xc2\x08\x25\xdc\xbd\xe3\x72\x8e\x6d\x17\xad\xd8\x44\x7c\x7d\xbc\x5f\xa1\x91\x85\xe5\xe6\x71\x6c\x1c\x05\x22\x76\x0a\x8e\xe5\x9d\x27\x37\x3f\xa7\x49\xbe\xda\xd6\xad\x80\x51\xfd\xb6\xd3\xa5\x69\xac\x1a\xca\x0c\x33\x2c\x5b\x21\x56\xcc\x52\xea\x2f\x55\x7e\xd4\x69\x81\xbd\xc9\xc2\x4f\x8b\x75\x3e\x2e\x77\x7d\xcd\x5c\x4c\x7b\x9f\xdc\x30\xfe\x6f\x01\xd4\x66\x2a\xa5\x9e\x56\x3b\xe3\xdb\xa5\x1f\x87\x73\xca\x32\x6d\x6b\xc0\xe7\x70\x4e\xfa\x6f\x7d\x88\x8d\x55\xca\x49\xee\x99\xba\xbd\xe4\x96\xe3\xd1\x70\x32\xf5\xd9\xed\x29\x52\x11\x66\x8e\x30\x76\x05\xb4\xb2\x4b\xee\x01\x18\x4e\x4e\xb7\xf0\x62\xb9\x51\xfd\xe4\x08\x48\x05\x38\x46\x98\xf6\x18\x1a\xb0\xe6\xeb\x1d\xf7\x8f\xda\x39\x44\x72\xe0\x69\x72\x4b\xe3\x46\x9b\x5f\x6b\xef\xa5\xc3\x5a\xe3\x3c\x59\x2e\x13\x78\x9a\xc1\x2d\xef\xc1\x5d\x1c\x5c\x96\x82\x87\x5e\xd5\x8d\xd2\x93\x47\x05\x22\xf5\xcd\x02\xb5\x32\x7f\x05\xb8\xfe\xdd\xea\x52\x38\x68\x6a\x97\xd1\xb5\x0d\x82\x1c\x97\x6a\x5c\xb7\x87\x14\x6d\xcd\xe7\x0c\x1a\x85\x25\xdc\xea\xd4\xba\xe5\x45\x33\x44\xe5\x62\x15\x67\x0b\x48\x4e\xd5\xfa\xb8\xf5\x66\xb7\x46\xd0\xb4\x79\x08\x41\x87\xfe\x20\xea\xaf\xa5\x7b\x50\x4a\x74\x49\x67\xb1\x49\xfa\x0a\x4a\x39\xfa\x7c\x60\x6d\xb1\xba\x26\x76\xb9\x15\x35\x6c\x86\xec\x38\xc4\x8e\xcb\x7b\xf9\xbd\x41\xf2\x15\xb7\xfe\x5b\x88\xbc\x76\x68\x51\xc8\x8e\xfa\x66\x81\x56\x7d\x85\x8a\x33\xe0\x5e\xd4\xaf\xb9\xcb\xaa\xaa\x6b\x1b\x99\x55\x3d\x64\x6f\xda\x22\x86\x28\xdc\xbe\x72\xc5\x2b\xfc\xb1\x8e\x18\xae\xc4\x49\x80\xd1\xbf\x7a\x0e\x51\x8f\x45\x9c\x81\x34\x60\x91\x07\x30\x16\x96\xf3\xc4\x9f\x29\xb1\x77\xc4\xeb\xa5\x41\xe8\x63\xca\x4d\xa5\xe2\xec\x6d\x44\xaa\x8b\x34\xb0\x16\xe9\x49\x1b\xcd\xb1\x84\xe2\x43\x1f\x94\x45\xe6\x83\xd5\x4b\xdd\xcb\xea\xde\x97\xc4\x16\x96\x8b\x44\xf8\x0d\xed\x62\x74\x1d\x65\x93\xbb\xe3\x3c\x06\x35\x1d\xd5\x62\xf6\xba\xdd\xfd\x56\x75\x34\x0c\x6f\xd9\xc7\xcd\x7d\x4f\xa1\x2a\x45\x56\xe1\xe9\x4b\xeb\x2e\xf9\x87\xfd\x91\x7f\x9a\x22\x04\x80\x07\xeb\xd4\xa0\xf6\xf4\x74\x77\xfc\xda\x79\x6b\xc5\x48\x3c\x87\x7a\x91\x5c\xfc\x82\xe4\xcb\x01\x6a\xe9\xdf\x49\x2a\xdc\xa1\xcb\x3e\x25\x62\x97\xa9\xaa\x5f\x1d\xe9\xa7\xe6\x23\x24\x0b\xd7\xd3\x8e\xaf\x76\xa1\x76\x88\x66\x6e\x1e\x06\xf5\xc7\x3a\xfb\x3c\x60\xdb\x85\xc2\xb7\x09\x73\x9d\xc4\xb6\xe2\x65\x18\xcf\xe8\x83\x36\xd8\x18\x22\xb4\x64\xc9\xbe\x32\x8f\x5d\xbb\xab\xe5\x1c\xcc\x93\xd3\x0a\x79\xcf\x38\x55\xdd\x51\x09\x39\x0e\x89\x5a\x4e\x05\xcf\x3f\x76\x9f\x0b\x3f\x89\xea\xaa\x23\xa2\xae\x38\xb9\xe9\x0a\xac\xfb\x9b\x9d\xad\xc2\xff\x6b\x57\x69\x27\xa5\x68\xa6\x06\x76\xd1\x8a\x55\x4b\x62\xf1\xce\xda\x91\x0d\x31\x87\xd5\xd9\x19\x78\xe8\x99\x07\xdb\x9f\xc6\x55\x32\x5c\x1b\xe1\xbf\x9c\x5e\x4c\x84\x83\x6a\x55\x1e\x7d\x45\x9d\xde\x10\x6b\xd5\xa0\xaf\x8b\xbc\xaa\xc9\x97\x67\x51\xba\x35\x8c\x7c\x3a\x3e\x15\x3e\x7c\xf1\xd9\x6b\x52\xd9\xd7\xfd\x69\xd9\x7f\x0f\x3b\xc4\x4e\x52\xed\xb4\x47\x1c\x52\x39\x91\xa9\x78\x33\x11\xb5\x5f\x4e\x99\x69\x2d\x51\x67\x61\x0c\xab\x55\xfe\x38\x43\x58\x8f\x83\x99\x99\xd7\x4a\xb8\x41\x5a\xc4\x84\xbd\xeb\x42\xd2\xac\xc0\xc1\x33\x28\x3a\x8b\x6f\x52\x2c\x67\xab\xa4\x13\xb6\xee\x52\x09\x65\xc5\x45\xde\x30\x9c\xa5\x67\xc8\x5b\xef\xa8\xcf\xff\x77\x78\x54\x3d\xd9\x3c\x5b\xe9\xa7\xe3\xdc\x4e\xd8\x20\x6f\xd2\x64\x89\x03\xef\x73\xbb\x55\x06\x99\x26\xfb\x1e\xc2\x18\x61\xd3\x94\x40\x69\x0e\xd3\xf4\x94\xc9\x87\x55\x70\x36\x33\x88\xc5\x7a\x95\x9a\xaa\x02\x97\x4e\x76\x1c\x04\x08\x21\x8d\x7c\x96\x85\x41\xb9\x97\x61\xa5\x31\x17\x5b\x6e\xed\x52\x68\xf7\x60\x49\x8c\x04\x56\x8b\x7d\x5a\x32\xa1\x6e\xcb\x88\xd4\x17\xfd\x93\xf4\x27\xc1\x82\x02\x0a\x4f\x15\xc4\x79\x56\xc6\x4a\xb4\xcb\x85\x56\xd0\x56\xaa\x4b\xaf\xf0\x12\xfb\xee\x6c\x2e\x48\x55\xd5\x55\xdd\x9a\x60\xdb\x2a\xc2\xaa\xc4\xea\x36\xa0\x15\x90\xeb\xf0\x28\x2e\x11\xa3\x36\xf9\x3a\x65\x15\x5c\x66\x6f\x53\xd8\x70\xe1\x62\xca\x8f\xd2\x4f\x53\x3f\xc4\xb3\x0f\x51\x5f\x20\x70\x8d\x62\xbe\x90\xf0\x47\x96\xe6\xb4\xab\x9f\xe1\xfe\x78\x64\xa8\x8e\x12\x8f\x7e\x22\x08\x7b\x7a\x16\xd1\xb2\xd3\xcb\x1f\x8f\xac\x6e\x69\xc2\xd8\x6f\x49\x4c\xd5\x10\x65\xd3\x5b\xea\x47\xd9\x62\xb8\xa0\xc1\xad\x9d\xcb\x13\x4d\xeb\xe9\x02\x14\xd4\x22\x89\x66\xfc\x80\xca\x5c\xac\x33\x5c\xe9\x3b\x5e\x2e\xf2\x77\x2b\xe3\x95\xde\xb8\xcf\xfc\x45\xae\x46\x96\xed\x38\xb6\x7e\xcd\x8e\x7f\x13\xa6\x2c\xc3\x3f\x94\x83\xe5\xaa\x0a\xd0\x18\xf7\xd2\xf8\xfe\x3e\x5e\x38\x27\x53\x66\x61\x34\x96\xa8\x02\xa4\x1d\x95\xb2\x29\xb5\x7b\x48\x3b\xd8\x9a\xd3\x51\x89\xa2\x47\x1e\x62\xfc\x37\x10\xdb\x9d\xc5\x40\xa4\xdc\x3c\x7a\x0d\x9b\x93\xef\xbb\xc4\x39\xdc\xd2\x55\xea\xb5\x6a\x6a\x82\x74\x95\xf6\xa5\x8d\x0b\x71\xd6\x8a\x7d\x75\xd6\xd4\x14\xa5\xd5\x30\xa8\x80\xdb\x07\x73\xe4\x1e\x71\x15\x23\x4d\xce\xb5\xac\x86\x65\xd4\x9e\x9b\x23\x21\x76\xae\xb6\x42\xc1\xff\x49\xef\xff\x14\xe9\xfd\xdf\x2d\xb7\xc6\xdf\x9f\xf6\xed\x4e\xd6\x54\x2d\x7e\x75\x13\x23\x92\x3a\x6d\xf6\xa8\x51\x3c\x29\x3c\x16\xde\xb9\x14\xbe\xba\x4e\xca\xdd\x35\xc0\xb5\x26\x57\xfd\x68\x96\xa5\xe1\x75\x9e\x09\x06\x55\xc9\xe1\x40\x8a\x98\x26\x32\x38\xb0\x0a\xa0\x3d\xf4\x7f\xbc\x0a\xcc\xe6\x53\x83\x60\x0b\x42\xf6\x21\xda\x95\x42\xce\x0a\xf7\x5b\x9c\x1f\x7d\x01\x91\x3c\x7f\x3d\x4c\x92\xdb\x90\x4e\x20\x1a\xb9\xc5\xca\x13\x56\xf8\xa8\x38\x71\x53\x00\xfc\x39\x3f\x9c\xc4\x23\x17\x03\x87\x95\x91\x10\x19\x0b\x57\xa2\xa2\x2e\x1e\x76\x9c\xa6\x34\x04\x28\x65\x6d\x80\x16\x84\x35\x65\x3b\x1c\x45\x8c\x7a\xc1\x0a\x38\xcc\xf1\x2f\xc9\x75\x59\xe8\x86\x1f\x8a\x6a\xd8\xba\x50\xae\xb6\x6c\xb6\x36\xdb\xa0\x20\x15\x5b\xf9\x6c\xfd\x00\xcb\xd7\x7a\xf8\xfb\xc9\x6a\x25\x27\xdd\x43\x8a\xf5\x8c\x90\x56\x8f\xca\xd3\xf8\xfd\x85\xfc\xa0\xc1\x3c\xb3\xd8\x74\x6b\xa9\xa9\xe6\x6b\x1f\x1f\x19\x21\x4a\xa5\x0a\xd8\xfb\x2d\x5c\xbd\x09\x39\x29\x76\x3c\xe0\x7d\x8c\x31\x1e\x30\x24\xdb\xeb\xe4\x10\x7b\x31\xd0\x00\x41\xd6\xf9\xc9\x2e\x7a\xb7\xff\xbe\xf3\x53\xc2\xb3\xde\xe4\x15\x49\xe9\x9f\x39\xc4\xbc\x07\x1d\xfe\xa1\xf3\x5d\xa5\x33\x02\xfb\xf7\x06\x28\xfc\xd9\x63\xb3\xdb\x1a\xe0\x20\x4a\xf2\x59\x51\x27\x0c\xfd\x62\x7a\x8f\x18\xfa\x43\x6c\x78\xa3\x1a\x0e\xdc\xbd\xff\xcc\x69\xba\x66\x3c\x37\xae\x0f\xa9\x7d\x76\x0c\xeb\x42\x24\x8e\xf2\x00\xc7\xa3\xdd\x8a\xff\x2a\x52\x4f\x03\xd2\xd1\x24\xa8\x63\xc3\x6e\x1a\xc7\x92\xd5\x5d\x7d\x1a\xdf\xf5\x2f\x2e\x4f\x47\x9f\xa7\xe7\x93\xcf\xe3\xd1\x2f\xa3\xe1\xf4\xf3\xfb\x8b\x93\xf7\xd3\xb7\x97\xe3\xb3\xdf\x46\xa7\x40\x49\xe7\xa8\x79\x6d\xe8\xc3\x0a\x55\xa7\x12\x4d\xe8\x35\x97\x02\x7f\x40\xef\x68\x9c\x75\x49\x90\x40\x04\xf9\x90\x7d\xe7\x9e\x18\xb4\x32\x90\xce\x7e\x94\xdc\x1c\x74\xf0\x6a\xd2\x68\x32\x25\xe3\xd1\x70\x74\xf6\x61\x74\x0a\x93\x25\x3f\x90\x5f\x26\x97\x17\x7d\xc1\xcb\x70\xbe\x16\x68\xbf\x6b\x66\x2a\xc7\x6e\xac\x6d\x7f\xc6\x93\x7e\xd7\x94\x73\x93\x1d\x08\x96\x77\x35\x8a\xd3\xb4\x4b\x66\x7e\xe6\xd7\x10\x8b\x3f\xa0\x3b\x10\x6e\x0b\x84\x3d\x2d\x84\xae\x50\xab\x7e\xd9\x88\x34\xc4\x56\x64\x28\x1e\x33\x9f\x2d\xae\x13\x3f\x9d\xd5\x62\x52\x90\x2b\x9f\xb1\xfb\xa4\x05\xa0\x3c\x27\x81\x25\xc3\x29\xf7\x05\x53\x7e\x3f\xfa\xd4\x97\xb5\xd1\x2d\x46\x52\x37\x9c\xab\x38\xca\xdb\xcf\xad\x16\x4a\xfd\xc0\x5a\x1d\x20\xea\xf0\xd5\xd1\x4f\x8a\xc0\x7e\xc4\xaf\x9b\xfd\x33\xfc\x89\x84\x3f\xfc\xd0\xc0\x78\xfc\xc1\x25\x92\x7d\x7f\x0f\xd5\x74\x7e\xa5\x6b\xf2\x0a\x64\xfa\x54\x31\xb2\xd3\x02\x13\xfe\x14\x9c\x87\x49\x56\xb0\x72\x73\xbb\x95\x51\xf8\xb3\xd9\x06\x50\x69\x6c\xe2\x0f\xe7\x4d\xc9\xfb\x9d\xd8\x53\x76\xc7\xb9\x14\x8b\xa5\x98\x74\x25\x65\xa8\x2d\x8f\x94\xcc\x01\x8b\x6a\x30\x7f\x6d\x46\x71\x01\x5f\x89\x62\xe5\x1a\x9d\xaa\xff\x2c\xc0\x71\x1b\x94\x4b\xdd\x6d\x82\x47\xa5\x37\x20\x7f\xfb\xdb\xcb\x66\x48\x3f\x5b\x80\x1e\x3b\x04\x47\x9f\x1d\xa2\x3a\xe3\xfa\xab\x0f\x7f\xc2\xef\x9d\xc3\xe2\x9e\xb6\xd6\xa6\xaa\xaa\xb1\x3d\xcd\xe3\x4e\xe3\x18\xc0\xe1\x45\x32\x83\x51\xae\x2e\x27\xd3\x66\xf0\x05\xf5\x67\xb0\x3e\x83\x76\x6b\xdb\x39\x09\x02\xba\xca\x3a\x80\x1e\x88\x8e\x30\x98\x02\xae\x1e\xfe\xc1\x92\x16\x94\x71\x04\x58\x64\x05\xf3\xea\xa1\x27\x65\xa3\x79\xe8\xdd\xdf\xdf\xf7\x50\x45\xf7\xf2\x14\x24\x19\xaf\x07\xcf\x5a\xe2\x7d\xcf\x68\xda\x3b\xb9\x01\xd4\x88\x15\x3c\xc6\xe8\xb0\x62\x10\xed\x4e\x9b\x46\xd4\x7e\xce\x17\x4c\xdc\x58\x1a\xe0\xaa\x28\xe9\xde\x2a\x9c\x4f\x52\x73\x28\x9d\xe0\x2b\x80\x64\x72\x37\xa6\x8f\x7e\x03\x65\xd9\x81\x94\x58\xcd\x24\x81\xf7\xda\x62\x0f\x22\xbe\xeb\x64\xb6\x46\xa3\x5d\xb5\xd9\x36\xb4\x61\x73\xf1\xe2\x5a\xce\x06\x9d\x2e\x5e\x38\xe8\x33\xfe\x17\x3a\x78\xf5\x26\xcb\x89\xe6\xad\x94\x29\xc0\x63\xd9\x6b\x44\x2b\x25\xce\x61\xb5\x6d\xa4\x9c\x08\x9a\x8d\x50\x10\x00\xc1\x41\x27\xcf\xe6\xff\x70\xb8\x50\xae\x7e\xc0\xae\x0e\x9a\xa1\x8e\xc6\xbf\x60\x91\xc7\xb7\xc0\x41\xc1\x9e\x1f\x5e\x11\xfe\x81\x6c\xda\x63\x84\xd0\x42\x47\xd8\x52\x23\xea\xcc\xc1\xa1\x9b\xc7\x93\xbd\xd0\x57\xea\xcf\x92\x98\x3a\x1c\x4e\x1b\xbc\x61\x16\x8e\xe6\x6d\xe0\x20\x84\x62\xc2\x69\x9a\xa4\x1d\xd3\x2d\x4a\x9a\x1c\x1e\x7b\xce\x9d\x11\x76\x42\x71\x10\xbd\xdb\x48\x13\x9f\xfa\xdc\x0f\xa3\x83\x16\x7d\x9e\x38\x39\xee\xaf\x80\x92\x3f\x05\xf9\x80\x5d\xa2\xf9\xe7\x9a\xa8\x36\xcc\x10\x74\xc2\x72\x09\x7e\x6e\x87\x0c\xa4\xa6\x96\x1f\xf6\xbc\x08\xf7\x10\xfa\xd2\x03\x45\xed\x76\x36\x20\x3c\x48\xe8\x16\x61\x71\xda\x54\x07\x55\xa0\xc1\xb4\x0f\xe6\x4d\x49\xc7\x19\xa8\x55\x78\x20\x73\x5a\x95\xa8\x9a\x1f\x03\x2e\xc0\x5a\xca\x20\x77\x9c\x1b\xb7\xad\xad\x83\x52\x64\x2a\x1b\x0c\x38\x50\x63\x64\xad\x47\xd4\xe7\x49\x7c\xa3\xc2\x68\x16\x2c\xe8\x2c\x37\x2f\x6c\x4f\xe4\xb7\xd1\xc3\x0a\xcf\xe2\xe5\xf9\xaa\x3a\xa3\xc4\x16\xab\x0e\x4e\x9c\x6e\x55\x72\x53\x3c\x84\x76\x87\xdb\x7a\xfa\xa0\xae\xea\x9d\xa7\x34\x6d\x82\xe5\x41\x9a\x75\xea\xb6\x12\x17\x3c\x1f\x3f\xe2\x63\x4b\x1f\xe1\xd7\x8f\x9e\x99\x2e\xf8\x08\xa3\x7c\x54\xe1\x5c\x09\x20\x73\x54\x05\x80\x14\xd1\x12\x60\x28\x3e\x70\x80\x8d\x76\x3c\xe6\x48\xb1\x38\xd6\x4f\x24\x40\xae\x68\xba\x0c\x19\x73\x65\x4a\x88\x9d\x2a\xd1\x60\x5d\x4b\x4a\x8c\x35\x95\x57\xa4\x49\x71\x05\x77\x70\x06\x66\xf8\x96\xba\xae\x29\x1b\x99\x15\xb2\xe3\xa2\x68\xf7\xe1\x71\x50\xbe\xad\x99\x75\x03\x5e\x97\x23\x9e\x4a\xe2\x68\x6a\x4b\xb5\x2a\xe2\xae\x0d\xfc\xf4\x34\x96\xf3\xbd\x2a\x71\xb5\x4c\x5c\x9e\x7e\xeb\xb3\xb1\x84\xd1\x17\x03\x29\xbb\x4c\x8d\xe4\xf3\xb6\xf7\xac\xcc\xdb\xd8\x40\x71\xce\x7a\xd4\x67\x59\xef\x18\xe8\xd6\x4f\xbe\x9f\x88\xe3\x1e\xdc\x9a\xde\x8b\x67\xe0\xa0\xb9\xc0\xc1\xe9\x90\x28\x50\x54\x6b\xaf\xaf\xb9\x2e\x0f\x97\xfc\xc2\x16\xc7\x23\x37\x8e\xfb\x32\x63\x0d\x4c\x6e\x06\xa3\x38\xa3\xca\x7d\x09\xe5\xbc\x54\x53\x77\xa5\xef\xb9\x97\x69\xb4\x5b\x2d\x75\xa5\x4a\xdf\x28\x89\x6b\x66\x99\x75\xab\x52\xcd\x4c\x9c\xc4\x3c\x89\x07\x95\x52\x3e\x4e\x16\x84\x12\x58\xab\x02\xf8\xb4\x9b\x8b\xdb\x96\xe5\xc9\xa3\xd9\xec\x73\x20\xef\xea\xcf\x1c\xe9\x15\x94\xb5\xfc\xb1\xae\x98\x1b\x97\x71\xdc\xd7\x2d\xed\x57\x43\x6a\x96\xbf\xd5\x6b\x21\xb5\x8f\x80\x58\xcf\x30\x94\x2f\x72\x18\xdf\x49\xe5\x7d\x0e\xa3\xd9\x3a\x15\x69\x78\x42\xc4\x7c\x3e\xc4\x1e\x47\x7b\x4c\xc4\x6a\xc2\x4c\x61\x60\xeb\x55\x0b\xc6\x3c\x35\x31\x9e\x69\xf8\xc6\x41\x6d\xcd\xdb\x1c\x4a\xc3\x96\xb6\xa6\xf2\xa8\x88\xfb\x68\xce\x30\xf3\xe6\x51\x88\xbe\xde\xdd\x2a\x58\xfd\x1b\x2f\xfb\x7f\xbe\xa5\x76\x91\x79\x2b\x15\x95\x66\xf8\x4a\xd9\x75\x51\x69\x76\x2a\xb3\x9e\xdf\x3b\x0f\xce\xea\xfa\x08\x9d\x40\x53\x75\x42\xc6\xb0\x62\xd0\x59\xb3\xd6\x8c\x6d\x6c\xe3\xfa\x57\x98\x2d\x5a\xe0\x0a\x5e\x34\x12\x0f\x20\x27\x10\xb0\x27\x69\xf8\x17\x75\x56\x5d\x56\x7a\xb9\x8e\x01\xb5\xa7\x58\x9c\x7c\xfd\xde\x81\xc6\xfa\xb2\xe5\x8d\x9b\x8a\x1b\xfd\xa9\x59\x25\xeb\xb7\x63\xd1\x37\xb0\xce\x83\x8d\x47\x19\xfa\xc5\xa9\x59\x79\xc1\x53\x2a\x25\xdd\x47\x36\xaf\x9d\x7a\x67\x4b\xff\xa6\x68\xe3\x7f\x94\x8d\x8f\x8f\xfc\x88\x4c\x78\x8a\x27\x69\xea\xaf\xcb\xea\x71\xf9\xd5\xdc\x3b\xc5\xc5\x3e\x7e\x50\xd5\x25\xdf\xd2\x88\xcb\x3d\x2f\x19\x76\xe2\x51\x1d\xf1\x4e\x2d\xef\xb4\xd9\x74\x1f\x1f\x31\x19\xbe\xd9\xc0\x7f\xe3\x59\xf5\x6a\xad\x42\xba\xd1\xdc\x56\x13\xf4\x93\x22\x9f\x27\xd5\x01\x75\x4c\x8b\xe1\xc5\x05\x06\x88\xbe\xcc\xaa\x3d\x35\x19\x98\xc2\x1d\x9a\x11\x1b\x7e\x53\x14\x5a\x97\x03\x79\xe5\xcb\x0f\x8e\xea\x36\xf3\x75\x88\xc2\x93\x76\x3f\xab\x58\x72\xee\x96\xae\x81\x6f\x82\x06\xf1\x16\xc7\xdd\x3b\x7f\x65\xd4\x18\x62\x31\x36\x80\xc9\x5a\x53\x45\xb0\xec\xb3\xd1\xdf\x7d\x31\x0e\x8a\xeb\xc7\x38\x0f\xe3\xdb\x0f\x7e\xca\xb6\x8e\xd2\x88\xdf\xfb\xf5\xec\x62\x34\x39\x9b\x78\xee\xe7\x16\x0a\xb5\x7b\x7e\xf9\xf3\xe7\x9f\xc7\x97\xef\xaf\xbc\x9a\x07\x16\x4a\x05\x3d\xbe\x1c\x8e\x26\x13\x5b\x72\x8d\xc8\xe4\x43\x12\x81\xd6\xd5\x75\x78\x19\x86\xbe\x43\x2f\x03\xeb\x17\x24\x90\x3d\xbf\xbe\x1e\xed\x55\xd8\x25\x48\x23\x35\x87\xea\x52\xc6\x94\x51\x30\x08\x68\xd7\x53\x2f\xfc\x30\xc9\xaf\x3c\x94\xd0\x5c\x2d\xe2\xae\xbe\xd4\xbd\x84\x6d\x17\x91\xdb\x95\xe7\x54\xaf\xca\x96\x06\x59\x77\x46\x77\xe4\x24\xd6\xf3\x86\x11\xbd\xa1\x45\x74\x5c\x7e\x91\x83\x89\x50\xa9\x16\x1d\x68\xd4\xba\xf8\x49\x7f\xfe\xa7\xfa\xc8\x8e\xe9\xb5\x4d\x5e\x0e\x06\xf2\x29\x2b\x49\xda\x29\xa8\x1c\x34\xb8\x45\x9d\x04\x50\x80\x5c\x68\x70\xeb\x02\x0c\xc1\x91\x61\xa9\x28\xc1\xb7\x0b\x9b\xbd\xa9\x7f\xc3\xec\xd8\x4c\x3c\xa5\xe0\xb1\x35\x98\xca\x25\x7a\xa9\x45\x41\x8b\x7c\x3d\x8b\x98\x31\x94\x84\xc7\x87\x0e\xbb\xb5\x1e\xb7\x1e\x08\xb8\xec\x8f\xc6\xb5\xff\x0c\x00\x00\xff\xff\xd3\x4d\x82\xae\xe0\x59\x00\x00")
+var _templatesAppTmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x7c\xff\x73\xdb\xb6\x92\xf8\xef\xfe\x2b\x30\x98\xcc\xc8\x69\x69\xc5\x4e\xfa\xfa\xe9\xa3\x3f\x7e\x33\xae\xac\x34\x6a\x1d\xdb\x27\x39\xe9\x5c\x13\x4f\x87\x26\x21\x09\x35\x05\x30\x00\x68\x5b\xd5\xe8\x7f\xbf\x01\xc0\x2f\x00\x09\x8a\xb4\xac\xe4\xde\xcd\x9d\xdf\xbc\x69\x44\x2c\x16\x8b\xdd\xc5\x62\x77\xb1\xc0\x6a\x05\x22\x34\xc5\x04\x01\x18\x24\x09\x04\xeb\xf5\x1e\x00\xab\x3d\x00\x00\x80\xa7\xbf\x4f\xae\xd1\x22\x89\x03\x81\xde\x52\xb6\x08\xc4\x47\xc4\x38\xa6\x04\x02\x1f\xc0\xd7\x87\x47\x87\x07\x87\xff\x3c\x38\xfc\x27\xf4\x34\xf8\x80\x92\x08\x0b\x4c\x09\x87\x7e\x86\x02\x80\xd5\x0a\x88\x0c\x07\x80\xb7\x41\x1c\x90\x10\xb1\x83\xb0\x04\x05\x7d\x3d\x66\x0d\x38\x61\x34\x44\x9c\x77\x82\x65\x68\x86\xb9\x60\xcb\x26\x60\x78\xc5\xf0\x7d\x20\x90\x24\x0c\xc0\xb7\xc4\xf7\x87\x5f\xd2\x20\x96\x84\x7e\x92\x5f\xc6\x68\x0a\xfd\x12\x0a\xac\x3d\x00\xff\x13\x71\x08\x6e\x80\x46\xb1\xce\xe6\x78\x15\xb0\x60\x81\x04\x62\x1c\x82\xb6\x49\x26\x12\xb6\xc3\x04\x2d\xb8\x9c\xe0\x41\x9c\x72\x81\x98\xc1\x49\x00\xe0\xf5\x32\x41\x8a\xf9\x13\xc1\x30\x99\x41\xaf\x6c\x3a\x43\xd3\x20\x8d\x85\x6a\xb5\xbf\xf3\x90\xe1\x44\xe4\x62\x83\x59\xd3\xda\x2b\x46\x4a\x52\xc7\x28\x3e\x80\x17\xe9\xe2\x16\x31\xd7\x20\x4a\xfc\x87\x4d\xc3\xf8\x00\x0e\xae\x3e\x00\x3e\x0f\x18\xe2\x80\x4e\x01\x0a\xc2\x39\xc8\x66\x5b\x1f\xff\x0c\x25\x31\x5d\x2e\x10\x11\xef\x83\x47\xbc\x48\x17\x3b\xa7\x26\xc3\x0b\x12\xc4\x42\x44\x44\x30\x43\x92\xaa\x8c\x20\xc4\x81\xa0\xe0\x0e\xa1\x04\xb0\x94\x10\x4c\x66\xe0\x61\x8e\x63\x04\x22\x45\x97\x64\xf3\x26\x92\x31\xd9\x92\xe4\xa3\xcd\x24\x63\xb2\x5b\x92\x87\xe4\x1e\x33\x4a\x24\xcd\x6e\x62\x9b\x55\x6a\x83\x46\x39\x15\xea\x37\xb4\xfc\xda\x43\x18\xcb\xb9\xdb\x30\xb6\x76\x32\x24\x17\x20\x26\x02\x31\x12\xc4\x20\xa6\x41\x04\xf2\x65\xcb\x01\x26\x20\xd1\xf8\x01\x4f\x6f\x09\x12\xbc\x81\xe4\x0b\x6a\x36\x9c\xc6\x31\x7d\x40\xd1\xc7\x20\x4e\x91\x36\x2c\xca\x84\x78\x0a\x0e\xdc\xd4\xe6\x30\x46\x31\x0a\x38\xfa\x06\x2b\x7c\x8c\x12\xca\xb1\xa0\x6c\xb9\xf3\xc1\x26\x34\x65\x21\x02\x21\x8d\x10\x60\xe5\x30\x35\x12\x26\x19\x23\xdd\xe3\x9f\x63\x2e\xfe\xff\xe9\xef\x13\xdf\x1f\x0e\x5e\xfb\xbe\x06\xf6\xfd\x51\xf4\xaf\x6d\x68\xfa\x78\x35\xc8\x05\x07\xa6\x94\x01\x31\xc7\x1c\xc8\xcd\xad\x89\xaa\x66\x6d\xfa\x3a\xc4\x55\xb4\xab\x85\xc8\x7c\xd3\xb5\xa8\xab\x68\xf4\xfe\x78\xf8\x1f\x1f\x46\xe3\xe1\xd9\x4b\x70\x1e\x2c\x6e\xa3\x00\x0c\x52\x2e\xe8\xe2\x9a\x26\x38\x04\xef\x02\x12\xc5\x88\x81\x4c\xe3\x40\x8e\xd1\x20\xf3\x3d\x26\xe7\x88\xcc\xc4\x5c\x11\x79\x04\xbd\x0a\x23\x4a\x25\xa9\xd3\x77\x35\x68\xe0\x5c\xc9\xb4\x8f\x57\x03\xc9\xb1\x6d\x19\xe6\x64\x90\xb5\x2f\xbf\x0f\x92\x04\x93\x99\xa9\x60\xf0\x8a\x32\x71\xc5\xa8\xa0\x21\x8d\x6d\x0a\xe7\x42\x24\x50\xb9\x02\x52\xb8\x88\x20\x66\xc0\xc1\x77\xd7\xd7\x57\x72\xd9\x8e\x08\x17\xd2\x24\xb8\xda\x26\x28\x4c\x19\x6a\x82\x98\xc0\x92\x3f\xd9\x70\x7c\xf3\x78\x93\x67\x0f\x68\x8d\x28\xc2\x0d\xf3\xbb\x1e\x34\x4e\xef\x7a\xd0\x32\xd8\x64\x72\x5e\x1d\x2a\xde\x30\x35\x09\xfe\xbc\xa1\xc0\xda\x29\xef\x31\xe2\xca\xf2\x58\x02\x37\x74\x7e\x4c\xe3\x86\xdd\x41\x29\xe5\xe8\xf4\xbd\xef\x2b\x18\x63\x26\x57\x8c\x26\x88\x09\x6c\x21\xd5\xa6\x9d\xf3\x74\x81\x24\xfc\x15\x8d\x71\xb8\x3c\xa3\x61\x5a\xdb\x49\x2b\x8b\x55\x3a\xc8\xaf\x0f\x8e\x0e\x0f\x8e\xfe\x1f\xf4\x6c\xa0\x89\x08\x04\xca\xfa\x7f\xb2\x9a\x40\x05\x9f\xde\xba\xa7\x53\x14\x0a\xe8\x67\x5b\x0c\xf4\xea\x20\x57\x0c\x93\x10\x27\x41\xac\x45\x31\x41\xec\x1e\x87\x48\x6f\x42\xb1\x32\x08\xfd\x60\x11\xfc\x4d\x49\xf0\xc0\xfb\x21\x5d\x28\xc7\xd6\x81\xe7\x34\xcc\x2c\xca\x27\x00\xb9\xe0\x7e\x39\xf1\x72\x07\x03\xc0\x14\x48\xfe\x67\xb6\x5a\x98\xe1\x55\x20\xe6\x92\xf8\x57\xd0\xfe\x2c\x39\xa9\x79\xfd\x69\x6f\x13\x07\x34\xe4\xf2\x22\x58\x68\xf9\x45\x0b\x4c\xa4\xb3\x1f\x08\xca\xa0\xe7\x06\x6e\x14\x50\x67\x21\xd5\x05\x05\x56\x0e\x51\x18\x2c\x83\xdf\x41\xaf\x54\x4c\xfd\x01\xac\x5b\xd8\x66\xfe\xba\xd9\xab\x7e\x5d\x7b\x0e\xd5\xde\xa0\xd6\xda\xf6\xfb\xfe\xdb\x94\x68\xaa\x3a\x69\xf7\x80\x46\xa8\xae\xc9\x93\x37\x3f\xa7\xe1\x1d\x12\x65\xc8\xf4\x2b\xc5\x99\x6a\x1c\x40\x4f\xfe\x27\xa4\xe4\x9e\x3e\x42\xcf\x88\xa0\x14\x19\x63\x34\x93\x83\xcb\xc9\xd7\xf5\x0c\x4e\xde\x64\x0e\x62\x15\xab\x46\xca\xf4\x26\xf5\xca\x42\x9b\x4b\x4c\x05\x66\xaf\xa6\x2a\x18\xc5\x94\xf4\xff\xc6\x09\xd4\x83\x34\xaa\x5f\xb6\xf9\x41\xbf\x58\x09\xe8\x51\xfb\x7d\xb6\x3e\xbe\x47\x0b\xca\x96\x13\xfc\xb7\x62\xe7\xd1\xeb\x9f\xec\xe6\xdc\xa0\x68\xa2\x7f\x41\xe2\x54\x68\xad\xa8\x59\x1d\xa9\x13\x8c\xd4\x56\x18\x1c\xa7\x44\x60\xad\xc3\x84\x46\xe8\x2f\x6e\x0f\x70\x8d\x17\x88\xa6\x4a\xb7\xde\x1c\xc2\x66\x55\x70\xc7\x9a\xac\xb0\x87\x8d\xe1\x66\xc8\x28\xf9\x8b\xde\x76\x01\xcd\x23\xd3\x27\x80\x72\x6d\x72\xba\xc4\xe8\x55\xac\x2e\x60\x2e\xb7\x05\x2c\x96\xb0\x01\x19\x17\x3a\x4e\xb7\x77\x85\xcb\x54\x24\xa9\x68\x4f\x3f\xd0\x0c\xae\x75\x56\xad\x80\xc5\xa4\x6c\xc8\xc2\xc1\x44\x42\x54\xdc\x12\x69\x7f\x64\x88\x00\x7d\x43\xbf\x0b\xb8\xea\x76\xb7\x27\xff\xbf\x5a\x01\x44\x22\x85\xd7\x48\xd7\xb8\xf2\x23\x8a\xc8\xd5\x0a\xb0\x80\xcc\x10\x78\x71\x07\xfc\x13\xd0\x1f\x12\xc1\x94\xf9\xe4\xf9\x24\xe0\xcf\x71\x40\xee\x56\x2b\x90\x26\x09\x62\x12\x6e\xbd\x2e\xf7\x8c\x7a\x86\x44\x2e\x4d\x68\x68\x34\x3c\xc3\x3c\xb8\x8d\x51\x64\xa3\x38\x43\x1c\x33\x14\x0d\x68\x4a\x0c\xb3\x71\x41\xd5\x42\xa9\x63\x5d\xe5\xb3\xdf\x80\x05\xac\x3d\x70\x70\x24\x47\xce\x06\x2f\x79\xb1\x99\x2b\x79\x52\xa5\xc2\x11\xa4\x38\x52\xf0\xa1\x1c\x19\xf5\x25\x87\xc0\x7a\xad\xed\x80\xe9\x56\x34\x84\xf3\x56\xfe\xe1\x1f\x3f\x9a\xdf\xed\x50\xfe\x67\x40\xa7\x60\x7c\xfa\x1e\x08\x0a\x18\x92\x0b\x05\x41\x4b\x6d\x1d\x54\x54\x59\xb9\x57\xf5\xad\x9b\x88\xb1\xdd\xf7\x9a\x37\x7d\x3d\x47\x80\xa8\xae\x92\x28\xac\xdc\x2e\x81\x95\x31\x55\xa9\x1a\x31\x47\x79\x96\x41\x92\x9b\xc4\x41\x88\x40\x40\x22\x3b\xdb\x40\x09\x58\xd2\x94\x81\x30\x4b\x52\x15\xc3\xbd\xc7\x24\x57\xee\x83\xa3\x72\x8e\x5d\x85\x56\x2c\x22\x25\x1f\xf8\x1b\x26\x88\xe3\x72\xf1\x38\x16\x4e\x0e\xa2\x57\x8a\x1c\x0b\x9e\xd3\xd9\x2f\x8c\xa6\xc9\xa6\x6e\x05\x4c\xde\x6f\x33\x5d\x86\xc5\x6a\xa0\xcc\xda\x86\xb3\x56\xdf\x9f\x08\x86\x82\x05\xf4\xf6\x9a\x77\x60\x38\x99\x07\xac\x90\xf3\x51\xb9\xea\x1b\xe6\x62\xef\xf7\x74\xc6\x7d\xbf\x00\xea\x32\x95\xd2\x4e\xe7\x2b\xe3\xc5\x22\x20\x78\x8a\xb8\x30\x96\xc6\x6a\x05\xf0\x14\xf4\xdf\x05\xfc\xaa\x48\x39\xa9\x86\xe6\xb5\xe4\xd6\xe3\xe1\x60\x72\x1d\xf0\xbb\x33\x49\x05\x16\x8e\x30\x36\x41\x24\xe2\x97\xca\x03\xb0\x9c\x1c\xaf\xf0\x62\xd5\xa6\x7a\xe3\x08\x48\x35\xb8\xef\xd7\xc7\x30\x80\x0d\x5f\xef\xa8\x7f\xd8\xcd\x21\xca\x06\xbe\xa6\x77\x88\xb4\xee\xf9\x8d\xfb\x7d\xe6\xb0\x36\x38\x4f\x15\x97\x69\x22\x82\xf0\x4e\xf5\x50\x2e\xce\x6a\x65\xf0\x10\xd6\xdd\x28\x33\x79\x54\x20\xca\xbf\x55\x40\x2b\x99\xbf\x02\xdc\xfc\x5e\xe9\x52\x38\x68\xf9\x2a\x43\xcb\x2a\x88\xe4\x78\x66\xc6\xcd\xfd\x10\xc9\xbd\xe6\x4f\x11\xf0\x3b\xbd\x13\x66\x8a\x74\x1a\x2e\x50\x16\xa2\x83\xf5\xda\xb3\xfb\x04\xe1\x02\xd9\x5d\xb4\xfe\x6e\x74\x88\xdd\xba\x66\x6c\x62\x7b\x86\x7f\x9b\x6d\x90\xd0\x77\xed\x5c\x6e\x9b\xeb\x35\x28\xe9\x9e\x11\xa8\xa8\xb9\xbd\x40\x72\x99\xfc\x9c\xb9\x16\xe5\x6a\x28\xe9\x2c\x16\x58\x3f\x87\xca\x83\x04\x35\xb0\x21\x68\xcf\xc6\x9e\x2d\x63\xd3\x55\x37\xf5\xce\xa1\xb2\x6a\xad\x94\xdf\x5b\x56\x4d\xce\xad\x7f\x8b\xe5\x62\x1c\x78\x14\x7a\x97\x7f\xab\x80\xd6\xfd\x8c\x9a\x23\xe1\x16\xea\xb7\x5c\xa1\x75\xb3\xb7\x89\xcc\xba\x0d\xab\x2e\xf8\x22\xfe\x28\x5c\xc6\x52\xe2\x35\xfe\x54\x8e\x27\xae\xf4\x29\x82\xd5\xbf\x06\xb4\x01\x4b\xf0\xd8\x01\x4b\xf0\xe8\xc2\x72\x4e\x83\x28\x57\x7b\x47\xac\x5f\x6e\x26\x7d\x99\xae\xcb\xd3\x78\xd5\x65\x04\x80\x61\x48\xea\x8d\xa0\x2e\x43\xbf\x22\xc3\x27\xad\xc3\xba\x84\x0f\xa4\x91\x92\xba\xfb\xd3\xa1\x43\xd8\xf9\x7a\x8d\xf9\x7f\x0b\x71\x50\x7f\xe8\x0f\x28\x11\x01\x26\xca\x0a\x6d\xa0\xb2\x6a\x55\x1a\xbf\xda\x0b\xe0\x82\x6a\x3f\xaa\x5b\xce\xc2\x44\xd9\xe6\xfe\x39\x8f\x85\x6d\xc7\xbd\xe0\x8a\xe9\x87\xbc\xc8\x3b\x5a\x8e\x48\xd9\xc7\xad\x51\x30\x47\x55\x2e\xc3\x1c\x4f\x3f\xf3\x76\x32\xbe\xca\xfe\x92\xaf\x86\x71\xbf\x62\xf4\xb1\x72\x8a\xd2\x78\x9a\xbc\x3d\x7e\xe3\xfc\xb9\xb6\xf1\x3d\x87\x7a\x9d\x6c\xfd\x8a\xe4\x67\x03\x34\xd2\xbf\x95\x56\xb8\x43\xb9\x5d\x6a\xc4\x36\x53\xcd\xff\xb9\xd5\x91\x5a\x05\xd7\xd3\x8e\xf3\xb6\xa1\x76\x20\xb7\xee\x29\x0e\x9b\x8f\xb9\x76\x79\xe0\xb8\x0d\x85\xef\x28\x17\xdb\xf2\x12\x93\x08\x3d\x1a\x83\x8d\x03\x12\xd1\x05\xff\xc6\x3c\x76\xad\xae\x8e\x73\xb8\xa0\x1b\xc9\x7b\xc6\x29\xf3\x96\x46\xc8\x71\x68\xd6\x71\x2a\xf2\x3c\x68\xfb\xb9\xa8\x93\x39\x4f\xff\x57\xcd\x4a\x9e\x64\x79\x1a\xeb\xee\x66\xf7\xb8\xfc\x77\x92\xd2\x56\x46\xd1\x4e\x95\x6c\x63\x15\xeb\x3b\x49\x85\x77\x95\x15\xd9\x12\x47\x55\x3a\x3b\x83\x29\x33\x13\x53\x8d\x11\xa4\x94\x2c\x97\x47\xfb\x35\x67\x17\x93\x0b\xe5\x74\x57\x2a\xb1\xda\x6c\x7a\x83\xbf\xd8\x7d\xfb\x94\x3d\xeb\x27\x25\x1b\x23\xc9\x06\x4c\x0d\x71\x65\x2b\x3b\x0c\x7b\xd6\x81\x2f\xd5\x43\x02\xcb\xa1\xdb\xe1\x0e\xb7\x53\x1e\x38\x52\x73\xcf\xa2\x74\x63\xa2\xe0\xe9\xf8\xf2\x00\xf1\xab\xcf\xde\x14\xb6\x19\x31\x65\xfd\x77\x60\x2f\xaa\x29\xcc\xad\x2c\x86\x63\x8d\x4e\xb2\x83\x1a\x3b\x4d\xb9\x5b\x4e\xd9\x49\x4f\x5d\x85\x63\x0d\xeb\xed\x6d\x4e\x52\x40\x05\x66\xe7\xe5\x6b\x41\x19\xe8\x10\xf5\x1f\xdc\x16\x9a\x56\x09\xaf\xa0\x45\xd1\x88\xcc\x18\xe2\xbc\x9e\x30\xda\x68\xb3\x1a\xce\xbf\x07\x38\x62\x23\xc9\x5b\x78\xd8\x57\xff\x7b\x75\x58\x3f\xf7\x1e\x25\x66\xed\x84\xda\x35\xab\x20\x6f\x19\x5d\xc8\x81\x77\xb9\xdc\x6a\x83\x5c\xd3\x5d\x0f\x61\x8d\xb0\x6e\x4b\x91\xb5\x07\xad\x66\x52\xec\x63\x12\x8e\x22\x8b\x58\x59\xcd\xd4\x50\x73\xe2\xda\xa1\x1c\xc7\x44\x5a\x49\xe3\x80\x0b\x1c\x96\x6b\x19\x93\x99\xef\x97\x3f\xcd\x43\x93\x1d\xec\xab\x56\x8a\xb2\xc3\x3a\x05\x37\x6d\x4b\x46\xef\x9f\xe8\x0b\xe8\x4f\xc2\x39\x5a\x20\x00\xf3\x72\x49\x68\x33\x1c\xea\xf6\x4c\xd0\x39\x74\x25\x99\x69\xd6\xff\xe9\x75\x37\x9a\x6a\x52\xf3\xda\x3b\xaf\x21\xf5\x50\x29\xd1\x03\xeb\x16\xc0\x4a\x7a\xc2\x84\x77\x66\x68\x6c\xca\x6a\xb8\xbc\xbd\x66\x65\x93\x82\x23\x48\x15\x5a\x9c\xb1\x00\xcb\x93\x31\x5d\x7d\xa2\x71\x0d\x89\x12\x24\xf4\x81\x60\x29\xf2\xcc\x13\xfe\x1f\x0f\x4d\xcc\x06\x1e\xf3\xbc\x18\xc0\x51\x14\xa3\xb2\xd3\x9b\x1f\x0f\x2b\xdd\x18\xe5\xfc\x0f\x4a\x50\x3e\x44\xd9\xf4\x0e\x05\xb1\x98\x0f\xe6\x28\xbc\xab\xfa\x30\xba\x69\x79\x3d\x67\x88\xcf\x69\x1c\xa9\xe3\x4b\x5b\x58\x23\x29\xe9\x7b\x55\x4c\xf4\x8f\x4a\x4e\x93\xcd\xdc\x15\x21\x3a\xa3\x95\x15\x75\x39\x96\x7e\xc3\x8a\x7f\x8b\x19\x17\xf2\x47\xee\x6e\xba\x6a\x46\x0c\xc6\xbd\xb1\xbe\x7f\x20\x73\xe7\x64\xf6\x1c\xd6\xa2\x28\x4f\xdb\xd2\x28\xdb\x5a\xbb\x83\x24\x8c\xd7\x5e\xf8\x65\xc6\x61\x7a\xfc\xb7\x98\x44\x23\xf2\x3e\x48\xb2\xc5\x63\x56\x38\x7a\xcf\x4f\x3d\x69\x44\x6a\xa7\xab\x55\xf3\x35\x54\x8c\x99\x26\xed\x6b\x6f\x2e\xc0\x59\x49\xf8\xcd\x59\xd3\x50\xb2\xd8\xc0\xa0\x02\x6e\x17\xcc\xc9\xd6\x88\x63\x98\xc9\xe4\xdc\xc8\xf1\x8c\xa2\x67\x0f\x65\x60\xab\x6e\xc2\x00\xac\xff\x4f\x7b\xff\xa7\x6a\xef\xff\x6e\xbd\xb5\x7e\xdf\xec\xda\x9d\x6c\xa8\x69\xfd\xe6\x5b\x8c\x4e\x71\x75\x59\xa3\x56\x69\xad\xf6\x58\x54\xe7\x52\xf9\x9a\x3a\xe5\xee\xae\x05\x6e\x34\x39\x3a\x9e\x0a\xc1\xf0\x6d\x2a\x34\x83\xea\xe4\x98\x87\xb2\xb0\x8d\x0c\x2b\x80\x86\xd2\xff\x81\x35\x98\xf5\x4d\x8b\x62\x6b\x42\x76\xa1\xda\x37\xad\x16\x12\xb4\x9f\xa6\x7d\x05\x95\x3c\xff\x79\x40\xe9\x1d\x46\x13\x81\xc3\x3b\x4c\x10\xe7\x85\x8f\x2a\x27\x6e\x2b\x40\x30\x55\xc7\xcf\x4b\x68\x71\x0e\x56\x33\x12\x3a\x63\xe1\x4a\x54\x34\xc5\xc3\x8e\xb3\xa5\x96\x00\xa5\xac\xfe\x58\x77\xaf\x23\x73\x94\xb8\x9a\xe5\x4c\x03\x46\xc9\xaf\xf4\xb6\x58\x79\xd2\x83\x26\x45\xad\x74\x53\x28\xd7\x58\x54\xdd\x98\x6d\xc8\x21\x73\xb6\xaa\xd9\x06\xa1\x2c\x6e\x3c\x90\xff\x3e\x4d\x92\xfc\x58\x39\x64\x06\x42\xbb\x5a\x59\x1d\x6a\xf4\xe7\xd9\x07\x03\xe6\x99\xa5\xc8\x1b\x0b\x91\x0d\x5f\xfb\xe8\xd0\xdb\xdb\x54\x23\x0e\xff\xc0\xc9\x5b\xac\x48\xa9\xc6\x03\xf0\x33\x91\xf1\xc0\x9e\xb5\xee\x7a\x29\x47\x80\x0b\x86\x43\xd1\x3b\xae\x2c\x68\x58\xfd\x7d\x1f\x30\xa0\xce\x00\xc0\x09\x60\xe8\x4b\x8a\x19\xda\xef\xa9\x0f\xbd\x97\xc7\x2e\xe0\xe0\xc1\x02\x0d\x1e\xf8\x01\x8f\xee\x1a\x80\xc3\x98\xa6\x51\x51\x45\x0e\x4e\x00\x41\x0f\x12\x43\x7f\x20\x1b\xde\xe6\x0d\xfb\xee\xde\x5f\x52\xc4\x96\x5c\x9d\x14\x98\x43\x1a\x9f\x1d\xc3\xba\x10\xe9\x83\x4d\x70\x02\x56\xd5\x56\x00\x00\x28\x52\x4f\x3e\xe8\x19\x1a\xd4\xab\xc2\xae\x5b\xc7\xca\x6a\xff\xfa\x88\xdc\xf7\x2f\x2e\xcf\x86\x7f\x5e\x9f\x4f\xfe\x1c\x0f\x7f\x1d\x0e\xae\xff\xfc\x70\x71\xfa\xe1\xfa\xdd\xe5\x78\xf4\xc7\xf0\x0c\x9c\x80\xde\x61\xbb\x6c\xd0\x63\x22\x4d\x67\xae\x9a\xe0\x04\x4c\x33\x85\xdf\x47\xf7\x88\x08\x0f\x84\x94\x08\xf4\x28\x5e\xba\x27\x16\x52\xc2\x69\x8c\xfa\x31\x9d\xed\xf7\xe4\xc5\xb5\xe1\xe4\x1a\x8c\x87\x83\xe1\xe8\xe3\xf0\xcc\x07\x3d\xf0\x3d\xf8\x75\x72\x79\xd1\xd7\xbc\xc4\xd3\xa5\x46\xfb\xb2\x9d\xa9\x0a\xbb\x25\xdb\x7e\xa4\x92\x7e\xb7\x48\x71\x93\xef\x6b\x96\x7b\x06\xc5\x8c\x79\x20\x0a\x44\xd0\x40\xac\xfc\xc3\x53\x20\xe1\x36\x40\x54\xa7\x25\xa1\x8f\x9b\x80\xd7\x3a\x0d\xb1\x11\x99\x54\x8f\x28\xe0\xf3\x5b\x1a\xb0\xe8\xb8\x0d\x32\x09\x38\x7f\xa0\x1d\x00\xb3\x53\x23\x70\xa2\xa6\xdc\xd7\x4c\xf9\x74\x78\xd3\xcf\x2a\xe7\x3b\x8c\x94\xdf\x7f\xaf\xe3\x28\xef\xc6\x77\x12\x54\xfe\x37\xa5\x6c\x5f\xa2\xc6\x27\x87\xc7\x39\x81\xfd\x58\x5d\x46\xfc\x17\x3e\x06\xf8\xfb\xef\x5b\x18\x9f\x8b\x28\xeb\xfb\x09\xe7\xd3\xf9\x0d\x2d\xc1\xc9\x09\xe8\x9d\xe5\x8c\xec\x75\xc0\x24\xff\x0a\xce\x83\x13\x50\xc3\xaa\xb6\xdb\xe3\x36\x34\xeb\x4d\x00\xeb\xa7\xf2\x47\xf1\xa6\xe4\xfd\x56\xec\x29\xbb\x7f\xc2\x86\xb0\x72\x26\x5d\x65\x3a\xd4\x95\x47\xb9\xce\x81\x13\xd0\x80\xf9\x5b\x33\x4a\x29\x78\xa2\x4b\xd9\x4f\xda\x27\x31\xa7\x5c\xf8\xa5\xa8\xbd\x36\x78\x69\xf4\x7c\xf0\xc3\x0f\x6f\xda\x21\x03\x31\xf7\x41\xef\x55\x90\x24\xfc\x95\x34\x67\xca\x7e\xf5\x83\x24\x01\xdf\x83\xde\xab\xe2\x16\xbf\xd1\x96\x7d\x53\xed\x2c\x25\xbd\xd6\x31\x16\x48\xcc\x69\xe4\x83\xde\xd5\xe5\xe4\xba\x1d\x7c\x8e\x82\x08\x31\xee\x77\x93\x6d\xef\x34\x0c\x51\x22\x7a\x3e\xe8\x05\x49\x12\xcb\x60\x0a\x53\xf2\xea\x2f\x4e\x3b\x50\xa6\x10\xc8\x52\x34\x44\xc4\x81\xf4\xa4\xaa\x68\x1e\x0f\x1e\x1e\x1e\x0e\xa4\x89\x3e\x48\x59\x8c\x88\xbc\x3c\x1e\x75\xc4\xfb\x81\x23\x76\x70\x3a\x43\x44\x11\x17\xa6\x2c\x7e\xd5\x6b\xd5\xb0\x56\xd4\x41\xaa\x04\xa6\xef\xb3\xf9\x52\x2a\xb9\x76\x6f\x54\xce\xe3\xa7\x6a\x27\x43\x5f\xc0\x89\xf6\x6b\xfa\xd2\x6f\x40\x5c\xec\x67\x1a\x6b\x6c\x49\x0c\xf1\x0e\x6b\x50\xe2\xbb\xa5\xd1\x52\x6e\xda\xbd\xd6\x55\x66\xed\xb9\xf2\x5a\x63\xca\xfd\x9e\x07\x18\xe2\x7d\xae\x7e\x49\x07\xef\xe5\xd3\xd0\xbc\xcb\x74\xaa\xe7\x55\xf7\x6b\x89\x36\xd3\xb8\x97\xed\x48\x15\x11\x48\x0c\xa5\x22\x60\x32\xdb\xef\xa5\x62\xfa\x53\xaf\x5b\x3f\x4a\xf6\x7b\x72\x1b\xea\x19\xfc\x0b\xe7\x29\xb9\x7b\x09\x56\x9a\x3d\xdf\x9f\x00\xf5\x01\xac\xbb\x63\x44\x24\x32\x11\x76\xb4\x88\x26\x73\xe4\xd0\x2f\x8f\x3b\xf6\x92\xbe\x52\x3f\xa2\x04\xed\xb7\x77\x69\x99\xc5\xfa\xe5\x93\x94\x92\xa1\x2f\x7a\xc2\x8c\x51\xd6\xb3\xdd\x22\xca\x3a\xcc\xdb\x52\x88\xa1\xec\x24\xd5\x41\xf7\xee\xa2\x4d\x6a\xea\xd3\x00\xc7\xfb\x1d\xfa\x3c\x71\x72\xca\x5f\xa1\x5c\x9c\x05\x22\x00\x27\xa6\xdb\x6e\xa8\x6a\xcb\x0c\x7b\x21\x5d\x2c\x02\x12\xf5\x80\x9f\x59\xea\xec\xc3\x8e\x85\xf0\xc0\xb0\x40\xfb\x39\xb5\x2f\x8f\xdb\xe0\x11\x89\x36\x28\x8b\x73\x4f\x75\x50\xb5\x3e\x86\xd6\x61\x99\x55\x4b\xb2\x29\xfc\x36\x73\x5a\xb5\xa8\x5a\x1d\x03\xce\x29\x13\x59\x90\x3b\x4e\x63\xd4\x18\x5d\x0f\x25\x53\xb9\xef\x2b\xa0\xd6\xc8\xda\x8c\xa8\xcf\x29\x99\xe5\x61\x34\x0f\xe7\x28\x4a\xed\xeb\xfc\x93\xec\xdb\xf0\x31\x91\x67\xf1\xd9\xf9\x6a\x7e\x46\x29\x5b\x2a\x55\x81\xfa\x74\xab\x96\x9b\x52\x21\xb4\x3b\xdc\x36\xd3\x07\x4d\xf7\x1a\x46\x51\x3e\xac\x41\xb0\x1e\xaa\x7a\xea\x96\xe8\xeb\xbf\xab\xcf\xf2\x29\xae\xcf\xd0\x07\x9f\xa1\x9d\x2e\xf8\x0c\x3d\xf0\x39\x0f\xe7\x4a\x80\x2c\x47\x55\x00\x64\x2a\x5a\x02\x0c\xf4\x07\x05\xb0\x2e\x45\xbe\xbe\x69\x3c\xe7\x36\xe5\xa7\x13\x20\x57\x88\x2d\x30\xe7\xae\x4c\x09\xa8\xa6\x4a\x0c\x58\x97\x48\x81\x25\xd3\xec\x02\x3d\x28\x2e\x68\xfb\x23\x72\x4f\xef\x90\xeb\x12\xbb\x95\x59\x01\x5b\x0a\xc5\x78\x2d\x41\x0e\xaa\x96\x35\xaf\xbc\x8f\x60\xea\x91\x4a\x25\x29\x34\x8d\x85\x6b\x35\x75\x37\x06\x7e\x7a\x1a\xcb\xf9\x9a\x99\x5a\x5d\x50\x5f\xad\x7f\x17\xf0\x71\x06\x63\x0a\x43\x52\x76\xc9\xac\xe4\xf3\xa6\xd7\xce\xec\xbb\xfa\x1e\x80\x29\x3f\x40\x01\x17\x07\x47\x10\xdc\xac\xbd\xad\x71\x3c\x20\x2e\x0e\x5e\x3f\x03\x07\x4a\x35\x0e\x45\x47\x86\xe2\x66\xd3\xe5\x46\xd7\xd5\xf2\x92\x5f\xb2\xc5\xf1\x04\x92\xe3\x46\xd4\xd8\x00\xab\x3e\x6a\xa7\x6f\xd8\x55\xb9\xef\xed\x35\x5f\x9b\x6a\xba\xf0\xf9\xdc\xeb\x52\xc6\xbd\xa5\xa6\x52\x25\xf3\x1a\x69\x0b\xcb\x2a\x77\x6e\xf3\x99\xe9\x93\x98\x27\xf1\xa0\x56\xca\xa7\xc8\x3a\x0d\x43\x59\xab\x32\x8a\xcc\x3b\xba\x9b\xc4\xf2\xe4\xd1\xaa\xec\x73\x20\xf7\xcc\x47\xb0\xcc\xba\xc9\x46\xfe\x54\x1e\x20\xb0\xae\x5b\xb9\x2f\xe3\x56\xdf\x94\x69\x10\x7f\xa7\xb7\x64\x1a\x9f\x88\xa9\x3c\xd2\x51\xbe\xd7\x62\x7d\x07\xb5\xd7\x5b\xac\xe6\xca\xa9\x48\xcb\x03\x33\xf6\xe3\x32\xd5\x71\x8c\xa7\x66\x2a\x4d\x00\x40\x14\x56\xed\x6a\x05\xe6\xa6\xf1\x31\x94\x86\x7a\x2d\xe7\xcb\x2d\xf5\xe7\xe1\x6a\x4f\xce\xb8\x8f\xe6\x56\xb5\x07\x69\x8a\xa3\x10\x53\xde\xf5\x77\x6b\x36\xbc\x00\xb4\xfb\xc7\x7d\x1a\x85\xac\x5a\x91\xae\x34\x93\x6f\xd8\xdd\x16\x95\x66\x67\x59\xd6\xf3\x3b\xe8\x3d\xa1\x8f\xb6\x09\x88\xe5\x27\x64\x5c\x56\x0c\x3a\x6b\xd6\xda\xb1\x8d\xab\xb8\x7e\xc7\x62\xde\x01\x57\xf8\xba\x95\xf8\xf0\xb5\x7f\x9a\x8a\x39\x65\xf8\x6f\xe4\xac\xba\xac\xf5\x72\x1d\x03\x1a\x0f\xf5\x38\xf9\xfa\x9d\x03\x4d\xf7\x17\x90\x6a\x6e\xf4\x4d\xbb\x49\x36\xef\x4e\x4b\xdf\xa0\x72\x1e\x6c\x3d\xd9\xd1\x2f\x4e\xcd\xca\x2b\xbc\x99\x51\x32\x7d\x64\xfb\x62\x31\x1c\x2d\x82\x59\xd1\xa6\x7e\x94\x8d\xab\x15\x9e\x16\x9e\xe2\x29\x63\xc1\xb2\xac\x1e\xcf\xbe\xda\x6b\xa7\xb8\xba\xa9\x0e\xaa\x3c\xf0\x02\xc5\x4a\xef\x55\xc9\xb0\x13\x4f\xde\x11\x4f\xb3\x4e\xeb\xb5\xb7\x5a\xc9\x64\xb8\xbc\xf5\x8d\x48\x54\xbf\x3c\x9d\x23\x5d\x1b\x6e\xab\x0d\x7a\x93\x93\x2f\xf1\x00\x3c\x05\x04\x15\xc3\xeb\xeb\x1c\x00\x42\xbb\x6a\x2f\x9f\xcc\x6a\x05\xee\xe5\x36\x52\x85\x5f\x17\x85\xd6\xe5\x40\xb0\x7c\x17\xc4\x51\xdd\x66\xbf\x1d\x52\x78\xd2\xee\x47\x37\x8d\xf7\x59\xd0\xd2\x03\x2f\x34\x0d\xfa\xa5\x96\xfb\xf7\x41\x62\xd5\x18\xca\x62\xec\x3b\xb4\xcc\x6a\x4d\x73\x82\xb3\x3e\xeb\xc6\x82\xff\xe6\x31\xce\x31\xb9\xfb\x18\x30\xbe\x71\x94\x56\xfc\xf0\xb7\xd1\xc5\x70\x32\x9a\x34\x3c\xc6\x91\x77\x83\xe7\x97\xbf\xfc\xf9\xcb\xf8\xf2\xc3\x55\xd3\xf3\x1b\xa5\x81\x1e\x5f\x0e\x86\x93\x49\x55\x73\xad\xc8\xe4\x23\x8d\xd3\x85\x65\xc3\xcb\x30\xf4\xbd\xf4\x32\x64\xfd\x42\x06\x54\x9d\x5f\xdf\x8c\xf6\x6a\xec\xd2\xa4\x81\x86\x43\xf5\x4c\xc7\xf2\x4d\xc1\x22\xa0\x5b\x4f\xb3\xf0\xc3\x26\xbf\xf6\x8c\x46\x7b\xb5\x88\x3e\xce\x26\x54\xb8\xee\x43\xbb\x4b\x33\x1b\x8a\x66\x1d\x37\xd1\xbb\x56\xef\xd4\x6f\x1c\x57\x53\x09\xcd\xb5\xb3\x5b\xb2\x5f\x16\x01\xe3\x18\xcd\x50\x11\x52\x97\x5f\x32\x02\xd4\xa0\xcd\xe8\xc0\xcd\x5e\x53\xd0\x65\xbc\x42\xb1\x5b\xe3\x9b\x5d\x1d\xb7\x2d\x70\x53\x01\xad\x4e\x41\xbf\x0a\x12\xdc\xf0\xfe\x99\xc9\x5a\xd3\x2e\xeb\x09\x24\xba\xf0\x47\x5d\x53\xf7\xd4\x8b\xde\xfe\x6a\x65\x3d\xbf\x61\x8b\xcc\x2b\x8b\x13\xa4\x90\xf5\xbd\x71\xaf\x62\xf0\xe0\x8f\x3f\xe4\xe4\x4b\xe3\xc1\xcb\xb0\x37\x9b\xa1\xaf\x5e\x0e\x6f\x53\xf5\xcd\x7a\xb9\xc1\xaa\x4a\x84\x3f\x1d\xd6\x94\x50\xce\x71\xaf\x9a\xb9\xba\x79\x96\x0e\x98\xaf\x8a\xd5\xdf\xee\xb2\xdd\xfd\xc9\x1b\xdf\xcf\x5e\xc8\xcb\x1f\xc5\x42\x31\x92\x9e\x5a\x51\x60\x03\xc7\x48\xb2\xba\x25\x1e\x08\x65\xee\x46\x4a\x85\xe9\xbb\x1b\xd5\x8a\x78\x78\x1d\xcc\x78\x35\xa8\xd7\x2f\xb4\x40\xbe\xe4\x02\x2d\xa4\x18\x8b\x4a\x28\xad\x41\x16\xa7\x4a\x78\x29\x28\xaf\x31\x54\x33\x23\x48\x97\xe3\x62\x70\xed\xbf\x06\x00\x2c\x70\x3d\x8e\x37\x5e\x00\x00")
 
 func templatesAppTmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -91,7 +91,7 @@ func templatesAppTmpl() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/app.tmpl", size: 23008, mode: os.FileMode(420), modTime: time.Unix(1464812286, 0)}
+	info := bindataFileInfo{name: "templates/app.tmpl", size: 24119, mode: os.FileMode(420), modTime: time.Unix(1792404667, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1,9 +1,12 @@
 package aws
 
 import (
+	"archive/tar"
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -16,6 +19,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/kinesis"
@@ -27,6 +31,52 @@ import (
 	"github.com/convox/rack/api/structs"
 )
 
+// buildArtifact is the metadata stored in build.json of an exported build
+type buildArtifact struct {
+	Build  structs.Build     `json:"build"`
+	Images map[string]string `json:"images"`
+}
+
+// validate checks the artifact has exactly one image for every process in its manifest
+func (a *buildArtifact) validate() error {
+	var m manifest.Manifest
+
+	if err := yaml.Unmarshal([]byte(a.Build.Manifest), &m); err != nil {
+		return fmt.Errorf("invalid build artifact: could not parse manifest: %s", err)
+	}
+
+	for name := range m {
+		if a.Images[name] == "" {
+			return fmt.Errorf("invalid build artifact: no image for process %s", name)
+		}
+	}
+
+	for name := range a.Images {
+		if _, ok := m[name]; !ok {
+			return fmt.Errorf("invalid build artifact: image for unknown process %s", name)
+		}
+	}
+
+	return nil
+}
+
+// imageExists returns true if an image is already on the docker host
+func imageExists(tag string) bool {
+	return exec.Command("docker", "inspect", "--type=image", tag).Run() == nil
+}
+
+// removeImages removes images pulled or loaded for an export or import so they do
+// not fill the disk of the api host
+func removeImages(tags []string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	if out, err := exec.Command("docker", append([]string{"rmi"}, tags...)...).CombinedOutput(); err != nil {
+		helpers.Error(nil, fmt.Errorf("could not remove images: %s", strings.TrimSpace(string(out)))) // send internal error to rollbar
+	}
+}
+
 var regexpECR = regexp.MustCompile(`(\d+)\.dkr\.ecr\.([^.]+)\.amazonaws\.com\/([^:]+):([^ ]+)`)
 
 func buildsTable(app string) string {
@@ -263,6 +313,118 @@ func (p *AWSProvider) BuildDelete(app, id string) (*structs.Build, error) {
 	return b, nil
 }
 
+// BuildExport writes a .tgz artifact of a build to w. The artifact contains the build
+// metadata and a `docker save` archive of every image tagged for the build.
+func (p *AWSProvider) BuildExport(app, id string, w io.Writer) error {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return err
+	}
+
+	b, err := p.BuildGet(app, id)
+	if err != nil {
+		return err
+	}
+
+	if b.Status != "complete" {
+		return fmt.Errorf("can not export build with status: %s", b.Status)
+	}
+
+	var m manifest.Manifest
+
+	err = yaml.Unmarshal([]byte(b.Manifest), &m)
+	if err != nil {
+		return err
+	}
+
+	artifact := buildArtifact{
+		Build:  *b,
+		Images: map[string]string{},
+	}
+
+	artifact.Build.Logs = ""
+
+	tags := []string{}
+	pulled := []string{}
+
+	defer func() { removeImages(pulled) }()
+
+	for name := range m {
+		tag := registryTag(a, name, b.Id)
+		existed := imageExists(tag)
+
+		out, err := exec.Command("docker", "pull", tag).CombinedOutput()
+		if err != nil {
+			return fmt.Errorf("could not pull %s: %s", tag, strings.TrimSpace(string(out)))
+		}
+
+		if !existed {
+			pulled = append(pulled, tag)
+		}
+
+		artifact.Images[name] = tag
+		tags = append(tags, tag)
+	}
+
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(dir)
+
+	images := filepath.Join(dir, "images.tar")
+
+	out, err := exec.Command("docker", append([]string{"save", "-o", images}, tags...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not save images: %s", strings.TrimSpace(string(out)))
+	}
+
+	data, err := json.MarshalIndent(artifact, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err = tw.WriteHeader(&tar.Header{Name: "build.json", Mode: 0644, Size: int64(len(data)), ModTime: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+
+	fd, err := os.Open(images)
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	stat, err := fd.Stat()
+	if err != nil {
+		return err
+	}
+
+	err = tw.WriteHeader(&tar.Header{Name: "images.tar", Mode: 0644, Size: stat.Size(), ModTime: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tw, fd); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
 func (p *AWSProvider) BuildGet(app, id string) (*structs.Build, error) {
 	a, err := p.AppGet(app)
 	if err != nil {
@@ -291,6 +453,128 @@ func (p *AWSProvider) BuildGet(app, id string) (*structs.Build, error) {
 	return build, nil
 }
 
+// BuildImport reads a .tgz artifact created by BuildExport, loads and pushes its images
+// to the app registry and creates a completed build and release from its manifest.
+// build.json is validated before any images are loaded, so it must come first.
+func (p *AWSProvider) BuildImport(app string, r io.Reader) (*structs.Build, error) {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return nil, err
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := tar.NewReader(gz)
+
+	var artifact *buildArtifact
+
+	loaded := false
+
+	// images loaded or tagged here are removed once the import is done
+	remove := []string{}
+
+	defer func() { removeImages(remove) }()
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch header.Name {
+		case "build.json":
+			data, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+
+			artifact = &buildArtifact{}
+
+			err = json.Unmarshal(data, artifact)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := artifact.validate(); err != nil {
+				return nil, err
+			}
+		case "images.tar":
+			if artifact == nil {
+				return nil, fmt.Errorf("invalid build artifact: images.tar comes before build.json")
+			}
+
+			for _, src := range artifact.Images {
+				if !imageExists(src) {
+					remove = append(remove, src)
+				}
+			}
+
+			cmd := exec.Command("docker", "load")
+			cmd.Stdin = tr
+
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				return nil, fmt.Errorf("could not load images: %s", strings.TrimSpace(string(out)))
+			}
+
+			loaded = true
+		}
+	}
+
+	if artifact == nil {
+		return nil, fmt.Errorf("invalid build artifact: missing build.json")
+	}
+
+	if !loaded {
+		return nil, fmt.Errorf("invalid build artifact: missing images.tar")
+	}
+
+	b := structs.NewBuild(a.Name)
+	b.Description = fmt.Sprintf("Import of %s %s", artifact.Build.App, artifact.Build.Id)
+	b.Manifest = artifact.Build.Manifest
+	b.Started = time.Now()
+
+	err = p.BuildSave(b)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, src := range artifact.Images {
+		tag := registryTag(a, name, b.Id)
+
+		if out, err := exec.Command("docker", "tag", src, tag).CombinedOutput(); err != nil {
+			return b, p.buildFail(b, fmt.Errorf("could not tag %s: %s", src, strings.TrimSpace(string(out))))
+		}
+
+		remove = append(remove, tag)
+
+		if out, err := exec.Command("docker", "push", tag).CombinedOutput(); err != nil {
+			return b, p.buildFail(b, fmt.Errorf("could not push %s: %s", tag, strings.TrimSpace(string(out))))
+		}
+	}
+
+	b.Status = "complete"
+	b.Ended = time.Now()
+
+	_, err = p.BuildRelease(b)
+
+	p.EventSend(&structs.Event{
+		Action: "build:create",
+		Data: map[string]string{
+			"app": b.App,
+			"id":  b.Id,
+		},
+	}, err)
+
+	return b, err
+}
+
 func (p *AWSProvider) BuildList(app string) (structs.Builds, error) {
 	a, err := p.AppGet(app)
 	if err != nil {
@@ -427,6 +711,27 @@ func (p *AWSProvider) BuildSave(b *structs.Build) error {
 	return err
 }
 
+// buildFail marks a build as failed with the given reason and returns the reason
+func (p *AWSProvider) buildFail(b *structs.Build, reason error) error {
+	b.Status = "failed"
+	b.Reason = reason.Error()
+	b.Ended = time.Now()
+
+	if err := p.BuildSave(b); err != nil {
+		return err
+	}
+
+	p.EventSend(&structs.Event{
+		Action: "build:create",
+		Data: map[string]string{
+			"app": b.App,
+			"id":  b.Id,
+		},
+	}, reason)
+
+	return reason
+}
+
 func (p *AWSProvider) buildArgs(a *structs.App, b *structs.Build, source string) []string {
 	return []string{
 		"run",
@@ -602,11 +907,14 @@ func (p *AWSProvider) buildWait(a *structs.App, b *structs.Build, cmd *exec.Cmd,
 	// scan all output
 	out := ""
 	scanner := bufio.NewScanner(stdout)
+	bl := p.newBuildLog(b)
 
 	for scanner.Scan() {
 		text := scanner.Text()
 		out += text + "\n"
 
+		bl.add(text)
+
 		p.kinesis().PutRecord(&kinesis.PutRecordInput{
 			Data:         []byte(text),
 			PartitionKey: aws.String(string(time.Now().UnixNano())),
@@ -617,6 +925,8 @@ func (p *AWSProvider) buildWait(a *structs.App, b *structs.Build, cmd *exec.Cmd,
 		helpers.Error(nil, err) // send internal error to rollbar
 	}
 
+	bl.flush()
+
 	// and wait for a return code
 	werr := cmd.Wait()
 
@@ -754,3 +1064,73 @@ func registryTag(a *structs.App, serviceName, buildId string) string {
 
 	return tag
 }
+
+// buildLog copies build output to a stream named <app>/<build> in the rack build log
+// group, which the system logs include. Lines are sent in batches and output that can
+// not be sent is dropped, as the build keeps its own copy.
+type buildLog struct {
+	provider *AWSProvider
+	group    string
+	stream   string
+	events   []*cloudwatchlogs.InputLogEvent
+	token    *string
+	flushed  time.Time
+}
+
+// newBuildLog returns nil on racks without a build log group
+func (p *AWSProvider) newBuildLog(b *structs.Build) *buildLog {
+	group := os.Getenv("BUILD_LOG_GROUP")
+
+	if group == "" {
+		return nil
+	}
+
+	stream := fmt.Sprintf("%s/%s", b.App, b.Id)
+
+	_, err := p.cloudwatchlogs().CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(group),
+		LogStreamName: aws.String(stream),
+	})
+	if err != nil {
+		helpers.Error(nil, err) // send internal error to rollbar
+		return nil
+	}
+
+	return &buildLog{provider: p, group: group, stream: stream, flushed: time.Now()}
+}
+
+func (bl *buildLog) add(line string) {
+	if bl == nil {
+		return
+	}
+
+	bl.events = append(bl.events, &cloudwatchlogs.InputLogEvent{
+		Message:   aws.String(line),
+		Timestamp: aws.Int64(time.Now().UnixNano() / int64(time.Millisecond)),
+	})
+
+	if len(bl.events) >= 100 || time.Since(bl.flushed) > 1*time.Second {
+		bl.flush()
+	}
+}
+
+func (bl *buildLog) flush() {
+	if bl == nil || len(bl.events) == 0 {
+		return
+	}
+
+	res, err := bl.provider.cloudwatchlogs().PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+		LogEvents:     bl.events,
+		LogGroupName:  aws.String(bl.group),
+		LogStreamName: aws.String(bl.stream),
+		SequenceToken: bl.token,
+	})
+	if err != nil {
+		helpers.Error(nil, err) // send internal error to rollbar
+	} else {
+		bl.token = res.NextSequenceToken
+	}
+
+	bl.events = nil
+	bl.flushed = time.Now()
+}
@@ -1,6 +1,11 @@
 package aws_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
 	"os"
 	"testing"
 	"time"
@@ -434,3 +439,58 @@ var releasesBuild2BatchWriteItemCycle = awsutil.Cycle{
 		Body:       `{"UnprocessedItems":{}}`,
 	},
 }
+
+func TestBuildImportInvalid(t *testing.T) {
+	defer func() {
+		provider.CurrentProvider = new(provider.TestProviderRunner)
+	}()
+
+	artifact := func(images string) io.Reader {
+		data := fmt.Sprintf(`{"build":{"id":"BAFVEWUCAYT","app":"httpd","manifest":"web:\n  image: httpd\nworker:\n  image: httpd\n"},"images":%s}`, images)
+
+		var buf bytes.Buffer
+
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		tw.WriteHeader(&tar.Header{Name: "build.json", Mode: 0644, Size: int64(len(data))})
+		tw.Write([]byte(data))
+		tw.Close()
+		gz.Close()
+
+		return &buf
+	}
+
+	tests := map[string]string{
+		`{"web":"convox/httpd:web.BAFVEWUCAYT"}`: "invalid build artifact: no image for process worker",
+		`{"web":"convox/httpd:web.BAFVEWUCAYT","worker":"convox/httpd:worker.BAFVEWUCAYT","cron":"convox/httpd:cron"}`: "invalid build artifact: image for unknown process cron",
+		`{"web":"convox/httpd:web.BAFVEWUCAYT","worker":"convox/httpd:worker.BAFVEWUCAYT"}`:                            "invalid build artifact: missing images.tar",
+	}
+
+	for images, message := range tests {
+		aws := StubAwsProvider(
+			describeStacksCycle,
+		)
+
+		_, err := provider.BuildImport("httpd", artifact(images))
+		assert.EqualError(t, err, message)
+
+		aws.Close()
+	}
+	// images are only loaded once build.json has been validated
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "images.tar", Mode: 0644, Size: 4})
+	tw.Write([]byte("data"))
+	tw.Close()
+	gz.Close()
+
+	aws := StubAwsProvider(
+		describeStacksCycle,
+	)
+	defer aws.Close()
+
+	_, err := provider.BuildImport("httpd", &buf)
+	assert.EqualError(t, err, "invalid build artifact: images.tar comes before build.json")
+}
@@ -3,102 +3,248 @@ package aws
 import (
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/convox/rack/api/structs"
 )
 
+// maximum number of records returned by LogQuery
+const logQueryLimit = 10000
+
+// throttled requests tried before a query that does not follow gives up
+const logQueryThrottles = 3
+
 func (p *AWSProvider) LogStream(app string, w io.Writer, opts structs.LogStreamOptions) error {
 	a, err := p.AppGet(app)
 	if err != nil {
 		return err
 	}
 
-	since := 2 * time.Minute
-	if opts.Since.Nanoseconds() > 0 {
-		since = opts.Since
+	return p.streamLogs(w, []logSource{{Group: a.Outputs["LogGroup"]}}, opts)
+}
+
+// SystemLogs streams the rack log group, which includes the api and monitor workers,
+// the build log group and the output of the formation Lambda function
+func (p *AWSProvider) SystemLogs(w io.Writer, opts structs.LogStreamOptions) error {
+	rack := os.Getenv("RACK")
+
+	res, err := p.describeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(rack),
+	})
+	if err != nil {
+		return err
 	}
 
-	startTime := aws.Int64(time.Now().Add(-since).UnixNano() / int64(time.Millisecond)) // number of milliseconds since Jan 1, 1970 00:00:00 UTC
-	nextToken := aws.String("")
+	if len(res.Stacks) != 1 {
+		return fmt.Errorf("could not load stack for rack: %s", rack)
+	}
 
-	for {
-		req := &cloudwatchlogs.FilterLogEventsInput{
-			Interleaved:  aws.Bool(true),
-			LogGroupName: aws.String(a.Outputs["LogGroup"]),
-			StartTime:    startTime,
-		}
+	outputs := stackOutputs(res.Stacks[0])
 
-		if opts.Filter != "" {
-			req.FilterPattern = aws.String(opts.Filter)
-		}
+	sources := []logSource{{Group: outputs["LogGroup"]}}
 
-		startTime, nextToken, err = p.writeLogEvents(req, w)
-		if err != nil {
-			return err
-		}
+	// racks installed before the build log group do not have it
+	if group := outputs["BuildLogGroup"]; group != "" {
+		sources = append(sources, logSource{Group: group, Prefix: "build", Stream: true})
+	}
+
+	fres, err := p.cloudformation().DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		LogicalResourceId: aws.String("CustomTopic"),
+		StackName:         aws.String(rack),
+	})
+	if err != nil {
+		return err
+	}
+
+	sources = append(sources, logSource{
+		Group:  fmt.Sprintf("/aws/lambda/%s", *fres.StackResourceDetail.PhysicalResourceId),
+		Prefix: "lambda:formation",
+	})
+
+	return p.streamLogs(w, sources, opts)
+}
 
-		for nextToken != nil {
-			req.NextToken = nextToken
+// logSource is a log group to stream with an optional prefix for each line. Stream
+// adds the log stream name to the prefix, e.g. build:<app>/<build>.
+type logSource struct {
+	Group  string
+	Prefix string
+	Stream bool
+}
+
+func (p *AWSProvider) streamLogs(w io.Writer, sources []logSource, opts structs.LogStreamOptions) error {
+	reqs := make([]*cloudwatchlogs.FilterLogEventsInput, len(sources))
+
+	for i, s := range sources {
+		reqs[i] = logEventsRequest(s.Group, opts)
+	}
 
-			startTime, nextToken, err = p.writeLogEvents(req, w)
+	count := 0
+
+	for {
+		for i, req := range reqs {
+			prefix := sources[i].Prefix
+			stream := sources[i].Stream
+
+			done, err := p.eachLogEvent(req, opts, func(r structs.LogRecord) (bool, error) {
+				line := r.Raw()
+
+				if stream {
+					line = fmt.Sprintf("%s:%s %s", prefix, r.Stream, line)
+				} else if prefix != "" {
+					line = fmt.Sprintf("%s %s", prefix, line)
+				}
+
+				if _, err := w.Write([]byte(fmt.Sprintf("%s %s\n", r.Timestamp.Format(time.RFC3339), line))); err != nil {
+					return true, err
+				}
+
+				count++
+
+				return opts.Limit > 0 && count >= opts.Limit, nil
+			})
+			// lambda log groups do not exist until the function has run
+			if awsError(err) == "ResourceNotFoundException" && len(reqs) > 1 {
+				continue
+			}
 			if err != nil {
 				return err
 			}
+
+			if done {
+				return nil
+			}
 		}
 
 		// assert that websocket is still alive
-		_, err = w.Write([]byte{})
+		_, err := w.Write([]byte{})
 		if err != nil {
 			return err
 		}
 
-		if !opts.Follow {
+		if !opts.Follow || (!opts.End.IsZero() && time.Now().After(opts.End)) {
 			return nil
 		}
 
 		// According to http://docs.aws.amazon.com/AmazonCloudWatch/latest/DeveloperGuide/cloudwatch_limits.html
 		// the maximum rate of a GetLogEvents request is 10 requests per second per AWS account.
 		// Aim for 5 reqs / sec so two clients can tail.
-		time.Sleep(200 * time.Millisecond)
+		time.Sleep(time.Duration(len(reqs)) * 200 * time.Millisecond)
 	}
 
 	return nil
 }
 
-func (p *AWSProvider) writeLogEvents(req *cloudwatchlogs.FilterLogEventsInput, w io.Writer) (*int64, *string, error) {
-	resp, err := p.cloudwatchlogs().FilterLogEvents(req)
-	if code := awsError(err); code == "ThrottlingException" {
-		// Backoff but don't return an error
-		fmt.Printf("logs writeLogEvents err=%q\n", code)
-		time.Sleep(1 * time.Second)
-		return req.StartTime, req.NextToken, nil
+func (p *AWSProvider) LogQuery(app string, opts structs.LogStreamOptions) (structs.LogRecords, error) {
+	a, err := p.AppGet(app)
+	if err != nil {
+		return nil, err
 	}
+
+	if opts.Limit <= 0 || opts.Limit > logQueryLimit {
+		opts.Limit = logQueryLimit
+	}
+
+	records := structs.LogRecords{}
+
+	_, err = p.eachLogEvent(logEventsRequest(a.Outputs["LogGroup"], opts), opts, func(r structs.LogRecord) (bool, error) {
+		records = append(records, r)
+		return len(records) >= opts.Limit, nil
+	})
 	if err != nil {
-		fmt.Printf("logs writeLogEvents err=%q\n", err)
-		return req.StartTime, req.NextToken, err
+		return nil, err
 	}
 
-	ts := req.StartTime
+	return records, nil
+}
+
+func logEventsRequest(group string, opts structs.LogStreamOptions) *cloudwatchlogs.FilterLogEventsInput {
+	start := opts.Start
 
-	for _, e := range resp.Events {
-		if *e.Timestamp >= *ts {
-			*ts = *e.Timestamp + int64(1)
+	if start.IsZero() {
+		since := 2 * time.Minute
+		if opts.Since.Nanoseconds() > 0 {
+			since = opts.Since
 		}
 
-		sec := *e.Timestamp / 1000
-		nsec := *e.Timestamp - (sec * 1000)
-		t := time.Unix(sec, nsec)
+		start = time.Now().Add(-since)
+	}
+
+	req := &cloudwatchlogs.FilterLogEventsInput{
+		Interleaved:  aws.Bool(true),
+		LogGroupName: aws.String(group),
+		StartTime:    aws.Int64(logTimestamp(start)),
+	}
 
-		line := fmt.Sprintf("%s %s\n", t.Format(time.RFC3339), *e.Message)
-		_, err := w.Write([]byte(line))
+	if !opts.End.IsZero() {
+		req.EndTime = aws.Int64(logTimestamp(opts.End))
+	}
+
+	if opts.Filter != "" {
+		req.FilterPattern = aws.String(opts.Filter)
+	}
+
+	return req
+}
+
+// eachLogEvent calls fn for every matching event until the results are exhausted
+// or fn reports it is done. req.StartTime is advanced past the last event seen
+// so the same request can be reused to follow the log group. Throttled requests
+// are retried while following, other callers get the error after a few tries
+// so they can back off.
+func (p *AWSProvider) eachLogEvent(req *cloudwatchlogs.FilterLogEventsInput, opts structs.LogStreamOptions, fn func(structs.LogRecord) (bool, error)) (bool, error) {
+	req.NextToken = nil
+
+	throttles := 0
+
+	for {
+		res, err := p.cloudwatchlogs().FilterLogEvents(req)
+		if code := awsError(err); code == "ThrottlingException" {
+			fmt.Printf("logs eachLogEvent err=%q\n", code)
+
+			if throttles++; !opts.Follow && throttles >= logQueryThrottles {
+				return false, err
+			}
+
+			time.Sleep(1 * time.Second)
+			continue
+		}
 		if err != nil {
-			return ts, req.NextToken, err
+			fmt.Printf("logs eachLogEvent err=%q\n", err)
+			return false, err
+		}
+
+		for _, e := range res.Events {
+			if *e.Timestamp >= *req.StartTime {
+				req.StartTime = aws.Int64(*e.Timestamp + 1)
+			}
+
+			r := structs.NewLogRecord(aws.StringValue(e.LogStreamName), *e.Message, time.Unix(0, *e.Timestamp*int64(time.Millisecond)))
+			r.Id = aws.StringValue(e.EventId)
+
+			if !opts.Matches(r) {
+				continue
+			}
+
+			done, err := fn(r)
+			if err != nil || done {
+				return done, err
+			}
 		}
+
+		if res.NextToken == nil {
+			return false, nil
+		}
+
+		req.NextToken = res.NextToken
 	}
+}
 
-	// return latest timestamp and NextToken seen in the response
-	return ts, resp.NextToken, nil
+// logTimestamp returns the number of milliseconds since Jan 1, 1970 00:00:00 UTC
+func logTimestamp(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
 }
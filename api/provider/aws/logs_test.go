@@ -0,0 +1,63 @@
+package aws_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/convox/rack/api/awsutil"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogQuery(t *testing.T) {
+	aws := StubAwsProvider(
+		describeStacksCycle,
+		filterLogEventsCycle,
+	)
+	defer aws.Close()
+
+	defer func() {
+		//TODO: remove: as we arent updating all tests we need to set current provider back to a
+		//clean default one (I miss rspec before)
+		provider.CurrentProvider = new(provider.TestProviderRunner)
+	}()
+
+	records, err := provider.LogQuery("httpd", structs.LogStreamOptions{
+		End:     time.Unix(1472724000, 0),
+		Limit:   10,
+		Process: "web",
+		Start:   time.Unix(1472720400, 0),
+	})
+
+	assert.Nil(t, err)
+	assert.EqualValues(t, structs.LogRecords{
+		structs.LogRecord{
+			Container: "0123456789ab",
+			Instance:  "i-1234abcd",
+			Message:   "GET / 200",
+			Process:   "web",
+			Release:   "RVFETUHHKKD",
+			Stream:    "i-1234abcd/0123456789ab",
+			Timestamp: time.Unix(1472720401, 0),
+		},
+	}, records)
+}
+
+var filterLogEventsCycle = awsutil.Cycle{
+	Request: awsutil.Request{
+		RequestURI: "/",
+		Operation:  "Logs_20140328.FilterLogEvents",
+		Body:       `{"endTime":1472724000000,"interleaved":true,"logGroupName":"convox-httpd-LogGroup-L4V203L35WRM","startTime":1472720400000}`,
+	},
+	Response: awsutil.Response{
+		StatusCode: 200,
+		Body: `{
+			"events": [
+				{"logStreamName": "i-1234abcd/0123456789ab", "message": "web:RVFETUHHKKD/0123456789ab GET / 200", "timestamp": 1472720401000},
+				{"logStreamName": "i-1234abcd/ba9876543210", "message": "worker:RVFETUHHKKD/ba9876543210 working", "timestamp": 1472720402000}
+			]
+		}`,
+	},
+}
@@ -0,0 +1,201 @@
+package aws
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/convox/rack/api/structs"
+)
+
+const (
+	// namespace for process metrics in CloudWatch
+	metricsNamespace = "Convox/Processes"
+
+	// CloudWatch returns at most 1440 datapoints per request
+	metricsMaxDatapoints = 1440
+)
+
+// processMetric maps a CloudWatch metric to a field of a sample
+type processMetric struct {
+	Name      string
+	Statistic string
+	Unit      string
+	Value     func(*structs.MetricSample) *float64
+}
+
+var processMetrics = []processMetric{
+	{"ContainerCount", "Average", "Count", func(s *structs.MetricSample) *float64 { return &s.Count }},
+	{"CPUUtilization", "Average", "Percent", func(s *structs.MetricSample) *float64 { return &s.Cpu }},
+	{"MemoryUtilization", "Average", "None", func(s *structs.MetricSample) *float64 { return &s.Memory }},
+	{"NetworkRxBytes", "Sum", "Bytes", func(s *structs.MetricSample) *float64 { return &s.NetworkRx }},
+	{"NetworkTxBytes", "Sum", "Bytes", func(s *structs.MetricSample) *float64 { return &s.NetworkTx }},
+	{"Restarts", "Sum", "Count", func(s *structs.MetricSample) *float64 { return &s.Restarts }},
+}
+
+// MetricsGet reads process metrics for an app from CloudWatch
+func (p *AWSProvider) MetricsGet(app string, opts structs.MetricsOptions) (structs.MetricSamples, error) {
+	if _, err := p.AppGet(app); err != nil {
+		return nil, err
+	}
+
+	if opts.End.IsZero() {
+		opts.End = time.Now()
+	}
+
+	if opts.Start.IsZero() {
+		opts.Start = opts.End.Add(-1 * time.Hour)
+	}
+
+	period := metricsPeriod(opts)
+
+	processes := []string{opts.Process}
+
+	if opts.Process == "" {
+		ps, err := p.metricProcesses(app)
+		if err != nil {
+			return nil, err
+		}
+
+		processes = ps
+	}
+
+	samples := structs.MetricSamples{}
+
+	for _, ps := range processes {
+		points := map[time.Time]*structs.MetricSample{}
+
+		for _, m := range processMetrics {
+			res, err := p.cloudwatch().GetMetricStatistics(&cloudwatch.GetMetricStatisticsInput{
+				Dimensions: metricDimensions(app, ps),
+				EndTime:    aws.Time(opts.End),
+				MetricName: aws.String(m.Name),
+				Namespace:  aws.String(metricsNamespace),
+				Period:     aws.Int64(period),
+				StartTime:  aws.Time(opts.Start),
+				Statistics: []*string{aws.String(m.Statistic)},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range res.Datapoints {
+				t := d.Timestamp.UTC()
+
+				s, ok := points[t]
+				if !ok {
+					s = &structs.MetricSample{Process: ps, Timestamp: t}
+					points[t] = s
+				}
+
+				if m.Statistic == "Sum" {
+					*m.Value(s) = aws.Float64Value(d.Sum)
+				} else {
+					*m.Value(s) = aws.Float64Value(d.Average)
+				}
+			}
+		}
+
+		for _, s := range points {
+			samples = append(samples, *s)
+		}
+	}
+
+	sort.Sort(samples)
+
+	return samples, nil
+}
+
+// MetricsPut records process metrics for an app in CloudWatch
+func (p *AWSProvider) MetricsPut(app string, samples structs.MetricSamples) error {
+	data := []*cloudwatch.MetricDatum{}
+
+	for _, s := range samples {
+		for _, m := range processMetrics {
+			data = append(data, &cloudwatch.MetricDatum{
+				Dimensions: metricDimensions(app, s.Process),
+				MetricName: aws.String(m.Name),
+				Timestamp:  aws.Time(s.Timestamp),
+				Unit:       aws.String(m.Unit),
+				Value:      aws.Float64(*m.Value(&s)),
+			})
+		}
+	}
+
+	// PutMetricData accepts at most 20 datums per request
+	for len(data) > 0 {
+		n := 20
+		if len(data) < n {
+			n = len(data)
+		}
+
+		_, err := p.cloudwatch().PutMetricData(&cloudwatch.PutMetricDataInput{
+			MetricData: data[0:n],
+			Namespace:  aws.String(metricsNamespace),
+		})
+		if err != nil {
+			return err
+		}
+
+		data = data[n:]
+	}
+
+	return nil
+}
+
+// metricProcesses returns the names of the processes of an app that have recorded metrics
+func (p *AWSProvider) metricProcesses(app string) ([]string, error) {
+	processes := []string{}
+
+	err := p.cloudwatch().ListMetricsPages(&cloudwatch.ListMetricsInput{
+		Dimensions: []*cloudwatch.DimensionFilter{
+			{Name: aws.String("Rack"), Value: aws.String(os.Getenv("RACK"))},
+			{Name: aws.String("App"), Value: aws.String(app)},
+		},
+		MetricName: aws.String("ContainerCount"),
+		Namespace:  aws.String(metricsNamespace),
+	}, func(res *cloudwatch.ListMetricsOutput, last bool) bool {
+		for _, m := range res.Metrics {
+			for _, d := range m.Dimensions {
+				if *d.Name == "Process" {
+					processes = append(processes, *d.Value)
+				}
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(processes)
+
+	return processes, nil
+}
+
+func metricDimensions(app, process string) []*cloudwatch.Dimension {
+	return []*cloudwatch.Dimension{
+		{Name: aws.String("Rack"), Value: aws.String(os.Getenv("RACK"))},
+		{Name: aws.String("App"), Value: aws.String(app)},
+		{Name: aws.String("Process"), Value: aws.String(process)},
+	}
+}
+
+// metricsPeriod returns the CloudWatch period in seconds for a step, which must be
+// a multiple of 60 and small enough to stay under the datapoint limit
+func metricsPeriod(opts structs.MetricsOptions) int64 {
+	period := int64(opts.Step.Seconds())
+
+	if min := int64(opts.End.Sub(opts.Start).Seconds()) / metricsMaxDatapoints; period < min {
+		period = min
+	}
+
+	if period < 60 {
+		return 60
+	}
+
+	return (period + 59) / 60 * 60
+}
@@ -19,7 +19,9 @@ type Provider interface {
 	BuildCreateRepo(app, url, manifest, description string, cache bool) (*structs.Build, error)
 	BuildCreateTar(app string, src io.Reader, manifest, description string, cache bool) (*structs.Build, error)
 	BuildDelete(app, id string) (*structs.Build, error)
+	BuildExport(app, id string, w io.Writer) error
 	BuildGet(app, id string) (*structs.Build, error)
+	BuildImport(app string, r io.Reader) (*structs.Build, error)
 	BuildList(app string) (structs.Builds, error)
 	BuildRelease(*structs.Build) (*structs.Release, error)
 	BuildSave(*structs.Build) error
@@ -39,8 +41,12 @@ type Provider interface {
 
 	InstanceList() (structs.Instances, error)
 
+	LogQuery(app string, opts structs.LogStreamOptions) (structs.LogRecords, error)
 	LogStream(app string, w io.Writer, opts structs.LogStreamOptions) error
 
+	MetricsGet(app string, opts structs.MetricsOptions) (structs.MetricSamples, error)
+	MetricsPut(app string, samples structs.MetricSamples) error
+
 	ReleaseDelete(app, id string) (*structs.Release, error)
 	ReleaseGet(app, id string) (*structs.Release, error)
 	ReleaseList(app string) (structs.Releases, error)
@@ -54,6 +60,7 @@ type Provider interface {
 	ServiceUnlink(name, app, process string) (*structs.Service, error)
 
 	SystemGet() (*structs.System, error)
+	SystemLogs(w io.Writer, opts structs.LogStreamOptions) error
 	SystemSave(system structs.System) error
 }
 
@@ -100,10 +107,18 @@ func BuildDelete(app, id string) (*structs.Build, error) {
 	return CurrentProvider.BuildDelete(app, id)
 }
 
+func BuildExport(app, id string, w io.Writer) error {
+	return CurrentProvider.BuildExport(app, id, w)
+}
+
 func BuildGet(app, id string) (*structs.Build, error) {
 	return CurrentProvider.BuildGet(app, id)
 }
 
+func BuildImport(app string, r io.Reader) (*structs.Build, error) {
+	return CurrentProvider.BuildImport(app, r)
+}
+
 func BuildList(app string) (structs.Builds, error) {
 	return CurrentProvider.BuildList(app)
 }
@@ -156,10 +171,22 @@ func InstanceList() (structs.Instances, error) {
 	return CurrentProvider.InstanceList()
 }
 
+func LogQuery(app string, opts structs.LogStreamOptions) (structs.LogRecords, error) {
+	return CurrentProvider.LogQuery(app, opts)
+}
+
 func LogStream(app string, w io.Writer, opts structs.LogStreamOptions) error {
 	return CurrentProvider.LogStream(app, w, opts)
 }
 
+func MetricsGet(app string, opts structs.MetricsOptions) (structs.MetricSamples, error) {
+	return CurrentProvider.MetricsGet(app, opts)
+}
+
+func MetricsPut(app string, samples structs.MetricSamples) error {
+	return CurrentProvider.MetricsPut(app, samples)
+}
+
 func ReleaseDelete(app, id string) (*structs.Release, error) {
 	return CurrentProvider.ReleaseDelete(app, id)
 }
@@ -204,6 +231,10 @@ func SystemGet() (*structs.System, error) {
 	return CurrentProvider.SystemGet()
 }
 
+func SystemLogs(w io.Writer, opts structs.LogStreamOptions) error {
+	return CurrentProvider.SystemLogs(w, opts)
+}
+
 func SystemSave(system structs.System) error {
 	return CurrentProvider.SystemSave(system)
 }
@@ -18,6 +18,8 @@ type TestProviderRunner struct {
 	Certificate  structs.Certificate
 	Certificates structs.Certificates
 	Instances    structs.Instances
+	LogRecords   structs.LogRecords
+	Metrics      structs.MetricSamples
 	Release      structs.Release
 	Releases     structs.Releases
 	Service      structs.Service
@@ -53,11 +55,21 @@ func (p *TestProviderRunner) BuildDelete(app, id string) (*structs.Build, error)
 	return &p.Build, nil
 }
 
+func (p *TestProviderRunner) BuildExport(app, id string, w io.Writer) error {
+	p.Called(app, id, w)
+	return nil
+}
+
 func (p *TestProviderRunner) BuildGet(app, id string) (*structs.Build, error) {
 	p.Called(app, id)
 	return &p.Build, nil
 }
 
+func (p *TestProviderRunner) BuildImport(app string, r io.Reader) (*structs.Build, error) {
+	p.Called(app, r)
+	return &p.Build, nil
+}
+
 func (p *TestProviderRunner) BuildList(app string) (structs.Builds, error) {
 	p.Called(app)
 	return p.Builds, nil
@@ -123,11 +135,26 @@ func (p *TestProviderRunner) InstanceList() (structs.Instances, error) {
 	return p.Instances, nil
 }
 
+func (p *TestProviderRunner) LogQuery(app string, opts structs.LogStreamOptions) (structs.LogRecords, error) {
+	p.Called(app, opts)
+	return p.LogRecords, nil
+}
+
 func (p *TestProviderRunner) LogStream(app string, w io.Writer, opts structs.LogStreamOptions) error {
 	p.Called(app, w, opts)
 	return nil
 }
 
+func (p *TestProviderRunner) MetricsGet(app string, opts structs.MetricsOptions) (structs.MetricSamples, error) {
+	p.Called(app, opts)
+	return p.Metrics, nil
+}
+
+func (p *TestProviderRunner) MetricsPut(app string, samples structs.MetricSamples) error {
+	p.Called(app, samples)
+	return nil
+}
+
 func (p *TestProviderRunner) ReleaseDelete(app, id string) (*structs.Release, error) {
 	p.Called(app, id)
 	return &p.Release, nil
@@ -183,6 +210,11 @@ func (p *TestProviderRunner) SystemGet() (*structs.System, error) {
 	return nil, nil
 }
 
+func (p *TestProviderRunner) SystemLogs(w io.Writer, opts structs.LogStreamOptions) error {
+	p.Called(w, opts)
+	return nil
+}
+
 func (p *TestProviderRunner) SystemSave(system structs.System) error {
 	p.Called(system)
 	return nil
@@ -1,6 +1,7 @@
 package structs
 
 import (
+	"fmt"
 	"strings"
 	"time"
 )
@@ -9,10 +10,30 @@ type Certificate struct {
 	Id         string    `json:"id"`
 	Domain     string    `json:"domain"`
 	Expiration time.Time `json:"expiration"`
+
+	// Balancers are the app balancer ports using the certificate
+	Balancers CertificateBalancers `json:"balancers,omitempty"`
 }
 
 type Certificates []Certificate
 
+// CertificateBalancer is an app balancer port with a certificate
+type CertificateBalancer struct {
+	App     string `json:"app"`
+	Process string `json:"process"`
+	Port    int    `json:"port"`
+}
+
+type CertificateBalancers []CertificateBalancer
+
+func (b CertificateBalancer) String() string {
+	return fmt.Sprintf("%s/%s:%d", b.App, b.Process, b.Port)
+}
+
 func (c Certificates) Len() int           { return len(c) }
 func (c Certificates) Less(i, j int) bool { return strings.ToUpper(c[i].Id) < strings.ToUpper(c[j].Id) }
 func (c Certificates) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+func (b CertificateBalancers) Len() int           { return len(b) }
+func (b CertificateBalancers) Less(i, j int) bool { return b[i].String() < b[j].String() }
+func (b CertificateBalancers) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
@@ -0,0 +1,63 @@
+package structs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogRecord is a single log line with the metadata from its stream and prefix
+type LogRecord struct {
+	Id        string    `json:"id,omitempty"`
+	Container string    `json:"container,omitempty"`
+	Instance  string    `json:"instance,omitempty"`
+	Message   string    `json:"message"`
+	Process   string    `json:"process,omitempty"`
+	Release   string    `json:"release,omitempty"`
+	Stream    string    `json:"stream"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type LogRecords []LogRecord
+
+// log lines are prefixed with process:release/container, e.g. "web:RABCDEFGHI/0123456789ab"
+var logPrefix = regexp.MustCompile(`^([a-z][-a-z0-9]*):([^/ ]+)/([^ ]+) (.*)$`)
+
+// NewLogRecord parses the prefix of a log line. Lines without a prefix are kept whole.
+// Log streams named for an EC2 instance set the instance.
+func NewLogRecord(stream, line string, t time.Time) LogRecord {
+	r := LogRecord{
+		Message:   line,
+		Stream:    stream,
+		Timestamp: t,
+	}
+
+	if m := logPrefix.FindStringSubmatch(line); m != nil {
+		r.Process = m[1]
+		r.Release = m[2]
+		r.Container = m[3]
+		r.Message = m[4]
+	}
+
+	for _, part := range strings.Split(stream, "/") {
+		if strings.HasPrefix(part, "i-") {
+			r.Instance = part
+		}
+	}
+
+	if strings.HasPrefix(r.Container, "i-") {
+		r.Instance = r.Container
+	}
+
+	return r
+}
+
+// Raw returns the log line as it was written
+func (r LogRecord) Raw() string {
+	if r.Process == "" {
+		return r.Message
+	}
+
+	return fmt.Sprintf("%s:%s/%s %s", r.Process, r.Release, r.Container, r.Message)
+}
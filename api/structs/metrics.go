@@ -0,0 +1,161 @@
+package structs
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MetricSample is the resource usage of every container of a process at a point in time
+type MetricSample struct {
+	Process   string    `json:"process"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Count is the number of running containers
+	Count float64 `json:"count"`
+
+	// Cpu is the average cpu percentage across containers
+	Cpu float64 `json:"cpu"`
+
+	// Memory is the average fraction of the memory limit used across containers
+	Memory float64 `json:"memory"`
+
+	// NetworkRx and NetworkTx are the bytes received and sent since the previous sample
+	NetworkRx float64 `json:"network-rx"`
+	NetworkTx float64 `json:"network-tx"`
+
+	// Restarts is the number of containers that exited and were replaced since the previous sample
+	Restarts float64 `json:"restarts"`
+}
+
+type MetricSamples []MetricSample
+
+type MetricsOptions struct {
+	End     time.Time     `json:"end"`
+	Process string        `json:"process"`
+	Start   time.Time     `json:"start"`
+	Step    time.Duration `json:"step"`
+}
+
+func (ss MetricSamples) Len() int      { return len(ss) }
+func (ss MetricSamples) Swap(i, j int) { ss[i], ss[j] = ss[j], ss[i] }
+
+func (ss MetricSamples) Less(i, j int) bool {
+	if ss[i].Timestamp.Equal(ss[j].Timestamp) {
+		return ss[i].Process < ss[j].Process
+	}
+
+	return ss[i].Timestamp.Before(ss[j].Timestamp)
+}
+
+// Bucket combines samples into one sample per process for every step. Gauges are
+// averaged and counters are summed.
+func (ss MetricSamples) Bucket(step time.Duration) MetricSamples {
+	if step <= 0 {
+		return ss
+	}
+
+	type bucket struct {
+		sample MetricSample
+		n      float64
+	}
+
+	buckets := map[string]*bucket{}
+	keys := []string{}
+
+	for _, s := range ss {
+		t := s.Timestamp.Truncate(step)
+		key := s.Process + "/" + t.Format(time.RFC3339Nano)
+
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{sample: MetricSample{Process: s.Process, Timestamp: t}}
+			buckets[key] = b
+			keys = append(keys, key)
+		}
+
+		b.n++
+		b.sample.Count += s.Count
+		b.sample.Cpu += s.Cpu
+		b.sample.Memory += s.Memory
+		b.sample.NetworkRx += s.NetworkRx
+		b.sample.NetworkTx += s.NetworkTx
+		b.sample.Restarts += s.Restarts
+	}
+
+	bucketed := make(MetricSamples, len(keys))
+
+	for i, key := range keys {
+		b := buckets[key]
+		b.sample.Count /= b.n
+		b.sample.Cpu /= b.n
+		b.sample.Memory /= b.n
+		bucketed[i] = b.sample
+	}
+
+	sort.Sort(bucketed)
+
+	return bucketed
+}
+
+// MetricsRing keeps the most recent samples for each app in memory for providers
+// without a metrics service
+type MetricsRing struct {
+	lock    sync.Mutex
+	next    map[string]int
+	samples map[string]MetricSamples
+	size    int
+}
+
+// NewMetricsRing returns a ring that holds up to size samples per app
+func NewMetricsRing(size int) *MetricsRing {
+	return &MetricsRing{
+		next:    map[string]int{},
+		samples: map[string]MetricSamples{},
+		size:    size,
+	}
+}
+
+// Put records samples for an app, overwriting the oldest once the ring is full
+func (r *MetricsRing) Put(app string, samples MetricSamples) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, s := range samples {
+		if len(r.samples[app]) < r.size {
+			r.samples[app] = append(r.samples[app], s)
+			continue
+		}
+
+		r.samples[app][r.next[app]] = s
+		r.next[app] = (r.next[app] + 1) % r.size
+	}
+}
+
+// Get returns the samples for an app that match opts, bucketed by opts.Step
+func (r *MetricsRing) Get(app string, opts MetricsOptions) MetricSamples {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	matched := MetricSamples{}
+
+	for _, s := range r.samples[app] {
+		if opts.Process != "" && s.Process != opts.Process {
+			continue
+		}
+
+		if !opts.Start.IsZero() && s.Timestamp.Before(opts.Start) {
+			continue
+		}
+
+		if !opts.End.IsZero() && s.Timestamp.After(opts.End) {
+			continue
+		}
+
+		matched = append(matched, s)
+	}
+
+	sort.Sort(matched)
+
+	return matched.Bucket(opts.Step)
+}
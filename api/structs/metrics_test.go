@@ -0,0 +1,53 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricSamplesBucket(t *testing.T) {
+	now := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	samples := MetricSamples{
+		{Process: "web", Timestamp: now, Count: 2, Cpu: 10, Memory: 0.25, NetworkRx: 100, NetworkTx: 50, Restarts: 0},
+		{Process: "web", Timestamp: now.Add(30 * time.Second), Count: 2, Cpu: 30, Memory: 0.75, NetworkRx: 300, NetworkTx: 150, Restarts: 1},
+		{Process: "worker", Timestamp: now.Add(30 * time.Second), Count: 1, Cpu: 5, Memory: 0.1},
+		{Process: "web", Timestamp: now.Add(60 * time.Second), Count: 3, Cpu: 20, Memory: 0.3},
+	}
+
+	assert.Equal(t, MetricSamples{
+		{Process: "web", Timestamp: now, Count: 2, Cpu: 20, Memory: 0.5, NetworkRx: 400, NetworkTx: 200, Restarts: 1},
+		{Process: "worker", Timestamp: now, Count: 1, Cpu: 5, Memory: 0.1},
+		{Process: "web", Timestamp: now.Add(60 * time.Second), Count: 3, Cpu: 20, Memory: 0.3},
+	}, samples.Bucket(time.Minute))
+
+	assert.Equal(t, samples, samples.Bucket(0))
+}
+
+func TestMetricsRing(t *testing.T) {
+	now := time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	r := NewMetricsRing(3)
+
+	for i := 0; i < 5; i++ {
+		r.Put("httpd", MetricSamples{{Process: "web", Timestamp: now.Add(time.Duration(i) * time.Minute), Cpu: float64(i)}})
+	}
+
+	r.Put("other", MetricSamples{{Process: "web", Timestamp: now, Cpu: 99}})
+
+	// the two oldest samples were overwritten
+	assert.Equal(t, MetricSamples{
+		{Process: "web", Timestamp: now.Add(2 * time.Minute), Cpu: 2},
+		{Process: "web", Timestamp: now.Add(3 * time.Minute), Cpu: 3},
+		{Process: "web", Timestamp: now.Add(4 * time.Minute), Cpu: 4},
+	}, r.Get("httpd", MetricsOptions{}))
+
+	assert.Equal(t, MetricSamples{
+		{Process: "web", Timestamp: now.Add(3 * time.Minute), Cpu: 3},
+	}, r.Get("httpd", MetricsOptions{Start: now.Add(3 * time.Minute), End: now.Add(3 * time.Minute)}))
+
+	assert.Equal(t, MetricSamples{}, r.Get("httpd", MetricsOptions{Process: "worker"}))
+	assert.Equal(t, MetricSamples{}, r.Get("missing", MetricsOptions{}))
+}
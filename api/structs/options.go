@@ -3,7 +3,30 @@ package structs
 import "time"
 
 type LogStreamOptions struct {
-	Filter string        `json:"filter"`
-	Follow bool          `json:"follow"`
-	Since  time.Duration `json:"since"`
+	End      time.Time     `json:"end"`
+	Filter   string        `json:"filter"`
+	Follow   bool          `json:"follow"`
+	Instance string        `json:"instance"`
+	Limit    int           `json:"limit"`
+	Process  string        `json:"process"`
+	Release  string        `json:"release"`
+	Since    time.Duration `json:"since"`
+	Start    time.Time     `json:"start"`
+}
+
+// Matches returns true if a log record passes the process, release and instance filters
+func (o LogStreamOptions) Matches(r LogRecord) bool {
+	if o.Process != "" && r.Process != o.Process {
+		return false
+	}
+
+	if o.Release != "" && r.Release != o.Release {
+		return false
+	}
+
+	if o.Instance != "" && r.Instance != o.Instance && r.Container != o.Instance {
+		return false
+	}
+
+	return true
 }
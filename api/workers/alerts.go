@@ -0,0 +1,242 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+	"github.com/ddollar/logger"
+)
+
+// Evaluate alert rules and send events when they trigger and resolve
+func StartAlerts() {
+	log := logger.New("ns=alerts")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	for _ = range time.Tick(1 * time.Minute) {
+		evaluateAlerts(log)
+	}
+}
+
+func evaluateAlerts(log *logger.Logger) {
+	alerts, err := models.ListAlerts()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	if len(alerts) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	states := map[string]models.AlertState{}
+
+	for _, a := range alerts {
+		active, value, message, err := checkAlert(a, now)
+		if err != nil {
+			log.Log("alert=%s err=%q", a.Id, err)
+			states[a.Id] = a.State
+			continue
+		}
+
+		state, action := nextAlertState(a, active, value, message, now)
+
+		if action != "" {
+			log.Log("alert=%s action=%s value=%f", a.Id, action, value)
+
+			err = sendAlert(a, action, state)
+			if err != nil {
+				log.Log("alert=%s err=%q", a.Id, err)
+
+				// retry a failed trigger on the next pass
+				if action == "alert:trigger" {
+					state.Notified = false
+				}
+			}
+		}
+
+		states[a.Id] = state
+	}
+
+	err = models.SaveAlertState(states)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// checkAlert returns whether the condition of an alert holds, its current value and a description
+func checkAlert(a models.Alert, now time.Time) (bool, float64, string, error) {
+	switch a.Metric {
+	case "cpu", "memory", "restarts":
+		samples, err := provider.MetricsGet(a.App, structs.MetricsOptions{
+			End:     now,
+			Process: a.Process,
+			Start:   now.Add(-a.Window),
+			Step:    a.Window,
+		})
+		if err != nil {
+			return false, 0, "", err
+		}
+
+		process, value := worstProcess(a.Metric, samples)
+
+		subject := a.Subject()
+
+		if a.Process == "" && process != "" {
+			subject = fmt.Sprintf("%s of %s/%s", a.Metric, a.App, process)
+		}
+
+		switch a.Metric {
+		case "restarts":
+			return value > a.Threshold, value, fmt.Sprintf("%s is %0.0f in %s, threshold %0.0f", subject, value, a.Window, a.Threshold), nil
+		default:
+			return value > a.Threshold, value, fmt.Sprintf("%s is %0.1f%% over %s, threshold %0.1f%%", subject, value, a.Window, a.Threshold), nil
+		}
+	case "deployment":
+		if a.App != "" {
+			app, err := models.GetApp(a.App)
+			if err != nil {
+				return false, 0, "", err
+			}
+
+			return app.Status != "running", 0, fmt.Sprintf("%s is %s for more than %s", a.Subject(), app.Status, a.Window), nil
+		}
+
+		services, err := models.ClusterServices()
+		if err != nil {
+			return false, 0, "", err
+		}
+
+		return !services.IsConverged(), 0, fmt.Sprintf("%s has not converged for more than %s", a.Subject(), a.Window), nil
+	case "instances":
+		system, err := provider.SystemGet()
+		if err != nil {
+			return false, 0, "", err
+		}
+
+		value := float64(system.Count)
+
+		return value >= a.Threshold, value, fmt.Sprintf("%s is %0.0f, threshold %0.0f", a.Subject(), value, a.Threshold), nil
+	}
+
+	return false, 0, "", fmt.Errorf("unknown alert metric: %s", a.Metric)
+}
+
+// worstProcess returns the process with the highest value for a metric. Cpu and memory are
+// averaged over the samples and restarts are summed. Memory is returned as a percentage.
+func worstProcess(metric string, samples structs.MetricSamples) (string, float64) {
+	totals := map[string]float64{}
+	counts := map[string]float64{}
+
+	for _, s := range samples {
+		switch metric {
+		case "cpu":
+			totals[s.Process] += s.Cpu
+		case "memory":
+			totals[s.Process] += s.Memory * 100
+		case "restarts":
+			totals[s.Process] += s.Restarts
+		}
+
+		counts[s.Process]++
+	}
+
+	worst := ""
+	value := 0.0
+
+	for ps, total := range totals {
+		if metric != "restarts" {
+			total /= counts[ps]
+		}
+
+		if worst == "" || total > value {
+			worst = ps
+			value = total
+		}
+	}
+
+	return worst, value
+}
+
+// nextAlertState moves an alert between ok, pending and firing. Deployment alerts stay
+// pending until the condition has held for the window. Each time an alert fires it is
+// sent once, or once its silence ends, and a resolve is sent only if the trigger was.
+func nextAlertState(a models.Alert, active bool, value float64, message string, now time.Time) (models.AlertState, string) {
+	state := a.State
+	state.Updated = now
+	state.Value = value
+
+	if !active {
+		action := ""
+
+		if state.Status == "firing" && state.Notified && !a.Silenced(now) {
+			action = "alert:resolve"
+		}
+
+		if state.Status != "ok" {
+			state.Since = now
+		}
+
+		state.Status = "ok"
+		state.Message = ""
+		state.Notified = false
+
+		return state, action
+	}
+
+	state.Message = message
+
+	if state.Status != "pending" && state.Status != "firing" {
+		state.Status = "pending"
+		state.Since = now
+		state.Notified = false
+	}
+
+	hold := time.Duration(0)
+
+	if a.Metric == "deployment" {
+		hold = a.Window
+	}
+
+	if state.Status == "pending" && now.Sub(state.Since) >= hold {
+		state.Status = "firing"
+		state.Since = now
+	}
+
+	if state.Status == "firing" && !state.Notified && !a.Silenced(now) {
+		state.Notified = true
+		return state, "alert:trigger"
+	}
+
+	return state, ""
+}
+
+func sendAlert(a models.Alert, action string, state models.AlertState) error {
+	e := &structs.Event{
+		Action: action,
+		Data: map[string]string{
+			"app":       a.App,
+			"id":        a.Id,
+			"metric":    a.Metric,
+			"process":   a.Process,
+			"threshold": fmt.Sprintf("%g", a.Threshold),
+			"value":     fmt.Sprintf("%g", state.Value),
+		},
+	}
+
+	if action == "alert:trigger" {
+		return provider.EventSend(e, errors.New(state.Message))
+	}
+
+	e.Data["message"] = fmt.Sprintf("%s resolved", a.Subject())
+
+	return provider.EventSend(e, nil)
+}
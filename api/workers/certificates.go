@@ -0,0 +1,144 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/ddollar/logger"
+)
+
+// Renew certificates issued through ACME before they expire and warn about
+// certificates close to expiry
+func StartCertificates() {
+	log := logger.New("ns=certificates")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	warnings, err := models.CertificateExpiryWarnings(os.Getenv("CERTIFICATE_EXPIRY_WARNINGS"))
+	if err != nil {
+		log.Error(err)
+		warnings = models.DefaultCertificateExpiryWarnings
+	}
+
+	for _ = range time.Tick(1 * time.Hour) {
+		renewCertificates(log)
+		warnExpiringCertificates(log, warnings)
+	}
+}
+
+func renewCertificates(log *logger.Logger) {
+	certs, err := models.ListAcmeCertificates()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, c := range certs {
+		if c.Due(time.Now()) {
+			log.Log("id=%s status=%s expiration=%s renew=true", c.Id, c.Status, c.Expiration.Format(time.RFC3339))
+
+			if err := models.IssueAcmeCertificate(c.Id); err != nil {
+				log.Log("id=%s err=%q", c.Id, err)
+				continue
+			}
+		}
+
+		if err := models.RetireReplacedCertificates(c.Id); err != nil {
+			log.Log("id=%s retire=%q", c.Id, err)
+		}
+	}
+}
+
+// warnExpiringCertificates sends a cert:expiring event each time a certificate
+// crosses one of the warning thresholds. The thresholds sent are kept in the
+// settings bucket so a restarted rack does not warn again.
+func warnExpiringCertificates(log *logger.Logger, warnings []int) {
+	certs, err := models.ListCertificates()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	// smallest warning threshold sent per certificate
+	warned, err := models.LoadCertificateWarnings()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	changed := false
+
+	// forget certificates that have been deleted
+	for id := range warned {
+		found := false
+
+		for _, c := range certs {
+			if c.Id == id {
+				found = true
+			}
+		}
+
+		if !found {
+			delete(warned, id)
+			changed = true
+		}
+	}
+
+	defer func() {
+		if !changed {
+			return
+		}
+
+		if err := models.SaveCertificateWarnings(warned); err != nil {
+			log.Error(err)
+		}
+	}()
+
+	for _, c := range certs {
+		if c.Expiration.IsZero() {
+			continue
+		}
+
+		days := models.CertificateDays(c.Expiration, time.Now())
+
+		threshold, crossed := models.CertificateExpiryWarning(days, warnings)
+
+		if !crossed {
+			if _, ok := warned[c.Id]; ok {
+				delete(warned, c.Id)
+				changed = true
+			}
+			continue
+		}
+
+		if last, ok := warned[c.Id]; ok && last <= threshold {
+			continue
+		}
+
+		warned[c.Id] = threshold
+		changed = true
+
+		balancers := []string{}
+
+		for _, b := range c.Balancers {
+			balancers = append(balancers, b.String())
+		}
+
+		log.Log("id=%s domain=%s days=%d threshold=%d expiring=true", c.Id, c.Domain, days, threshold)
+
+		models.NotifySuccess("cert:expiring", map[string]string{
+			"id":         c.Id,
+			"domain":     c.Domain,
+			"expiration": c.Expiration.Format(time.RFC3339),
+			"days":       fmt.Sprintf("%d", days),
+			"threshold":  fmt.Sprintf("%d", threshold),
+			"balancers":  strings.Join(balancers, ","),
+		})
+	}
+}
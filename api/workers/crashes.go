@@ -0,0 +1,105 @@
+package workers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+	"github.com/ddollar/logger"
+)
+
+const (
+	crashLoopCount  = 3                // crashes of a process within the window that make a crash loop
+	crashLoopWindow = 10 * time.Minute // window to count crashes in and to wait before notifying again
+)
+
+// Record stopped processes before ECS forgets them and notify when a process crashes repeatedly
+func StartCrashes() {
+	log := logger.New("ns=crashes")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	notified := map[string]time.Time{}
+
+	for _ = range time.Tick(1 * time.Minute) {
+		recordCrashes(log, notified)
+	}
+}
+
+func recordCrashes(log *logger.Logger, notified map[string]time.Time) {
+	apps, err := models.ListApps()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, a := range apps {
+		if a.Status != "running" {
+			continue
+		}
+
+		stopped, err := models.FetchStoppedProcesses(a.Name)
+		if err != nil {
+			log.Log("app=%s err=%q", a.Name, err)
+			continue
+		}
+
+		added, err := models.RecordStoppedProcesses(a.Name, stopped)
+		if err != nil {
+			log.Log("app=%s err=%q", a.Name, err)
+			continue
+		}
+
+		for _, p := range added {
+			log.Log("app=%s process=%s id=%s crashed=%t oom=%t reason=%q", a.Name, p.Name, p.Id, p.Crashed, p.OOMKilled, p.Description)
+		}
+
+		now := time.Now()
+
+		for name, crashes := range crashLoops(stopped, now) {
+			key := fmt.Sprintf("%s/%s", a.Name, name)
+
+			if now.Sub(notified[key]) < crashLoopWindow {
+				continue
+			}
+
+			notified[key] = now
+
+			last := crashes[0]
+
+			provider.EventSend(&structs.Event{
+				Action: "process:crash",
+				Data: map[string]string{
+					"app":     a.Name,
+					"process": name,
+					"release": last.Release,
+					"count":   fmt.Sprintf("%d", len(crashes)),
+				},
+			}, fmt.Errorf("%s crashed %d times in %s, last: %s", name, len(crashes), crashLoopWindow, last.Description))
+		}
+	}
+}
+
+// crashLoops returns the recent crashes of each process that crashed repeatedly, newest first
+func crashLoops(ps models.StoppedProcesses, now time.Time) map[string]models.StoppedProcesses {
+	crashes := map[string]models.StoppedProcesses{}
+
+	for _, p := range ps {
+		if p.Crashed && now.Sub(p.Stopped) < crashLoopWindow {
+			crashes[p.Name] = append(crashes[p.Name], p)
+		}
+	}
+
+	for name, cs := range crashes {
+		if len(cs) < crashLoopCount {
+			delete(crashes, name)
+		}
+	}
+
+	return crashes
+}
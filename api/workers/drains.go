@@ -0,0 +1,405 @@
+package workers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+	"github.com/ddollar/logger"
+)
+
+const (
+	drainBatch   = 500   // lines sent per request or connection
+	drainBuffer  = 10000 // lines queued per drain before the oldest are dropped
+	drainPage    = 5000  // log events read per query
+	drainRetries = 3     // attempts per batch before giving up until the next pass
+
+	// log queries share the CloudWatch rate limit with convox logs so the
+	// interval between passes doubles while queries are throttled
+	drainInterval    = 10 * time.Second
+	drainIntervalMax = 5 * time.Minute
+
+	// CloudWatch can ingest events minutes after their timestamp so every query
+	// reaches back this far and events already seen are skipped by id
+	drainLag = 2 * time.Minute
+)
+
+// drainCursor tracks how far the logs of an app have been read
+type drainCursor struct {
+	latest time.Time
+	polled time.Time
+	seen   map[string]time.Time
+}
+
+// drainOrder sorts apps so the ones read longest ago go first, which keeps a
+// throttled pass from always skipping the same apps
+type drainOrder struct {
+	apps    []string
+	cursors map[string]*drainCursor
+}
+
+func (o drainOrder) Len() int      { return len(o.apps) }
+func (o drainOrder) Swap(i, j int) { o.apps[i], o.apps[j] = o.apps[j], o.apps[i] }
+
+func (o drainOrder) Less(i, j int) bool {
+	return o.polled(o.apps[i]).Before(o.polled(o.apps[j]))
+}
+
+func (o drainOrder) polled(app string) time.Time {
+	if c, ok := o.cursors[app]; ok {
+		return c.polled
+	}
+
+	return time.Time{}
+}
+
+// drainQueue holds the lines waiting for a drain. Each queue is delivered by
+// its own goroutine so a slow or dead endpoint does not hold up other drains.
+type drainQueue struct {
+	lock    sync.Mutex
+	lines   []string
+	removed bool
+	sending bool
+	status  models.DrainStatus
+}
+
+// Forward app logs to log drains
+func StartDrains() {
+	log := logger.New("ns=drains")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	queues := map[string]*drainQueue{}
+	cursors := map[string]*drainCursor{}
+
+	interval := drainInterval
+
+	for {
+		time.Sleep(interval)
+
+		if drainLogs(log, queues, cursors) {
+			interval *= 2
+
+			if interval > drainIntervalMax {
+				interval = drainIntervalMax
+			}
+
+			log.Log("throttled=true interval=%s", interval)
+		} else {
+			interval = drainInterval
+		}
+	}
+}
+
+// drainLogs reads new events for every app with a drain and queues them. It
+// returns true when log queries were throttled and the pass stopped early.
+func drainLogs(log *logger.Logger, queues map[string]*drainQueue, cursors map[string]*drainCursor) bool {
+	drains, err := models.ListDrains("")
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	if len(drains) == 0 {
+		return false
+	}
+
+	apps, err := drainApps(drains)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+
+	// forget apps that no longer have a drain
+	for app := range cursors {
+		found := false
+
+		for _, a := range apps {
+			if a == app {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			delete(cursors, app)
+		}
+	}
+
+	sort.Stable(drainOrder{apps: apps, cursors: cursors})
+
+	throttled := false
+
+	for _, app := range apps {
+		matching := models.Drains{}
+
+		for _, d := range drains {
+			if d.App == "" || d.App == app {
+				matching = append(matching, d)
+			}
+		}
+
+		c, ok := cursors[app]
+		if !ok {
+			c = &drainCursor{latest: time.Now().Add(-10 * time.Second), seen: map[string]time.Time{}}
+			cursors[app] = c
+		}
+
+		err := c.read(app, func(r structs.LogRecord) {
+			for _, d := range matching {
+				drainQueueFor(queues, d).push(d.Line(app, r))
+			}
+		})
+		if ae, ok := err.(awserr.Error); ok && ae.Code() == "ThrottlingException" {
+			// the remaining apps are read first on the next pass
+			throttled = true
+			break
+		}
+
+		c.polled = time.Now()
+
+		if err != nil {
+			log.Log("app=%s err=%q", app, err)
+		}
+	}
+
+	status := map[string]models.DrainStatus{}
+
+	for _, d := range drains {
+		q := drainQueueFor(queues, d)
+		q.start(log, d)
+		status[d.Id] = q.snapshot()
+	}
+
+	// forget drains that have been removed
+	for id, q := range queues {
+		if _, ok := status[id]; !ok {
+			q.remove()
+			delete(queues, id)
+		}
+	}
+
+	err = models.SaveDrainStatus(status)
+	if err != nil {
+		log.Error(err)
+	}
+
+	return throttled
+}
+
+// drainApps returns the apps whose logs are drained. Only a rack wide drain
+// needs every app, otherwise just the apps named by drains are read.
+func drainApps(drains models.Drains) ([]string, error) {
+	names := map[string]bool{}
+
+	for _, d := range drains {
+		if d.App == "" {
+			all, err := models.ListApps()
+			if err != nil {
+				return nil, err
+			}
+
+			for _, a := range all {
+				names[a.Name] = true
+			}
+
+			break
+		}
+
+		names[d.App] = true
+	}
+
+	apps := []string{}
+
+	for name := range names {
+		apps = append(apps, name)
+	}
+
+	sort.Strings(apps)
+
+	return apps, nil
+}
+
+// read calls fn for every event of an app not seen before. The query window
+// starts drainLag before the latest event seen and is paged forward when full.
+func (c *drainCursor) read(app string, fn func(structs.LogRecord)) error {
+	start := c.latest.Add(-drainLag)
+
+	for {
+		records, err := provider.LogQuery(app, structs.LogStreamOptions{Limit: drainPage, Start: start})
+		if err != nil {
+			return err
+		}
+
+		for _, r := range records {
+			if r.Timestamp.After(c.latest) {
+				c.latest = r.Timestamp
+			}
+
+			id := r.Id
+
+			// providers without event ids fall back to the content of the event
+			if id == "" {
+				id = fmt.Sprintf("%d/%s/%s", r.Timestamp.UnixNano(), r.Stream, r.Message)
+			}
+
+			if _, ok := c.seen[id]; ok {
+				continue
+			}
+
+			c.seen[id] = r.Timestamp
+
+			fn(r)
+		}
+
+		if len(records) < drainPage {
+			break
+		}
+
+		next := records[len(records)-1].Timestamp
+
+		if !next.After(start) {
+			next = start.Add(time.Millisecond)
+		}
+
+		start = next
+	}
+
+	// events older than the next window can not be returned again
+	for id, t := range c.seen {
+		if t.Before(c.latest.Add(-drainLag)) {
+			delete(c.seen, id)
+		}
+	}
+
+	return nil
+}
+
+// drainQueueFor returns the queue for a drain, carrying over counters saved before a restart
+func drainQueueFor(queues map[string]*drainQueue, d models.Drain) *drainQueue {
+	if _, ok := queues[d.Id]; !ok {
+		queues[d.Id] = &drainQueue{lines: []string{}, status: d.Status}
+	}
+
+	return queues[d.Id]
+}
+
+// push queues a line, dropping the oldest line when the drain is not keeping up
+func (q *drainQueue) push(line string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.lines) >= drainBuffer {
+		q.lines = q.lines[1:]
+		q.status.Dropped++
+	}
+
+	q.lines = append(q.lines, line)
+}
+
+// start delivers queued lines in the background unless a delivery is already running
+func (q *drainQueue) start(log *logger.Logger, d models.Drain) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.sending || len(q.lines) == 0 {
+		return
+	}
+
+	q.sending = true
+
+	go q.deliver(log, d)
+}
+
+// remove stops delivery after the batch in flight
+func (q *drainQueue) remove() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.removed = true
+}
+
+func (q *drainQueue) snapshot() models.DrainStatus {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.status.Queued = len(q.lines)
+	q.status.Updated = time.Now().UTC()
+
+	return q.status
+}
+
+// deliver sends queued lines in batches, retrying each batch with backoff.
+// Undelivered lines stay queued for the next pass.
+func (q *drainQueue) deliver(log *logger.Logger, d models.Drain) {
+	defer func() {
+		q.lock.Lock()
+		q.sending = false
+		q.lock.Unlock()
+	}()
+
+	for {
+		q.lock.Lock()
+
+		if q.removed || len(q.lines) == 0 {
+			q.lock.Unlock()
+			return
+		}
+
+		n := len(q.lines)
+
+		if n > drainBatch {
+			n = drainBatch
+		}
+
+		batch := make([]string, n)
+		copy(batch, q.lines[0:n])
+		dropped := q.status.Dropped
+
+		q.lock.Unlock()
+
+		var err error
+		retries := int64(0)
+
+		for attempt := 0; attempt < drainRetries; attempt++ {
+			if attempt > 0 {
+				retries++
+				time.Sleep(time.Duration(attempt) * time.Second)
+			}
+
+			if err = d.Send(batch); err == nil {
+				break
+			}
+		}
+
+		q.lock.Lock()
+
+		q.status.Retries += retries
+
+		if err != nil {
+			log.Log("drain=%s app=%q err=%q", d.Id, d.App, err)
+			q.status.Failed++
+			q.status.LastError = err.Error()
+			q.lock.Unlock()
+			return
+		}
+
+		// lines dropped from the front while sending were part of this batch
+		if n -= int(q.status.Dropped - dropped); n > 0 {
+			q.lines = q.lines[n:]
+		}
+
+		q.status.Delivered += int64(len(batch))
+		q.status.LastDelivered = time.Now().UTC()
+
+		q.lock.Unlock()
+	}
+}
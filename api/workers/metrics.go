@@ -0,0 +1,161 @@
+package workers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/convox/rack/api/provider"
+	"github.com/convox/rack/api/structs"
+	"github.com/ddollar/logger"
+)
+
+// metricContainer is what the previous pass saw of a container
+type metricContainer struct {
+	name      string
+	release   string
+	networkRx uint64
+	networkTx uint64
+}
+
+// Record cpu, memory, network and restarts for the processes of every app
+func StartMetrics() {
+	log := logger.New("ns=metrics")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	previous := map[string]map[string]metricContainer{}
+
+	for _ = range time.Tick(1 * time.Minute) {
+		recordMetrics(log, previous)
+	}
+}
+
+func recordMetrics(log *logger.Logger, previous map[string]map[string]metricContainer) {
+	apps, err := models.ListApps()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, a := range apps {
+		if a.Status != "running" {
+			continue
+		}
+
+		ps, err := models.ListProcesses(a.Name)
+		if err != nil {
+			log.Log("app=%s err=%q", a.Name, err)
+			continue
+		}
+
+		fetchProcessStats(ps)
+
+		samples, current := metricSamples(ps, previous[a.Name], time.Now().UTC())
+
+		previous[a.Name] = current
+
+		if len(samples) == 0 {
+			continue
+		}
+
+		err = provider.MetricsPut(a.Name, samples)
+		if err != nil {
+			log.Log("app=%s err=%q", a.Name, err)
+		}
+	}
+}
+
+func fetchProcessStats(ps []*models.Process) {
+	var wg sync.WaitGroup
+
+	for _, p := range ps {
+		if p.Id == "pending" {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(p *models.Process) {
+			defer wg.Done()
+			p.FetchStats()
+		}(p)
+	}
+
+	wg.Wait()
+}
+
+// metricSamples aggregates the containers of each process into a sample. Network traffic
+// is measured against the previous pass. A container that disappeared while others of the
+// same process and release are still running counts as a restart unless the process was
+// scaled down.
+func metricSamples(ps []*models.Process, previous map[string]metricContainer, now time.Time) (structs.MetricSamples, map[string]metricContainer) {
+	current := map[string]metricContainer{}
+	samples := map[string]*structs.MetricSample{}
+	releases := map[string]map[string]bool{}
+	names := []string{}
+
+	for _, p := range ps {
+		if p.Id == "pending" {
+			continue
+		}
+
+		s, ok := samples[p.Name]
+		if !ok {
+			s = &structs.MetricSample{Process: p.Name, Timestamp: now}
+			samples[p.Name] = s
+			releases[p.Name] = map[string]bool{}
+			names = append(names, p.Name)
+		}
+
+		rx, tx := p.Network()
+
+		s.Count++
+		s.Cpu += p.Cpu
+		s.Memory += p.Memory
+
+		if prev, ok := previous[p.Id]; ok && rx >= prev.networkRx && tx >= prev.networkTx {
+			s.NetworkRx += float64(rx - prev.networkRx)
+			s.NetworkTx += float64(tx - prev.networkTx)
+		}
+
+		releases[p.Name][p.Release] = true
+
+		current[p.Id] = metricContainer{name: p.Name, release: p.Release, networkRx: rx, networkTx: tx}
+	}
+
+	prevCount := map[string]float64{}
+	gone := map[string]float64{}
+
+	for id, c := range previous {
+		prevCount[c.name]++
+
+		if _, ok := current[id]; !ok && releases[c.name][c.release] {
+			gone[c.name]++
+		}
+	}
+
+	result := structs.MetricSamples{}
+
+	for _, name := range names {
+		s := samples[name]
+
+		if scaled := prevCount[name] - s.Count; scaled > 0 {
+			gone[name] -= scaled
+		}
+
+		if gone[name] > 0 {
+			s.Restarts = gone[name]
+		}
+
+		s.Cpu /= s.Count
+		s.Memory /= s.Count
+
+		result = append(result, *s)
+	}
+
+	return result, current
+}
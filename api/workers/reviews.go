@@ -0,0 +1,54 @@
+package workers
+
+import (
+	"time"
+
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/ddollar/logger"
+)
+
+// Delete review apps whose ttl has expired or whose branch is gone
+func StartReviews() {
+	log := logger.New("ns=reviews")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	for _ = range time.Tick(5 * time.Minute) {
+		expireReviews(log)
+	}
+}
+
+func expireReviews(log *logger.Logger) {
+	apps, err := models.ListApps()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, a := range apps {
+		// leave apps that are creating, updating or already deleting alone
+		if a.Review == nil || a.Status != "running" {
+			continue
+		}
+
+		reason, err := a.ReviewExpired()
+		if err != nil {
+			log.Log("app=%s err=%q", a.Name, err)
+			continue
+		}
+
+		if reason == "" {
+			continue
+		}
+
+		log.Log("app=%s branch=%s reason=%q", a.Name, a.Review.Branch, reason)
+
+		err = a.Delete()
+		if err != nil {
+			log.Error(err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"time"
+
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/ddollar/logger"
+)
+
+// Move service credential rotations along. Their state is kept in the settings
+// bucket so a rotation survives monitor restarts.
+func StartServiceRotations() {
+	log := logger.New("ns=rotations")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	for _ = range time.Tick(30 * time.Second) {
+		advanceRotations(log)
+	}
+}
+
+func advanceRotations(log *logger.Logger) {
+	rotations, err := models.ListServiceRotations()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for i := range rotations {
+		r := &rotations[i]
+
+		if err := models.AdvanceServiceRotation(r); err != nil {
+			log.Log("service=%s step=%s err=%q", r.Service, r.Step, err)
+			continue
+		}
+
+		log.Log("service=%s step=%s waiting=%t", r.Service, r.Step, r.Waiting)
+	}
+}
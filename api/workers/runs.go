@@ -0,0 +1,115 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/convox/rack/api/helpers"
+	"github.com/convox/rack/api/models"
+	"github.com/ddollar/logger"
+)
+
+// Record when detached runs exit and stop the ones that pass their timeout
+func StartRuns() {
+	log := logger.New("ns=runs")
+
+	defer recoverWith(func(err error) {
+		helpers.Error(log, err)
+	})
+
+	for _ = range time.Tick(1 * time.Minute) {
+		checkRuns(log)
+	}
+}
+
+func checkRuns(log *logger.Logger) {
+	apps, err := models.ListApps()
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	for _, a := range apps {
+		runs, err := models.ListRuns(a.Name)
+		if err != nil {
+			log.Log("app=%s err=%q", a.Name, err)
+			continue
+		}
+
+		running := map[string]models.Run{}
+		arns := []*string{}
+
+		for _, r := range runs {
+			if r.Status == "running" || (r.Status == "timeout" && r.Ended.IsZero()) {
+				running[r.Task] = r
+				arns = append(arns, aws.String(r.Task))
+			}
+		}
+
+		if len(arns) == 0 {
+			continue
+		}
+
+		// DescribeTasks takes at most 100 tasks, the rest are checked once these finish
+		if len(arns) > 100 {
+			arns = arns[0:100]
+		}
+
+		res, err := models.ECS().DescribeTasks(&ecs.DescribeTasksInput{
+			Cluster: aws.String(os.Getenv("CLUSTER")),
+			Tasks:   arns,
+		})
+		if err != nil {
+			log.Log("app=%s err=%q", a.Name, err)
+			continue
+		}
+
+		now := time.Now()
+
+		for _, task := range res.Tasks {
+			r := running[aws.StringValue(task.TaskArn)]
+
+			if r.Expired(now) {
+				_, err := models.ECS().StopTask(&ecs.StopTaskInput{
+					Cluster: aws.String(os.Getenv("CLUSTER")),
+					Reason:  aws.String(fmt.Sprintf("timed out after %s", time.Duration(r.Timeout)*time.Second)),
+					Task:    task.TaskArn,
+				})
+				if err != nil {
+					log.Log("app=%s run=%s err=%q", a.Name, r.Id, err)
+					continue
+				}
+
+				log.Log("app=%s run=%s timeout=%d", a.Name, r.Id, r.Timeout)
+
+				r.Status = "timeout"
+			}
+
+			r.Update(task)
+
+			if err := models.SaveRun(r); err != nil {
+				log.Log("app=%s run=%s err=%q", a.Name, r.Id, err)
+			}
+		}
+
+		// tasks that ECS no longer knows about can not report an exit code
+		for _, f := range res.Failures {
+			r := running[aws.StringValue(f.Arn)]
+
+			if r.Id == "" {
+				continue
+			}
+
+			r.Status = "failed"
+			r.Ended = now
+			r.Reason = aws.StringValue(f.Reason)
+
+			if err := models.SaveRun(r); err != nil {
+				log.Log("app=%s run=%s err=%q", a.Name, r.Id, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"fmt"
+	"time"
+)
+
+type Alert struct {
+	Id        string        `json:"id"`
+	App       string        `json:"app"`
+	Metric    string        `json:"metric"`
+	Process   string        `json:"process"`
+	Threshold float64       `json:"threshold"`
+	Window    time.Duration `json:"window"`
+	Created   time.Time     `json:"created"`
+
+	SilencedUntil time.Time `json:"silenced-until"`
+
+	State AlertState `json:"state"`
+}
+
+type Alerts []Alert
+
+type AlertState struct {
+	Status   string    `json:"status"`
+	Since    time.Time `json:"since"`
+	Message  string    `json:"message"`
+	Notified bool      `json:"notified"`
+	Updated  time.Time `json:"updated"`
+	Value    float64   `json:"value"`
+}
+
+func (c *Client) ListAlerts() (Alerts, error) {
+	var alerts Alerts
+
+	err := c.Get("/alerts", &alerts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// CreateAlert adds an alert rule. An alert without an app watches the rack.
+func (c *Client) CreateAlert(app, process, metric string, threshold float64, window time.Duration) (*Alert, error) {
+	params := Params{
+		"app":       app,
+		"metric":    metric,
+		"process":   process,
+		"threshold": fmt.Sprintf("%g", threshold),
+		"window":    window.String(),
+	}
+
+	var alert Alert
+
+	err := c.Post("/alerts", params, &alert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+func (c *Client) DeleteAlert(id string) (*Alert, error) {
+	var alert Alert
+
+	err := c.Delete(fmt.Sprintf("/alerts/%s", id), &alert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+// SilenceAlert suppresses notifications for an alert for a duration, or removes the silence if the duration is 0
+func (c *Client) SilenceAlert(id string, duration time.Duration) (*Alert, error) {
+	params := Params{
+		"duration": duration.String(),
+	}
+
+	var alert Alert
+
+	err := c.Post(fmt.Sprintf("/alerts/%s/silence", id), params, &alert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &alert, nil
+}
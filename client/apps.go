@@ -10,6 +10,14 @@ type App struct {
 	Name    string `json:"name"`
 	Release string `json:"release"`
 	Status  string `json:"status"`
+
+	Review *AppReview `json:"review,omitempty"`
+}
+
+type AppReview struct {
+	Branch  string     `json:"branch"`
+	Expires *time.Time `json:"expires,omitempty"`
+	Source  string     `json:"source"`
 }
 
 type Apps []App
@@ -42,6 +50,30 @@ func (c *Client) CreateApp(name string) (*App, error) {
 	return &app, nil
 }
 
+// CreateReviewApp creates an app tagged as a review of a branch of the source app.
+// The rack deletes it once ttl has passed (if non-zero) or the branch is gone.
+func (c *Client) CreateReviewApp(name, source, branch string, ttl time.Duration) (*App, error) {
+	params := Params{
+		"name":          name,
+		"review-branch": branch,
+		"review-source": source,
+	}
+
+	if ttl > 0 {
+		params["review-ttl"] = ttl.String()
+	}
+
+	var app App
+
+	err := c.Post("/apps", params, &app)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &app, nil
+}
+
 func (c *Client) GetApp(name string) (*App, error) {
 	var app App
 
@@ -66,10 +98,25 @@ func (c *Client) DeleteApp(name string) (*App, error) {
 	return &app, nil
 }
 
+// RenderAppTemplate renders the template the current release of an app would have
+// with a different manifest
+func (c *Client) RenderAppTemplate(app string, manifest []byte, diff bool) (*ReleaseTemplate, error) {
+	params := Params{
+		"diff":     fmt.Sprintf("%t", diff),
+		"manifest": string(manifest),
+	}
+
+	var t ReleaseTemplate
+
+	err := c.Post(fmt.Sprintf("/apps/%s/template", app), params, &t)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
 func (c *Client) StreamAppLogs(app, filter string, follow bool, since time.Duration, output io.WriteCloser) error {
-	return c.Stream(fmt.Sprintf("/apps/%s/logs", app), map[string]string{
-		"Filter": filter,
-		"Follow": fmt.Sprintf("%t", follow),
-		"Since":  since.String(),
-	}, nil, output)
+	return c.StreamAppLogsOptions(app, LogOptions{Filter: filter, Follow: follow, Since: since}, output)
 }
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -133,6 +135,70 @@ func (c *Client) CopyBuild(app, id, destApp string) (*Build, error) {
 	return &build, nil
 }
 
+func (c *Client) ExportBuild(app, id string, w io.Writer) error {
+	req, err := c.request("GET", fmt.Sprintf("/apps/%s/builds/%s/export", app, id), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client().Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if err := responseError(res); err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(w, hash), res.Body); err != nil {
+		return err
+	}
+
+	if sum := res.Header.Get("Checksum"); sum != "" && sum != hex.EncodeToString(hash.Sum(nil)) {
+		return fmt.Errorf("build export is incomplete: checksum mismatch")
+	}
+
+	return nil
+}
+
+func (c *Client) ImportBuild(app string, r io.Reader) (*Build, error) {
+	req, err := c.request("POST", fmt.Sprintf("/apps/%s/builds/import", app), r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/gzip")
+
+	res, err := c.client().Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if err := responseError(res); err != nil {
+		return nil, err
+	}
+
+	var build Build
+
+	err = json.NewDecoder(res.Body).Decode(&build)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &build, nil
+}
+
 func (c *Client) DeleteBuild(app, id string) (*Build, error) {
 	var build Build
 
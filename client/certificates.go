@@ -10,10 +10,25 @@ type Certificate struct {
 	Id         string    `json:"id"`
 	Domain     string    `json:"domain"`
 	Expiration time.Time `json:"expiration"`
+
+	Balancers CertificateBalancers `json:"balancers,omitempty"`
 }
 
 type Certificates []Certificate
 
+// CertificateBalancer is an app balancer port with a certificate
+type CertificateBalancer struct {
+	App     string `json:"app"`
+	Process string `json:"process"`
+	Port    int    `json:"port"`
+}
+
+type CertificateBalancers []CertificateBalancer
+
+func (b CertificateBalancer) String() string {
+	return fmt.Sprintf("%s/%s:%d", b.App, b.Process, b.Port)
+}
+
 func (c *Client) CreateCertificate(pub, key, chain string) (*Certificate, error) {
 	var cert Certificate
 
@@ -52,6 +67,23 @@ func (c *Client) GenerateCertificate(domains []string) (*Certificate, error) {
 	return &cert, nil
 }
 
+// ReplaceCertificate points every balancer port using a certificate at another one
+func (c *Client) ReplaceCertificate(old, id string) (CertificateBalancers, error) {
+	var balancers CertificateBalancers
+
+	params := Params{
+		"certificate": id,
+	}
+
+	err := c.Post(fmt.Sprintf("/certificates/%s/replace", old), params, &balancers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return balancers, nil
+}
+
 func (c *Client) ListCertificates() (Certificates, error) {
 	var certs Certificates
 
@@ -63,3 +95,79 @@ func (c *Client) ListCertificates() (Certificates, error) {
 
 	return certs, nil
 }
+
+// AcmeCertificate is a certificate the rack issues and renews through Let's Encrypt
+type AcmeCertificate struct {
+	Id          string    `json:"id"`
+	Domains     []string  `json:"domains"`
+	Email       string    `json:"email"`
+	Directory   string    `json:"directory"`
+	Challenge   string    `json:"challenge"`
+	Dns         string    `json:"dns,omitempty"`
+	Certificate string    `json:"certificate"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	Expiration  time.Time `json:"expiration"`
+	Created     time.Time `json:"created"`
+	Updated     time.Time `json:"updated"`
+	Replaced    []string  `json:"replaced,omitempty"`
+}
+
+type AcmeCertificates []AcmeCertificate
+
+// AcmeOptions are the options for a certificate issued through ACME
+type AcmeOptions struct {
+	Email     string
+	Challenge string
+	Dns       string
+	DnsConfig []string
+	Staging   bool
+}
+
+func (c *Client) GenerateAcmeCertificate(domains []string, opts AcmeOptions) (*AcmeCertificate, error) {
+	var cert AcmeCertificate
+
+	params := Params{
+		"domains":    strings.Join(domains, ","),
+		"email":      opts.Email,
+		"challenge":  opts.Challenge,
+		"dns":        opts.Dns,
+		"dns-config": strings.Join(opts.DnsConfig, "\n"),
+	}
+
+	if opts.Staging {
+		params["staging"] = "true"
+	}
+
+	err := c.Post("/certificates/acme", params, &cert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+func (c *Client) DeleteAcmeCertificate(id string) (*AcmeCertificate, error) {
+	var cert AcmeCertificate
+
+	err := c.Delete(fmt.Sprintf("/certificates/acme/%s", id), &cert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+func (c *Client) ListAcmeCertificates() (AcmeCertificates, error) {
+	var certs AcmeCertificates
+
+	err := c.Get("/certificates/acme", &certs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return certs, nil
+}
@@ -92,6 +92,23 @@ func (c *Client) PostBody(path string, body io.Reader, out interface{}) error {
 }
 
 func (c *Client) PostBodyResponse(path string, body io.Reader, out interface{}) (*http.Response, error) {
+	return c.postBodyResponse(path, body, nil, out)
+}
+
+// postHeaders is Post with extra request headers
+func (c *Client) postHeaders(path string, params Params, headers map[string]string, out interface{}) error {
+	form := url.Values{}
+
+	for k, v := range params {
+		form.Set(k, v)
+	}
+
+	_, err := c.postBodyResponse(path, strings.NewReader(form.Encode()), headers, out)
+
+	return err
+}
+
+func (c *Client) postBodyResponse(path string, body io.Reader, headers map[string]string, out interface{}) (*http.Response, error) {
 	req, err := c.request("POST", path, body)
 
 	if err != nil {
@@ -100,6 +117,10 @@ func (c *Client) PostBodyResponse(path string, body io.Reader, out interface{})
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
 	res, err := c.client().Do(req)
 
 	if err != nil {
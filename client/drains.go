@@ -0,0 +1,80 @@
+package client
+
+import (
+	"fmt"
+	"time"
+)
+
+type Drain struct {
+	Id      string    `json:"id"`
+	App     string    `json:"app"`
+	Url     string    `json:"url"`
+	Format  string    `json:"format"`
+	Created time.Time `json:"created"`
+
+	Status DrainStatus `json:"status"`
+}
+
+type Drains []Drain
+
+type DrainStatus struct {
+	Delivered     int64     `json:"delivered"`
+	Dropped       int64     `json:"dropped"`
+	Failed        int64     `json:"failed"`
+	Queued        int       `json:"queued"`
+	Retries       int64     `json:"retries"`
+	LastDelivered time.Time `json:"last-delivered"`
+	LastError     string    `json:"last-error"`
+	Updated       time.Time `json:"updated"`
+}
+
+// ListDrains returns the drains for an app including rack-wide drains, or every drain if app is empty
+func (c *Client) ListDrains(app string) (Drains, error) {
+	var drains Drains
+
+	err := c.Get(drainsPath(app), &drains)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return drains, nil
+}
+
+// CreateDrain adds a drain for an app, or for every app if app is empty
+func (c *Client) CreateDrain(app, url, format string) (*Drain, error) {
+	params := Params{
+		"format": format,
+		"url":    url,
+	}
+
+	var drain Drain
+
+	err := c.Post(drainsPath(app), params, &drain)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &drain, nil
+}
+
+func (c *Client) DeleteDrain(app, id string) (*Drain, error) {
+	var drain Drain
+
+	err := c.Delete(fmt.Sprintf("%s/%s", drainsPath(app), id), &drain)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &drain, nil
+}
+
+func drainsPath(app string) string {
+	if app == "" {
+		return "/drains"
+	}
+
+	return fmt.Sprintf("/apps/%s/drains", app)
+}
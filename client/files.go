@@ -0,0 +1,60 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// CopyFromProcess writes a tar archive of a file or directory in a process to w
+func (c *Client) CopyFromProcess(app, pid, path string, w io.Writer) error {
+	r, pw := io.Pipe()
+
+	defer r.Close()
+
+	go func() {
+		pw.CloseWithError(c.Stream(fmt.Sprintf("/apps/%s/processes/%s/files", app, pid), map[string]string{"Path": path}, nil, pw))
+	}()
+
+	br := bufio.NewReader(r)
+
+	// the server reports failures as text in place of an archive
+	if head, _ := br.Peek(7); string(head) == "ERROR: " {
+		msg, _ := ioutil.ReadAll(br)
+		return fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(string(msg), "ERROR: ")))
+	}
+
+	_, err := io.Copy(w, br)
+
+	return err
+}
+
+// CopyToProcess extracts a tar archive read from archive to a path in a process. If the
+// path is an existing directory the archive is extracted inside it.
+func (c *Client) CopyToProcess(app, pid, path string, archive io.Reader) error {
+	r, w := io.Pipe()
+
+	defer r.Close()
+
+	go func() {
+		w.CloseWithError(c.Stream(fmt.Sprintf("/apps/%s/processes/%s/files/upload", app, pid), map[string]string{"Path": path}, archive, w))
+	}()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	out := strings.TrimSpace(string(data))
+
+	switch {
+	case strings.HasPrefix(out, StatusCodePrefix):
+		return nil
+	case strings.HasPrefix(out, "ERROR: "):
+		return fmt.Errorf("%s", strings.TrimPrefix(out, "ERROR: "))
+	}
+
+	return fmt.Errorf("copy did not complete")
+}
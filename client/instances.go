@@ -59,6 +59,7 @@ func (c *Client) SSHInstance(id, cmd string, height, width int, isTerm bool, in
 
 	headers := map[string]string{
 		"Command": cmd,
+		"User":    sessionUser(),
 	}
 
 	if isTerm {
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type LogOptions struct {
+	End      time.Time
+	Filter   string
+	Follow   bool
+	Instance string
+	Limit    int
+	Process  string
+	Release  string
+	Since    time.Duration
+	Start    time.Time
+}
+
+type LogRecord struct {
+	Container string    `json:"container,omitempty"`
+	Instance  string    `json:"instance,omitempty"`
+	Message   string    `json:"message"`
+	Process   string    `json:"process,omitempty"`
+	Release   string    `json:"release,omitempty"`
+	Stream    string    `json:"stream"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type LogRecords []LogRecord
+
+func (c *Client) StreamAppLogsOptions(app string, opts LogOptions, output io.WriteCloser) error {
+	headers := opts.values()
+	headers["Follow"] = fmt.Sprintf("%t", opts.Follow)
+
+	return c.Stream(fmt.Sprintf("/apps/%s/logs", app), headers, nil, output)
+}
+
+// StreamSystemLogs streams the logs of the rack itself
+func (c *Client) StreamSystemLogs(opts LogOptions, output io.WriteCloser) error {
+	headers := opts.values()
+	headers["Follow"] = fmt.Sprintf("%t", opts.Follow)
+
+	return c.Stream("/system/logs", headers, nil, output)
+}
+
+// QueryAppLogs returns the matching log records without following
+func (c *Client) QueryAppLogs(app string, opts LogOptions) (LogRecords, error) {
+	query := url.Values{}
+
+	for key, value := range opts.values() {
+		query.Set(strings.ToLower(key), value)
+	}
+
+	var records LogRecords
+
+	err := c.Get(fmt.Sprintf("/apps/%s/logs/query?%s", app, query.Encode()), &records)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// values returns the options that are set keyed by their websocket header names
+func (opts LogOptions) values() map[string]string {
+	values := map[string]string{}
+
+	set := func(key, value string) {
+		if value != "" {
+			values[key] = value
+		}
+	}
+
+	set("Filter", opts.Filter)
+	set("Instance", opts.Instance)
+	set("Process", opts.Process)
+	set("Release", opts.Release)
+
+	if opts.Since > 0 {
+		values["Since"] = opts.Since.String()
+	}
+
+	if !opts.Start.IsZero() {
+		values["Start"] = opts.Start.UTC().Format(time.RFC3339Nano)
+	}
+
+	if !opts.End.IsZero() {
+		values["End"] = opts.End.UTC().Format(time.RFC3339Nano)
+	}
+
+	if opts.Limit > 0 {
+		values["Limit"] = strconv.Itoa(opts.Limit)
+	}
+
+	return values
+}
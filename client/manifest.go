@@ -0,0 +1,30 @@
+package client
+
+type ManifestProblem struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Level   string `json:"level"`
+	Process string `json:"process,omitempty"`
+	Message string `json:"message"`
+}
+
+type ManifestProblems []ManifestProblem
+
+// ValidateManifest checks a manifest on the rack. Environment references are
+// checked against app unless it is empty.
+func (c *Client) ValidateManifest(manifest []byte, app string) (ManifestProblems, error) {
+	params := Params{
+		"app":      app,
+		"manifest": string(manifest),
+	}
+
+	var problems ManifestProblems
+
+	err := c.Post("/manifest/validate", params, &problems)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return problems, nil
+}
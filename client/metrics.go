@@ -0,0 +1,58 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type MetricsOptions struct {
+	End     time.Time
+	Process string
+	Start   time.Time
+	Step    time.Duration
+}
+
+type MetricSample struct {
+	Process   string    `json:"process"`
+	Timestamp time.Time `json:"timestamp"`
+	Count     float64   `json:"count"`
+	Cpu       float64   `json:"cpu"`
+	Memory    float64   `json:"memory"`
+	NetworkRx float64   `json:"network-rx"`
+	NetworkTx float64   `json:"network-tx"`
+	Restarts  float64   `json:"restarts"`
+}
+
+type MetricSamples []MetricSample
+
+// GetAppMetrics returns process metrics for an app
+func (c *Client) GetAppMetrics(app string, opts MetricsOptions) (MetricSamples, error) {
+	query := url.Values{}
+
+	if opts.Process != "" {
+		query.Set("process", opts.Process)
+	}
+
+	if !opts.Start.IsZero() {
+		query.Set("from", opts.Start.UTC().Format(time.RFC3339))
+	}
+
+	if !opts.End.IsZero() {
+		query.Set("to", opts.End.UTC().Format(time.RFC3339))
+	}
+
+	if opts.Step > 0 {
+		query.Set("step", opts.Step.String())
+	}
+
+	var samples MetricSamples
+
+	err := c.Get(fmt.Sprintf("/apps/%s/metrics?%s", app, query.Encode()), &samples)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return samples, nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -28,6 +29,25 @@ type Process struct {
 
 type Processes []Process
 
+type StoppedProcess struct {
+	Id              string    `json:"id"`
+	App             string    `json:"app"`
+	Name            string    `json:"name"`
+	Release         string    `json:"release"`
+	Task            string    `json:"task"`
+	Crashed         bool      `json:"crashed"`
+	ExitCode        *int64    `json:"exit-code"`
+	OOMKilled       bool      `json:"oom-killed"`
+	Reason          string    `json:"reason"`
+	ContainerReason string    `json:"container-reason"`
+	Description     string    `json:"description"`
+	Started         time.Time `json:"started"`
+	Stopped         time.Time `json:"stopped"`
+	Logs            []string  `json:"logs"`
+}
+
+type StoppedProcesses []StoppedProcess
+
 func (c *Client) GetProcesses(app string, stats bool) (Processes, error) {
 	var processes Processes
 
@@ -40,6 +60,19 @@ func (c *Client) GetProcesses(app string, stats bool) (Processes, error) {
 	return processes, nil
 }
 
+// GetStoppedProcesses returns the processes of an app that exited or were stopped, newest first
+func (c *Client) GetStoppedProcesses(app string) (StoppedProcesses, error) {
+	var processes StoppedProcesses
+
+	err := c.Get(fmt.Sprintf("/apps/%s/processes?stopped=true", app), &processes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return processes, nil
+}
+
 func (c *Client) GetProcess(app, id string) (*Process, error) {
 	var process Process
 
@@ -65,6 +98,7 @@ func (c *Client) ExecProcessAttached(app, pid, command string, in io.Reader, out
 	headers := map[string]string{
 		"Command": command,
 		"Height":  strconv.Itoa(height),
+		"User":    sessionUser(),
 		"Width":   strconv.Itoa(width),
 	}
 
@@ -79,7 +113,36 @@ func (c *Client) ExecProcessAttached(app, pid, command string, in io.Reader, out
 	return code, nil
 }
 
-func (c *Client) RunProcessAttached(app, process, command, release string, height, width int, in io.Reader, out io.WriteCloser) (int, error) {
+// RunOptions size and limit a one-off process. Zero values use the settings of the
+// process and no timeout.
+type RunOptions struct {
+	Cpu     int
+	Memory  int
+	Timeout time.Duration
+}
+
+// Run is a record of a detached one-off process
+type Run struct {
+	Id          string    `json:"id"`
+	App         string    `json:"app"`
+	Process     string    `json:"process"`
+	Command     string    `json:"command"`
+	Release     string    `json:"release"`
+	ClaimedUser string    `json:"claimed-user"`
+	Cpu         int       `json:"cpu"`
+	Memory      int       `json:"memory"`
+	Timeout     int       `json:"timeout"`
+	Status      string    `json:"status"`
+	Started     time.Time `json:"started"`
+	Ended       time.Time `json:"ended"`
+	ExitCode    *int      `json:"exit-code"`
+	Reason      string    `json:"reason"`
+	Logs        string    `json:"logs"`
+}
+
+type Runs []Run
+
+func (c *Client) RunProcessAttached(app, process, command, release string, height, width int, opts RunOptions, in io.Reader, out io.WriteCloser) (int, error) {
 	r, w := io.Pipe()
 
 	defer r.Close()
@@ -93,9 +156,14 @@ func (c *Client) RunProcessAttached(app, process, command, release string, heigh
 		"Command": command,
 		"Release": release,
 		"Height":  strconv.Itoa(height),
+		"User":    sessionUser(),
 		"Width":   strconv.Itoa(width),
 	}
 
+	for k, v := range opts.params() {
+		headers[strings.Title(k)] = v
+	}
+
 	err := c.Stream(fmt.Sprintf("/apps/%s/processes/%s/run", app, process), headers, in, w)
 
 	if err != nil {
@@ -107,22 +175,58 @@ func (c *Client) RunProcessAttached(app, process, command, release string, heigh
 	return code, nil
 }
 
-func (c *Client) RunProcessDetached(app, process, command, release string) error {
-	var success interface{}
+func (c *Client) RunProcessDetached(app, process, command, release string, opts RunOptions) (*Run, error) {
+	var run Run
 
-	params := map[string]string{
-		"command": command,
-		"release": release,
+	params := opts.params()
+
+	params["command"] = command
+	params["release"] = release
+
+	headers := map[string]string{
+		"User": sessionUser(),
 	}
 
-	err := c.Post(fmt.Sprintf("/apps/%s/processes/%s/run", app, process), params, &success)
+	err := c.postHeaders(fmt.Sprintf("/apps/%s/processes/%s/run", app, process), params, headers, &run)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return &run, nil
 }
+
+// GetRuns returns the detached runs of an app, newest first
+func (c *Client) GetRuns(app string) (Runs, error) {
+	var runs Runs
+
+	err := c.Get(fmt.Sprintf("/apps/%s/runs", app), &runs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (opts RunOptions) params() map[string]string {
+	params := map[string]string{}
+
+	if opts.Cpu > 0 {
+		params["cpu"] = strconv.Itoa(opts.Cpu)
+	}
+
+	if opts.Memory > 0 {
+		params["memory"] = strconv.Itoa(opts.Memory)
+	}
+
+	if opts.Timeout > 0 {
+		params["timeout"] = opts.Timeout.String()
+	}
+
+	return params
+}
+
 func (c *Client) StopProcess(app, id string) (*Process, error) {
 	var process Process
 
@@ -135,37 +239,69 @@ func (c *Client) StopProcess(app, id string) (*Process, error) {
 	return &process, nil
 }
 
+// copyWithExit copies r to w until the exit code trailer written by the server and sends
+// the exit code on ch. The trailer may arrive in the same read as output or split across
+// reads. If the stream ends without a trailer the exit code is 1.
 func copyWithExit(w io.Writer, r io.Reader, ch chan int) {
 	buf := make([]byte, 1024)
+	prefix := []byte(StatusCodePrefix)
+	pending := []byte{}
 	isTerminalRaw := false
 
 	for {
 		n, err := r.Read(buf)
 
-		if err == io.EOF {
-			ch <- 1
-			return
-		}
+		if n > 0 {
+			if !isTerminalRaw {
+				terminal.MakeRaw(int(os.Stdin.Fd()))
+				isTerminalRaw = true
+			}
 
-		if err != nil {
-			break
+			pending = append(pending, buf[0:n]...)
 		}
 
-		if !isTerminalRaw {
-			terminal.MakeRaw(int(os.Stdin.Fd()))
-			isTerminalRaw = true
+		if i := bytes.Index(pending, prefix); i >= 0 {
+			rest := pending[i+len(prefix):]
+
+			// wait for the rest of the code unless the stream has ended
+			if bytes.IndexByte(rest, '\n') >= 0 || err != nil {
+				w.Write(pending[0:i])
+
+				code, cerr := strconv.Atoi(strings.TrimSpace(strings.SplitN(string(rest), "\n", 2)[0]))
+				if cerr != nil {
+					code = 1
+				}
+
+				ch <- code
+				return
+			}
+		} else {
+			// hold back anything that could be the start of the trailer
+			keep := partialPrefix(pending, prefix)
+
+			if _, werr := w.Write(pending[0 : len(pending)-keep]); werr != nil {
+				ch <- 1
+				return
+			}
+
+			pending = append([]byte{}, pending[len(pending)-keep:]...)
 		}
 
-		if s := string(buf[0:n]); strings.HasPrefix(s, StatusCodePrefix) {
-			code, _ := strconv.Atoi(strings.TrimSpace(s[37:]))
-			ch <- code
+		if err != nil {
+			w.Write(pending)
+			ch <- 1
 			return
 		}
+	}
+}
 
-		_, err = w.Write(buf[0:n])
-
-		if err != nil {
-			break
+// partialPrefix returns the length of the longest end of data that is the start of prefix
+func partialPrefix(data, prefix []byte) int {
+	for n := len(prefix) - 1; n > 0; n-- {
+		if len(data) >= n && bytes.Equal(data[len(data)-n:], prefix[0:n]) {
+			return n
 		}
 	}
+
+	return 0
 }
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chunkReader returns one chunk per read to simulate websocket frames
+type chunkReader struct {
+	chunks []string
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.chunks[0])
+	r.chunks = r.chunks[1:]
+
+	return n, nil
+}
+
+func TestCopyWithExit(t *testing.T) {
+	tests := []struct {
+		chunks []string
+		output string
+		code   int
+	}{
+		{[]string{"hello\n", StatusCodePrefix + "3\n"}, "hello\n", 3},
+		{[]string{"hello\n" + StatusCodePrefix + "4\n"}, "hello\n", 4},
+		{[]string{"hello\n" + StatusCodePrefix[0:10], StatusCodePrefix[10:] + "5", "\n"}, "hello\n", 5},
+		{[]string{"hello\n"}, "hello\n", 1},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+
+		ch := make(chan int, 1)
+
+		copyWithExit(&out, &chunkReader{chunks: tt.chunks}, ch)
+
+		assert.Equal(t, tt.code, <-ch)
+		assert.Equal(t, tt.output, out.String())
+	}
+}
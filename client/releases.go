@@ -17,6 +17,44 @@ type Release struct {
 
 type Releases []Release
 
+// ReleaseChange is one difference between two releases
+type ReleaseChange struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+type ReleaseChanges []ReleaseChange
+
+// ProcessChange is the difference in the manifest for one process
+type ProcessChange struct {
+	Process string         `json:"process"`
+	Action  string         `json:"action"`
+	Changes ReleaseChanges `json:"changes"`
+}
+
+// ReleaseDiff is what changes when moving from one release to another
+type ReleaseDiff struct {
+	App        string          `json:"app"`
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	Build      *ReleaseChange  `json:"build"`
+	Env        ReleaseChanges  `json:"env"`
+	Processes  []ProcessChange `json:"processes"`
+	Parameters ReleaseChanges  `json:"parameters"`
+}
+
+// ReleaseTemplate is the stack template and parameters a promote sends to CloudFormation
+type ReleaseTemplate struct {
+	App              string            `json:"app"`
+	Release          string            `json:"release"`
+	Template         string            `json:"template"`
+	Parameters       map[string]string `json:"parameters"`
+	TemplateDiff     string            `json:"template-diff"`
+	ParameterChanges ReleaseChanges    `json:"parameter-changes"`
+}
+
 func (c *Client) GetReleases(app string) (Releases, error) {
 	var releases Releases
 
@@ -41,6 +79,33 @@ func (c *Client) GetRelease(app, id string) (*Release, error) {
 	return &release, nil
 }
 
+// DiffReleases compares two releases of an app
+func (c *Client) DiffReleases(app, from, to string) (*ReleaseDiff, error) {
+	var diff ReleaseDiff
+
+	err := c.Get(fmt.Sprintf("/apps/%s/releases/%s/diff/%s", app, from, to), &diff)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &diff, nil
+}
+
+// GetReleaseTemplate renders the template a promote of a release would send. With
+// diff it is compared against the stack as it runs now.
+func (c *Client) GetReleaseTemplate(app, id string, diff bool) (*ReleaseTemplate, error) {
+	var t ReleaseTemplate
+
+	err := c.Get(fmt.Sprintf("/apps/%s/releases/%s/template?diff=%t", app, id, diff), &t)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
 func (c *Client) PromoteRelease(app, id string) (*Release, error) {
 	var release Release
 
@@ -1,12 +1,16 @@
 package client
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Service struct {
 	Name         string            `json:"name"`
 	Status       string            `json:"status"`
 	StatusReason string            `json:"status-reason"`
 	Type         string            `json:"type"`
+	Apps         Apps              `json:"apps"`
 	Exports      map[string]string `json:"exports"`
 	// DEPRECATED: should inject any data in Exports
 	// we only set this on the outgoing response for old clients
@@ -81,3 +85,51 @@ func (c *Client) UpdateService(name string, options map[string]string) (*Service
 
 	return &service, nil
 }
+
+// ServiceRotation is the progress of a credential rotation
+type ServiceRotation struct {
+	Service  string            `json:"service"`
+	Step     string            `json:"step"`
+	Waiting  bool              `json:"waiting"`
+	User     string            `json:"user"`
+	Previous string            `json:"previous"`
+	Active   string            `json:"active"`
+	Apps     []string          `json:"apps"`
+	Releases map[string]string `json:"releases,omitempty"`
+	Grace    int64             `json:"grace"`
+	Until    time.Time         `json:"until,omitempty"`
+	Task     string            `json:"task,omitempty"`
+	Error    string            `json:"error,omitempty"`
+	Started  time.Time         `json:"started"`
+	Updated  time.Time         `json:"updated"`
+}
+
+func (c *Client) RotateService(name string, grace time.Duration) (*ServiceRotation, error) {
+	var rotation ServiceRotation
+
+	params := Params{}
+
+	if grace > 0 {
+		params["grace"] = grace.String()
+	}
+
+	err := c.Post(fmt.Sprintf("/services/%s/rotate", name), params, &rotation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rotation, nil
+}
+
+func (c *Client) GetServiceRotation(name string) (*ServiceRotation, error) {
+	var rotation ServiceRotation
+
+	err := c.Get(fmt.Sprintf("/services/%s/rotation", name), &rotation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rotation, nil
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+type Session struct {
+	Id          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	App         string    `json:"app"`
+	Target      string    `json:"target"`
+	Command     string    `json:"command"`
+	ClaimedUser string    `json:"claimed-user"`
+	Remote      string    `json:"remote"`
+	Height      int       `json:"height"`
+	Width       int       `json:"width"`
+	Started     time.Time `json:"started"`
+	Ended       time.Time `json:"ended"`
+	ExitCode    *int      `json:"exit-code"`
+	Chunks      int       `json:"chunks"`
+}
+
+type Sessions []Session
+
+func (c *Client) ListSessions() (Sessions, error) {
+	var sessions Sessions
+
+	err := c.Get("/sessions", &sessions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// GetSessionRecording returns true if the rack records exec, run and ssh sessions
+func (c *Client) GetSessionRecording() (bool, error) {
+	var res struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	err := c.Get("/sessions/recording", &res)
+
+	if err != nil {
+		return false, err
+	}
+
+	return res.Enabled, nil
+}
+
+func (c *Client) SetSessionRecording(enabled bool) error {
+	params := Params{
+		"enabled": fmt.Sprintf("%t", enabled),
+	}
+
+	var res struct {
+		Enabled bool `json:"enabled"`
+	}
+
+	return c.Post("/sessions/recording", params, &res)
+}
+
+// ExportSession writes a session recording as an asciicast v2 file
+func (c *Client) ExportSession(id string, w io.Writer) error {
+	req, err := c.request("GET", fmt.Sprintf("/sessions/%s/cast", id), nil)
+
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client().Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if err := responseError(res); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, res.Body)
+
+	return err
+}
+
+// WatchSession streams the output of a live session until it ends
+func (c *Client) WatchSession(id string, output io.WriteCloser) error {
+	return c.Stream(fmt.Sprintf("/sessions/%s/watch", id), map[string]string{}, nil, output)
+}
+
+// sessionUser names the caller in recorded sessions and detached runs. It is sent only
+// with exec, run and ssh streams and detached runs, and the rack records it as a claim
+// because it is not authenticated.
+func sessionUser() string {
+	if u := os.Getenv("CONVOX_USER"); u != "" {
+		return u
+	}
+
+	user := os.Getenv("USER")
+
+	if user == "" {
+		user = os.Getenv("USERNAME")
+	}
+
+	host, _ := os.Hostname()
+
+	return fmt.Sprintf("%s@%s", user, host)
+}
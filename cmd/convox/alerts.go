@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/convox/rack/cmd/convox/stdcli"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func init() {
+	stdcli.RegisterCommand(cli.Command{
+		Name:        "alerts",
+		Description: "manage alert rules for apps and the rack",
+		Usage:       "",
+		Action:      cmdAlerts,
+		Subcommands: []cli.Command{
+			{
+				Name:        "create",
+				Description: "create an alert rule: cpu, memory, restarts, deployment or instances",
+				Usage:       "<metric> [--app <app>] [--process <process>] [--threshold <n>] [--window <duration>]",
+				Action:      cmdAlertCreate,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "app, a",
+						Usage: "app to watch, omit to watch the rack",
+					},
+					cli.StringFlag{
+						Name:  "process",
+						Usage: "process to watch, omit to watch every process of the app",
+					},
+					cli.Float64Flag{
+						Name:  "threshold",
+						Usage: "value that triggers the alert, a percentage for cpu and memory",
+					},
+					cli.DurationFlag{
+						Name:  "window",
+						Value: 5 * time.Minute,
+						Usage: "period to evaluate the metric over, or how long a deployment may take",
+					},
+				},
+			},
+			{
+				Name:        "delete",
+				Description: "delete an alert rule",
+				Usage:       "<id>",
+				Action:      cmdAlertDelete,
+			},
+			{
+				Name:        "silence",
+				Description: "stop notifications for an alert",
+				Usage:       "<id>",
+				Action:      cmdAlertSilence,
+				Flags: []cli.Flag{
+					cli.DurationFlag{
+						Name:  "for",
+						Value: 1 * time.Hour,
+						Usage: "how long to silence the alert",
+					},
+				},
+			},
+			{
+				Name:        "unsilence",
+				Description: "resume notifications for an alert",
+				Usage:       "<id>",
+				Action:      cmdAlertUnsilence,
+			},
+		},
+	})
+}
+
+func cmdAlerts(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox alerts` does not take arguments. Perhaps you meant `convox alerts create`?"))
+	}
+
+	alerts, err := rackClient(c).ListAlerts()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t := stdcli.NewTable("ID", "APP", "PROCESS", "METRIC", "THRESHOLD", "WINDOW", "STATUS", "SILENCED", "MESSAGE")
+
+	for _, a := range alerts {
+		silenced := ""
+
+		if time.Now().Before(a.SilencedUntil) {
+			silenced = fmt.Sprintf("until %s", a.SilencedUntil.Local().Format("2006-01-02 15:04"))
+		}
+
+		t.AddRow(a.Id, stdcli.Default(a.App, "(rack)"), stdcli.Default(a.Process, "(all)"), a.Metric,
+			fmt.Sprintf("%g", a.Threshold), a.Window.String(), a.State.Status, silenced, a.State.Message)
+	}
+
+	t.Print()
+	return nil
+}
+
+func cmdAlertCreate(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "create")
+		return nil
+	}
+
+	fmt.Print("Creating alert... ")
+
+	a, err := rackClient(c).CreateAlert(c.String("app"), c.String("process"), c.Args()[0], c.Float64("threshold"), c.Duration("window"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println(a.Id)
+	return nil
+}
+
+func cmdAlertDelete(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "delete")
+		return nil
+	}
+
+	fmt.Printf("Deleting alert %s... ", c.Args()[0])
+
+	_, err := rackClient(c).DeleteAlert(c.Args()[0])
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+	return nil
+}
+
+func cmdAlertSilence(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "silence")
+		return nil
+	}
+
+	if c.Duration("for") <= 0 {
+		return stdcli.ExitError(fmt.Errorf("--for must be a positive duration"))
+	}
+
+	fmt.Printf("Silencing alert %s for %s... ", c.Args()[0], c.Duration("for"))
+
+	_, err := rackClient(c).SilenceAlert(c.Args()[0], c.Duration("for"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+	return nil
+}
+
+func cmdAlertUnsilence(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "unsilence")
+		return nil
+	}
+
+	fmt.Printf("Unsilencing alert %s... ", c.Args()[0])
+
+	_, err := rackClient(c).SilenceAlert(c.Args()[0], 0)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestAlerts(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/alerts", Code: 200, Response: client.Alerts{
+			client.Alert{Id: "AABCDEFGHIJ", App: "myapp", Process: "web", Metric: "restarts", Threshold: 3, Window: 5 * time.Minute, State: client.AlertState{Status: "firing", Message: "restarts of myapp/web is 4 in 5m0s, threshold 3"}},
+			client.Alert{Id: "AKLMNOPQRST", Metric: "instances", Threshold: 10, Window: 5 * time.Minute, State: client.AlertState{Status: "ok"}},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox alerts",
+			Exit:    0,
+			Stdout:  "ID           APP     PROCESS  METRIC     THRESHOLD  WINDOW  STATUS  SILENCED  MESSAGE                                        \nAABCDEFGHIJ  myapp   web      restarts   3          5m0s    firing            restarts of myapp/web is 4 in 5m0s, threshold 3\nAKLMNOPQRST  (rack)  (all)    instances  10         5m0s    ok                                                               \n",
+		},
+	)
+}
+
+func TestAlertsCreate(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/alerts", Body: "app=myapp&metric=memory&process=web&threshold=90&window=10m0s", Code: 200, Response: client.Alert{Id: "AABCDEFGHIJ"}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox alerts create memory --app myapp --process web --threshold 90 --window 10m",
+			Exit:    0,
+			Stdout:  "Creating alert... AABCDEFGHIJ\n",
+		},
+	)
+}
+
+func TestAlertsSilence(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/alerts/AABCDEFGHIJ/silence", Body: "duration=2h0m0s", Code: 200, Response: client.Alert{Id: "AABCDEFGHIJ"}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox alerts silence AABCDEFGHIJ --for 2h",
+			Exit:    0,
+			Stdout:  "Silencing alert AABCDEFGHIJ for 2h0m0s... OK\n",
+		},
+	)
+}
@@ -2,24 +2,86 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/convox/rack/api/manifest"
+	"github.com/convox/rack/client"
 	"github.com/convox/rack/cmd/convox/stdcli"
 	"gopkg.in/urfave/cli.v1"
+	"gopkg.in/yaml.v2"
 )
 
+// AppTemplate is the declarative configuration used by `apps create --from-template` and `apps clone`
+type AppTemplate struct {
+	Build       string                        `yaml:"build,omitempty"`
+	Environment map[string]string             `yaml:"environment,omitempty"`
+	Formation   map[string]AppTemplateProcess `yaml:"formation,omitempty"`
+	Links       []string                      `yaml:"links,omitempty"`
+	Parameters  map[string]string             `yaml:"parameters,omitempty"`
+}
+
+type AppTemplateProcess struct {
+	Count  *int `yaml:"count,omitempty"`
+	Memory int  `yaml:"memory,omitempty"`
+}
+
+// parameters the rack manages for each app that must not be copied between apps
+var appManagedParameters = map[string]bool{
+	"Cluster":        true,
+	"Environment":    true,
+	"Key":            true,
+	"Release":        true,
+	"Subnets":        true,
+	"SubnetsPrivate": true,
+	"Version":        true,
+	"VPC":            true,
+}
+
+// host ports are unique per app and process scaling is handled by formation
+var appProcessParameter = regexp.MustCompile(`(Port\d+Host|DesiredCount|Memory)$`)
+
+// how long to wait for an app stack to finish an update
+const appWaitTimeout = 30 * time.Minute
+
 func init() {
 	stdcli.RegisterCommand(cli.Command{
 		Name:        "apps",
 		Action:      cmdApps,
 		Description: "list deployed apps",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "review",
+				Usage: "only list review apps",
+			},
+		},
 		Subcommands: []cli.Command{
 			{
 				Name:        "create",
 				Description: "create a new application",
 				Usage:       "<name>",
 				Action:      cmdAppCreate,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "from-template",
+						Usage: "YAML file with parameters, environment, formation, links and build for the new app",
+					},
+				},
+			},
+			{
+				Name:        "clone",
+				Description: "create a new application with the configuration of an existing one",
+				Usage:       "<source> <name>",
+				Action:      cmdAppClone,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "build",
+						Usage: "copy and promote the latest build of the source app",
+					},
+				},
 			},
 			{
 				Name:        "delete",
@@ -34,6 +96,20 @@ func init() {
 				Action:      cmdAppInfo,
 				Flags:       []cli.Flag{appFlag},
 			},
+			{
+				Name:        "template",
+				Description: "render the CloudFormation template for an app from a local manifest",
+				Usage:       "[-f file] [--parameters] [--diff]",
+				Action:      cmdAppTemplate,
+				Flags: append([]cli.Flag{
+					appFlag,
+					cli.StringFlag{
+						Name:  "file, f",
+						Value: "docker-compose.yml",
+						Usage: "path to an alternate docker compose manifest file",
+					},
+				}, templateFlags...),
+			},
 			{
 				Name:        "params",
 				Description: "list advanced parameters for an app",
@@ -64,15 +140,40 @@ func cmdApps(c *cli.Context) error {
 		return nil
 	}
 
+	return listApps(c, c.Bool("review"))
+}
+
+func listApps(c *cli.Context, review bool) error {
 	apps, err := rackClient(c).GetApps()
 	if err != nil {
 		return stdcli.ExitError(err)
 	}
 
-	t := stdcli.NewTable("APP", "STATUS")
+	if !review {
+		t := stdcli.NewTable("APP", "STATUS")
+
+		for _, app := range apps {
+			t.AddRow(app.Name, app.Status)
+		}
+
+		t.Print()
+		return nil
+	}
+
+	t := stdcli.NewTable("APP", "STATUS", "SOURCE", "BRANCH", "EXPIRES")
 
 	for _, app := range apps {
-		t.AddRow(app.Name, app.Status)
+		if app.Review == nil {
+			continue
+		}
+
+		expires := "(on branch delete)"
+
+		if app.Review.Expires != nil {
+			expires = humanizeTime(*app.Review.Expires)
+		}
+
+		t.AddRow(app.Name, app.Status, app.Review.Source, app.Review.Branch, expires)
 	}
 
 	t.Print()
@@ -93,6 +194,27 @@ func cmdAppCreate(c *cli.Context) error {
 		return stdcli.ExitError(fmt.Errorf("must specify an app name"))
 	}
 
+	if file := c.String("from-template"); file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		var t AppTemplate
+
+		err = yaml.Unmarshal(data, &t)
+		if err != nil {
+			return stdcli.ExitError(fmt.Errorf("invalid template %s: %s", file, err))
+		}
+
+		err = createAppFromTemplate(rackClient(c), app, t)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		return nil
+	}
+
 	fmt.Printf("Creating app %s... ", app)
 
 	_, err = rackClient(c).CreateApp(app)
@@ -104,6 +226,61 @@ func cmdAppCreate(c *cli.Context) error {
 	return nil
 }
 
+func cmdAppClone(c *cli.Context) error {
+	if len(c.Args()) != 2 {
+		stdcli.Usage(c, "clone")
+		return nil
+	}
+
+	src := c.Args()[0]
+	app := c.Args()[1]
+
+	t, err := appTemplate(rackClient(c), src)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if c.Bool("build") {
+		t.Build = src
+	}
+
+	err = createAppFromTemplate(rackClient(c), app, *t)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	return nil
+}
+
+func cmdAppTemplate(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox apps template` does not take arguments"))
+	}
+
+	dir, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	m, err := manifest.Read(dir, c.String("file"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	data, err := m.Raw()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t, err := rackClient(c).RenderAppTemplate(app, data, c.Bool("diff"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	printTemplate(c, t)
+	return nil
+}
+
 func cmdAppDelete(c *cli.Context) error {
 	if len(c.Args()) < 1 {
 		stdcli.Usage(c, "delete")
@@ -221,3 +398,369 @@ func cmdAppParamsSet(c *cli.Context) error {
 	fmt.Println("OK")
 	return nil
 }
+
+// appTemplate captures the configuration of an existing app
+func appTemplate(rc *client.Client, app string) (*AppTemplate, error) {
+	params, err := rc.ListParameters(app)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := rc.GetEnvironment(app)
+	if err != nil {
+		return nil, err
+	}
+
+	formation, err := rc.ListFormation(app)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := rc.GetServices()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &AppTemplate{
+		Environment: env,
+		Formation:   map[string]AppTemplateProcess{},
+		Links:       []string{},
+		Parameters:  map[string]string{},
+	}
+
+	for key, value := range params {
+		if !appManagedParameters[key] && !appProcessParameter.MatchString(key) {
+			t.Parameters[key] = value
+		}
+	}
+
+	for _, f := range formation {
+		count := f.Count
+		t.Formation[f.Name] = AppTemplateProcess{Count: &count, Memory: f.Memory}
+	}
+
+	// only linkable services report their apps so look each one up
+	for _, s := range services {
+		service, err := rc.GetService(s.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range service.Apps {
+			if a.Name == app {
+				t.Links = append(t.Links, s.Name)
+			}
+		}
+	}
+
+	sort.Strings(t.Links)
+
+	return t, nil
+}
+
+// createAppFromTemplate creates an app and applies a template to it,
+// waiting for the app to settle between each stack update
+func createAppFromTemplate(rc *client.Client, app string, t AppTemplate) error {
+	fmt.Printf("Creating app %s... ", app)
+
+	_, err := rc.CreateApp(app)
+	if err != nil {
+		return err
+	}
+
+	err = waitForAppRunning(rc, app)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("OK")
+
+	err = applyAppTemplateConfig(rc, app, t)
+	if err != nil {
+		return err
+	}
+
+	if t.Build != "" {
+		err = copyTemplateBuild(rc, t.Build, app)
+		if err != nil {
+			return err
+		}
+	}
+
+	return applyAppTemplateScaling(rc, app, t)
+}
+
+// applyAppTemplateConfig sets the environment and links that a build needs
+func applyAppTemplateConfig(rc *client.Client, app string, t AppTemplate) error {
+	var err error
+
+	if len(t.Environment) > 0 {
+		data := ""
+
+		for _, key := range sortedKeys(t.Environment) {
+			data += fmt.Sprintf("%s=%s\n", key, t.Environment[key])
+		}
+
+		fmt.Print("Setting environment... ")
+
+		_, _, err = rc.SetEnvironment(app, strings.NewReader(data))
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("OK")
+	}
+
+	for _, link := range t.Links {
+		fmt.Printf("Linking %s... ", link)
+
+		_, err = rc.CreateLink(app, link)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("OK")
+	}
+
+	return nil
+}
+
+// applyAppTemplateScaling sets parameters and formation once a release has been promoted
+// as process parameters only exist from then on
+func applyAppTemplateScaling(rc *client.Client, app string, t AppTemplate) error {
+	current, err := rc.ListParameters(app)
+	if err != nil {
+		return err
+	}
+
+	params := map[string]string{}
+
+	for _, key := range sortedKeys(t.Parameters) {
+		value, ok := current[key]
+
+		switch {
+		case appManagedParameters[key] || appProcessParameter.MatchString(key):
+			fmt.Printf("Skipping parameter %s: managed by the rack\n", key)
+		case !ok:
+			fmt.Printf("Skipping parameter %s: not defined for this app\n", key)
+		case value != t.Parameters[key]:
+			params[key] = t.Parameters[key]
+		}
+	}
+
+	if len(params) > 0 {
+		fmt.Print("Updating parameters... ")
+
+		err = rc.SetParameters(app, params)
+		if err != nil {
+			return err
+		}
+
+		err = waitForAppRunning(rc, app)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("OK")
+	}
+
+	if len(t.Formation) > 0 {
+		formation, err := rc.ListFormation(app)
+		if err != nil {
+			return err
+		}
+
+		// processes only exist once a release has been promoted
+		if len(formation) == 0 {
+			fmt.Print(appTemplateFormationNote(app, t.Formation))
+			return nil
+		}
+
+		processes := map[string]client.FormationEntry{}
+
+		for _, f := range formation {
+			processes[f.Name] = f
+		}
+
+		names := []string{}
+
+		for name := range t.Formation {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			f, ok := processes[name]
+			if !ok {
+				fmt.Printf("Skipping formation for %s: no such process\n", name)
+				continue
+			}
+
+			p := t.Formation[name]
+
+			count := f.Count
+
+			if p.Count != nil {
+				count = *p.Count
+			}
+
+			if count == f.Count && (p.Memory == 0 || p.Memory == f.Memory) {
+				continue
+			}
+
+			fmt.Printf("Scaling %s... ", name)
+
+			err = rc.SetFormation(app, name, count, p.Memory)
+			if err != nil {
+				return err
+			}
+
+			err = waitForAppRunning(rc, app)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("OK")
+		}
+	}
+
+	return nil
+}
+
+// copyTemplateBuild copies a build from another app and promotes it.
+// The source is either an app name for its latest complete build or app/build.
+func copyTemplateBuild(rc *client.Client, source, app string) error {
+	parts := strings.SplitN(source, "/", 2)
+	src := parts[0]
+	id := ""
+
+	if len(parts) == 2 {
+		id = parts[1]
+	} else {
+		builds, err := rc.GetBuilds(src)
+		if err != nil {
+			return err
+		}
+
+		// builds are listed newest first
+		for _, b := range builds {
+			if b.Status == "complete" {
+				id = b.Id
+				break
+			}
+		}
+
+		if id == "" {
+			return fmt.Errorf("no complete builds for %s", src)
+		}
+	}
+
+	fmt.Printf("Copying build %s from %s... ", id, src)
+
+	b, err := rc.CopyBuild(src, id, app)
+	if err != nil {
+		return err
+	}
+
+	for b.Status != "complete" {
+		switch b.Status {
+		case "error", "failed":
+			return fmt.Errorf("build copy failed: %s", b.Id)
+		}
+
+		time.Sleep(1 * time.Second)
+
+		b, err = rc.GetBuild(app, b.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("OK")
+
+	if b.Release == "" {
+		return nil
+	}
+
+	fmt.Printf("Promoting %s... ", b.Release)
+
+	_, err = rc.PromoteRelease(app, b.Release)
+	if err != nil {
+		return err
+	}
+
+	err = waitForAppRunning(rc, app)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("OK")
+	return nil
+}
+
+// appTemplateFormationNote explains how to apply a formation to an app without a release
+func appTemplateFormationNote(app string, formation map[string]AppTemplateProcess) string {
+	names := []string{}
+
+	for name := range formation {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	commands := []string{}
+
+	for _, name := range names {
+		p := formation[name]
+		command := fmt.Sprintf("convox scale %s", name)
+
+		if p.Count != nil {
+			command += fmt.Sprintf(" --count %d", *p.Count)
+		}
+
+		if p.Memory > 0 {
+			command += fmt.Sprintf(" --memory %d", p.Memory)
+		}
+
+		commands = append(commands, fmt.Sprintf("  %s --app %s", command, app))
+	}
+
+	return fmt.Sprintf("Formation not applied, %s has no release yet. Deploy it and then run:\n%s\n", app, strings.Join(commands, "\n"))
+}
+
+func waitForAppRunning(rc *client.Client, app string) error {
+	deadline := time.Now().Add(appWaitTimeout)
+
+	for {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for app %s to be running", app)
+		}
+
+		a, err := rc.GetApp(app)
+		if err != nil {
+			return err
+		}
+
+		switch a.Status {
+		case "running":
+			return nil
+		case "creating", "updating":
+		default:
+			return fmt.Errorf("app %s is %s", app, a.Status)
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
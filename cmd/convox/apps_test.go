@@ -1,6 +1,10 @@
 package main
 
 import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/convox/rack/client"
@@ -57,3 +61,110 @@ func TestAppsCreateFail(t *testing.T) {
 		},
 	)
 }
+
+func TestAppsClone(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/src/parameters", Code: 200, Response: map[string]string{"Cpu": "256", "Release": "RABCDEFGHI", "WebDesiredCount": "2"}},
+		test.Http{Method: "GET", Path: "/apps/src/environment", Code: 200, Response: client.Environment{"FOO": "bar"}},
+		test.Http{Method: "GET", Path: "/apps/src/formation", Code: 200, Response: client.Formation{client.FormationEntry{Name: "web", Count: 2, Memory: 512}}},
+		test.Http{Method: "GET", Path: "/services", Code: 200, Response: client.Services{client.Service{Name: "papertrail"}}},
+		test.Http{Method: "GET", Path: "/services/papertrail", Code: 200, Response: client.Service{Name: "papertrail", Apps: client.Apps{client.App{Name: "src"}}}},
+		test.Http{Method: "POST", Path: "/apps", Body: "name=dest", Code: 200, Response: client.App{Name: "dest", Status: "creating"}},
+		test.Http{Method: "GET", Path: "/apps/dest", Code: 200, Response: client.App{Name: "dest", Status: "running"}},
+		test.Http{Method: "POST", Path: "/apps/dest/environment", Body: "FOO=bar\n", Code: 200, Response: client.Environment{"FOO": "bar"}},
+		test.Http{Method: "POST", Path: "/services/papertrail/links", Body: "app=dest", Code: 200, Response: client.Service{Name: "papertrail"}},
+		test.Http{Method: "GET", Path: "/apps/dest/parameters", Code: 200, Response: map[string]string{"Cpu": "200", "Release": ""}},
+		test.Http{Method: "POST", Path: "/apps/dest/parameters", Body: "Cpu=256", Code: 200, Response: map[string]bool{"success": true}},
+		test.Http{Method: "GET", Path: "/apps/dest/formation", Code: 200, Response: client.Formation{}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox apps clone src dest",
+			Exit:    0,
+			Stdout:  "Creating app dest... OK\nSetting environment... OK\nLinking papertrail... OK\nUpdating parameters... OK\nFormation not applied, dest has no release yet. Deploy it and then run:\n  convox scale web --count 2 --memory 512 --app dest\n",
+		},
+	)
+}
+
+func TestAppsCreateFromTemplate(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/apps", Body: "name=foobar", Code: 200, Response: client.App{Name: "foobar", Status: "creating"}},
+		test.Http{Method: "GET", Path: "/apps/foobar", Code: 200, Response: client.App{Name: "foobar", Status: "running"}},
+		test.Http{Method: "POST", Path: "/apps/foobar/environment", Body: "BAZ=qux\nFOO=bar\n", Code: 200, Response: client.Environment{"FOO": "bar"}},
+		test.Http{Method: "GET", Path: "/apps/foobar/parameters", Code: 200, Response: map[string]string{"Cpu": "200"}},
+		test.Http{Method: "GET", Path: "/apps/foobar/formation", Code: 200, Response: client.Formation{}},
+	)
+
+	defer ts.Close()
+
+	template, err := ioutil.TempFile("", "template")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(template.Name())
+
+	template.WriteString("environment:\n  FOO: bar\n  BAZ: qux\nparameters:\n  Cpu: \"200\"\n  Release: RABCDEFGHI\nformation:\n  web:\n    count: 2\n")
+	template.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox apps create foobar --from-template " + template.Name(),
+			Exit:    0,
+			Stdout:  "Creating app foobar... OK\nSetting environment... OK\nSkipping parameter Release: managed by the rack\nFormation not applied, foobar has no release yet. Deploy it and then run:\n  convox scale web --count 2 --app foobar\n",
+		},
+	)
+}
+
+func TestAppsReview(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps", Code: 200, Response: client.Apps{
+			client.App{Name: "myapp", Status: "running"},
+			client.App{Name: "myapp-feature-x", Status: "running", Review: &client.AppReview{Branch: "feature-x", Source: "myapp"}},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox apps --review",
+			Exit:    0,
+			Stdout:  "APP              STATUS   SOURCE  BRANCH     EXPIRES           \nmyapp-feature-x  running  myapp   feature-x  (on branch delete)\n",
+		},
+	)
+}
+
+func TestAppsTemplate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "template")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ioutil.WriteFile(filepath.Join(dir, "docker-compose.yml"), []byte("web:\n  image: httpd\n"), 0644)
+
+	body := url.Values{"diff": {"false"}, "manifest": {"web:\n  image: httpd\n"}}.Encode()
+
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/apps/myapp/template", Body: body, Code: 200, Response: client.ReleaseTemplate{
+			App:      "myapp",
+			Release:  "RAAAAAAAAAA",
+			Template: "{\n  \"Resources\": {}\n}\n",
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox apps template --app myapp",
+			Dir:     dir,
+			Exit:    0,
+			Stdout:  "{\n  \"Resources\": {}\n}\n",
+		},
+	)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/convox/rack/cmd/convox/stdcli"
 	"github.com/docker/docker/builder/dockerignore"
 	"github.com/docker/docker/pkg/fileutils"
+	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -79,6 +80,30 @@ func init() {
 						Name:  "promote",
 						Usage: "promote the release after copy",
 					},
+					cli.StringFlag{
+						Name:  "rack",
+						Usage: "copy the build to an app on another rack you have logged into",
+					},
+				},
+			},
+			{
+				Name:        "export",
+				Description: "export a build and its images to stdout",
+				Usage:       "<ID> > build.tgz",
+				Action:      cmdBuildsExport,
+				Flags:       []cli.Flag{appFlag},
+			},
+			{
+				Name:        "import",
+				Description: "import a build exported with `convox builds export` from stdin",
+				Usage:       "[app] < build.tgz",
+				Action:      cmdBuildsImport,
+				Flags: []cli.Flag{
+					appFlag,
+					cli.BoolFlag{
+						Name:  "promote",
+						Usage: "promote the release after import",
+					},
 				},
 			},
 			{
@@ -232,6 +257,10 @@ func cmdBuildsCopy(c *cli.Context) error {
 	build := c.Args()[0]
 	destApp := c.Args()[1]
 
+	if rack := c.String("rack"); rack != "" {
+		return copyBuildToRack(c, app, build, destApp, rack)
+	}
+
 	fmt.Print("Copying build... ")
 
 	b, err := rackClient(c).CopyBuild(app, build, destApp)
@@ -264,6 +293,114 @@ func cmdBuildsCopy(c *cli.Context) error {
 	return nil
 }
 
+func cmdBuildsExport(c *cli.Context) error {
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "export")
+		return nil
+	}
+
+	if terminal.IsTerminal(int(os.Stdout.Fd())) {
+		return stdcli.ExitError(fmt.Errorf("refusing to write build to a terminal, redirect output to a file"))
+	}
+
+	build := c.Args()[0]
+
+	fmt.Fprintf(os.Stderr, "Exporting %s... ", build)
+
+	err = rackClient(c).ExportBuild(app, build, os.Stdout)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Fprintln(os.Stderr, "OK")
+	return nil
+}
+
+func cmdBuildsImport(c *cli.Context) error {
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	switch len(c.Args()) {
+	case 0:
+	case 1:
+		app = c.Args()[0]
+	default:
+		stdcli.Usage(c, "import")
+		return nil
+	}
+
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return stdcli.ExitError(fmt.Errorf("no build on stdin, try `convox builds import < build.tgz`"))
+	}
+
+	fmt.Print("Importing build... ")
+
+	b, err := rackClient(c).ImportBuild(app, os.Stdin)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+
+	return promoteImportedBuild(c, rackClient(c), app, b)
+}
+
+// copyBuildToRack streams an export from the current rack into an import on another rack
+func copyBuildToRack(c *cli.Context, app, build, destApp, rack string) error {
+	dest, err := rackClientHost(c, rack)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Printf("Copying build to %s... ", rack)
+
+	r, w := io.Pipe()
+
+	go func() {
+		w.CloseWithError(rackClient(c).ExportBuild(app, build, w))
+	}()
+
+	b, err := dest.ImportBuild(destApp, r)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+
+	return promoteImportedBuild(c, dest, destApp, b)
+}
+
+func promoteImportedBuild(c *cli.Context, rc *client.Client, app string, b *client.Build) error {
+	fmt.Printf("Build:   %s\n", b.Id)
+	fmt.Printf("Release: %s\n", b.Release)
+
+	if b.Release == "" {
+		return nil
+	}
+
+	if !c.Bool("promote") {
+		fmt.Printf("To deploy this build run `convox releases promote %s --app %s`\n", b.Release, app)
+		return nil
+	}
+
+	fmt.Printf("Promoting %s %s... ", app, b.Release)
+
+	_, err := rc.PromoteRelease(app, b.Release)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+	return nil
+}
+
 func executeBuild(c *cli.Context, source, app, manifest, description string) (string, error) {
 	u, _ := url.Parse(source)
 
@@ -41,3 +41,27 @@ func TestBuildsCreateReturnsNoBuild(t *testing.T) {
 		},
 	)
 }
+
+func TestBuildsImport(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/apps/foo/builds/import", Body: "artifact", Code: 200, Response: client.Build{Id: "BNEW", Release: "RNEW"}},
+		test.Http{Method: "POST", Path: "/apps/foo/releases/RNEW/promote", Code: 200, Response: client.Release{Id: "RNEW"}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox builds import foo",
+			Stdin:   "artifact",
+			Exit:    0,
+			Stdout:  "Importing build... OK\nBuild:   BNEW\nRelease: RNEW\nTo deploy this build run `convox releases promote RNEW --app foo`\n",
+		},
+		test.ExecRun{
+			Command: "convox builds import --app foo --promote",
+			Stdin:   "artifact",
+			Exit:    0,
+			Stdout:  "Importing build... OK\nBuild:   BNEW\nRelease: RNEW\nPromoting foo RNEW... OK\n",
+		},
+	)
+}
@@ -3,7 +3,12 @@ package main
 import (
 	"fmt"
 	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/convox/rack/client"
 	"github.com/convox/rack/cmd/convox/stdcli"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -14,6 +19,19 @@ func init() {
 		Action:      cmdCertsList,
 		Description: "list certificates",
 		Subcommands: []cli.Command{
+			{
+				Name:        "acme",
+				Description: "list certificates issued and renewed through Let's Encrypt",
+				Action:      cmdCertsAcme,
+				Subcommands: []cli.Command{
+					{
+						Name:        "delete",
+						Description: "stop renewing a certificate",
+						Usage:       "<id>",
+						Action:      cmdCertsAcmeDelete,
+					},
+				},
+			},
 			{
 				Name:        "create",
 				Description: "upload a certificate",
@@ -37,6 +55,39 @@ func init() {
 				Description: "generate a certificate",
 				Usage:       "<domain> [domain...]",
 				Action:      cmdCertsGenerate,
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "acme",
+						Usage: "issue the certificate through Let's Encrypt and renew it before it expires",
+					},
+					cli.StringFlag{
+						Name:  "email",
+						Usage: "contact address for the Let's Encrypt account",
+					},
+					cli.StringFlag{
+						Name:  "challenge",
+						Value: "http-01",
+						Usage: "http-01 for domains that point at the rack balancer or an app balancer labeled convox.port.80.acme=true, dns-01 creates a TXT record through --dns and works for wildcards",
+					},
+					cli.StringFlag{
+						Name:  "dns",
+						Usage: "dns provider for dns-01 challenges: webhook",
+					},
+					cli.StringSliceFlag{
+						Name:  "dns-config",
+						Usage: "dns provider setting as KEY=VALUE, e.g. url=https://dns.example.org/hook",
+					},
+					cli.BoolFlag{
+						Name:  "staging",
+						Usage: "use the Let's Encrypt staging directory",
+					},
+				},
+			},
+			{
+				Name:        "replace",
+				Description: "swap a certificate on every balancer port using it",
+				Usage:       "<old> <new>",
+				Action:      cmdCertsReplace,
 			},
 		},
 	})
@@ -57,16 +108,33 @@ func cmdCertsList(c *cli.Context) error {
 		return stdcli.ExitError(err)
 	}
 
-	t := stdcli.NewTable("ID", "DOMAIN", "EXPIRES")
+	t := stdcli.NewTable("ID", "DOMAIN", "EXPIRES", "DAYS", "BALANCERS")
 
 	for _, cert := range certs {
-		t.AddRow(cert.Id, cert.Domain, humanizeTime(cert.Expiration))
+		balancers := []string{}
+
+		for _, b := range cert.Balancers {
+			balancers = append(balancers, b.String())
+		}
+
+		t.AddRow(cert.Id, cert.Domain, humanizeTime(cert.Expiration), certDays(cert.Expiration), strings.Join(balancers, ","))
 	}
 
 	t.Print()
 	return nil
 }
 
+// certDays returns the whole days left before a certificate expires
+func certDays(expiration time.Time) string {
+	days := int(math.Floor(expiration.Sub(time.Now()).Hours() / 24))
+
+	if days < 0 {
+		return "expired"
+	}
+
+	return strconv.Itoa(days)
+}
+
 func cmdCertsCreate(c *cli.Context) error {
 	if len(c.Args()) < 2 {
 		stdcli.Usage(c, "create")
@@ -122,12 +190,38 @@ func cmdCertsDelete(c *cli.Context) error {
 	return nil
 }
 
+func cmdCertsReplace(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		stdcli.Usage(c, "replace")
+		return nil
+	}
+
+	fmt.Printf("Replacing certificate... ")
+
+	balancers, err := rackClient(c).ReplaceCertificate(c.Args()[0], c.Args()[1])
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+
+	for _, b := range balancers {
+		fmt.Printf("Updating %s\n", b)
+	}
+
+	return nil
+}
+
 func cmdCertsGenerate(c *cli.Context) error {
 	if len(c.Args()) < 1 {
 		stdcli.Usage(c, "generate")
 		return nil
 	}
 
+	if c.Bool("acme") {
+		return generateAcmeCertificate(c)
+	}
+
 	fmt.Printf("Requesting certificate... ")
 
 	cert, err := rackClient(c).GenerateCertificate(c.Args())
@@ -138,3 +232,82 @@ func cmdCertsGenerate(c *cli.Context) error {
 	fmt.Printf("OK, %s\n", cert.Id)
 	return nil
 }
+
+// generateAcmeCertificate asks the rack to issue a certificate through Let's Encrypt.
+// The rack refuses http-01 challenges for domains that do not resolve to its balancer
+// or to an app balancer with an acmeproxy container.
+func generateAcmeCertificate(c *cli.Context) error {
+	opts := client.AcmeOptions{
+		Email:     c.String("email"),
+		Challenge: c.String("challenge"),
+		Dns:       c.String("dns"),
+		DnsConfig: c.StringSlice("dns-config"),
+		Staging:   c.Bool("staging"),
+	}
+
+	fmt.Printf("Requesting certificate... ")
+
+	cert, err := rackClient(c).GenerateAcmeCertificate(c.Args(), opts)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Printf("OK, %s\n", cert.Id)
+	fmt.Printf("Run `convox certs acme` to follow issuing\n")
+	return nil
+}
+
+func cmdCertsAcme(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox certs acme` does not take arguments. Perhaps you meant `convox certs acme delete`?"))
+	}
+
+	certs, err := rackClient(c).ListAcmeCertificates()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t := stdcli.NewTable("ID", "DOMAINS", "CHALLENGE", "STATUS", "CERTIFICATE", "EXPIRES")
+
+	for _, cert := range certs {
+		expires := ""
+
+		if !cert.Expiration.IsZero() {
+			expires = humanizeTime(cert.Expiration)
+		}
+
+		t.AddRow(cert.Id, strings.Join(cert.Domains, ","), cert.Challenge, cert.Status, cert.Certificate, expires)
+	}
+
+	t.Print()
+
+	for _, cert := range certs {
+		if cert.Error != "" {
+			fmt.Printf("%s: %s\n", cert.Id, cert.Error)
+		}
+	}
+
+	return nil
+}
+
+func cmdCertsAcmeDelete(c *cli.Context) error {
+	if len(c.Args()) < 1 {
+		stdcli.Usage(c, "delete")
+		return nil
+	}
+
+	fmt.Printf("Removing certificate... ")
+
+	cert, err := rackClient(c).DeleteAcmeCertificate(c.Args()[0])
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+
+	if cert.Certificate != "" {
+		fmt.Printf("%s is no longer renewed, remove it with `convox certs delete %s`\n", cert.Certificate, cert.Certificate)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestCertsList(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/certificates", Code: 200, Response: client.Certificates{
+			client.Certificate{Id: "acm-0123456789ab", Domain: "*.example.org", Expiration: time.Now().Add(200*24*time.Hour + time.Hour), Balancers: client.CertificateBalancers{
+				client.CertificateBalancer{App: "myapp", Process: "web", Port: 443},
+				client.CertificateBalancer{App: "other", Process: "api", Port: 8443},
+			}},
+			client.Certificate{Id: "cert-1234567890", Domain: "old.example.org", Expiration: time.Now().Add(-48 * time.Hour)},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox certs",
+			Exit:    0,
+			Stdout:  "ID                DOMAIN           EXPIRES            DAYS     BALANCERS                   \nacm-0123456789ab  *.example.org    6 months from now  200      myapp/web:443,other/api:8443\ncert-1234567890   old.example.org  2 days ago         expired                              \n",
+		},
+	)
+}
+
+func TestCertsReplace(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/certificates/cert-1234567890/replace", Body: "certificate=acm-0123456789ab", Code: 200, Response: client.CertificateBalancers{
+			client.CertificateBalancer{App: "myapp", Process: "web", Port: 443},
+			client.CertificateBalancer{App: "myapp", Process: "web", Port: 8443},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox certs replace cert-1234567890 acm-0123456789ab",
+			Exit:    0,
+			Stdout:  "Replacing certificate... OK\nUpdating myapp/web:443\nUpdating myapp/web:8443\n",
+		},
+	)
+}
+
+func TestCertsGenerateAcme(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/certificates/acme", Body: "challenge=dns-01&dns=webhook&dns-config=url%3Dhttps%3A%2F%2Fdns.example.org%0Atoken%3Dsecret&domains=%2A.example.org&email=ops%40example.org&staging=true", Code: 200, Response: client.AcmeCertificate{Id: "EABCDEFGHIJ"}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox certs generate *.example.org --acme --email ops@example.org --challenge dns-01 --dns webhook --dns-config url=https://dns.example.org --dns-config token=secret --staging",
+			Exit:    0,
+			Stdout:  "Requesting certificate... OK, EABCDEFGHIJ\nRun `convox certs acme` to follow issuing\n",
+		},
+	)
+}
+
+func TestCertsAcme(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/certificates/acme", Code: 200, Response: client.AcmeCertificates{
+			client.AcmeCertificate{Id: "EABCDEFGHIJ", Domains: []string{"example.org", "www.example.org"}, Challenge: "http-01", Status: "issued", Certificate: "cert-1234567890", Expiration: time.Now().Add(65 * 24 * time.Hour)},
+			client.AcmeCertificate{Id: "EKLMNOPQRST", Domains: []string{"*.example.org"}, Challenge: "dns-01", Status: "failed", Error: "acme: authorization for example.org is invalid"},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox certs acme",
+			Exit:    0,
+			Stdout:  "ID           DOMAINS                      CHALLENGE  STATUS  CERTIFICATE      EXPIRES          \nEABCDEFGHIJ  example.org,www.example.org  http-01    issued  cert-1234567890  2 months from now\nEKLMNOPQRST  *.example.org                dns-01     failed                                    \nEKLMNOPQRST: acme: authorization for example.org is invalid\n",
+		},
+	)
+}
+
+func TestCertsAcmeDelete(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "DELETE", Path: "/certificates/acme/EABCDEFGHIJ", Code: 200, Response: client.AcmeCertificate{Id: "EABCDEFGHIJ", Certificate: "cert-1234567890"}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox certs acme delete EABCDEFGHIJ",
+			Exit:    0,
+			Stdout:  "Removing certificate... OK\ncert-1234567890 is no longer renewed, remove it with `convox certs delete cert-1234567890`\n",
+		},
+	)
+}
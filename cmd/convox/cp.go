@@ -0,0 +1,241 @@
+package main
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/convox/rack/cmd/convox/stdcli"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func init() {
+	stdcli.RegisterCommand(cli.Command{
+		Name:        "cp",
+		Description: "copy files to or from a process",
+		Usage:       "<pid>:<path> <local> | <local> <pid>:<path>",
+		Action:      cmdCopy,
+		Flags:       []cli.Flag{appFlag},
+	})
+}
+
+func cmdCopy(c *cli.Context) error {
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if len(c.Args()) != 2 {
+		stdcli.Usage(c, "cp")
+		return nil
+	}
+
+	spid, spath, sremote := parseCopyPath(c.Args()[0])
+	dpid, dpath, dremote := parseCopyPath(c.Args()[1])
+
+	switch {
+	case sremote && dremote:
+		return stdcli.ExitError(fmt.Errorf("can not copy between processes, one path must be local"))
+	case !sremote && !dremote:
+		return stdcli.ExitError(fmt.Errorf("one path must be in a process, e.g. <pid>:/app/file"))
+	case sremote:
+		r, w := io.Pipe()
+
+		go func() {
+			w.CloseWithError(rackClient(c).CopyFromProcess(app, spid, spath, w))
+		}()
+
+		err = extractTar(r, dpath)
+		r.Close()
+	default:
+		if _, err := os.Stat(spath); err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		r, w := io.Pipe()
+
+		go func() {
+			w.CloseWithError(writeTar(w, spath))
+		}()
+
+		err = rackClient(c).CopyToProcess(app, dpid, dpath, r)
+		r.Close()
+	}
+
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	return nil
+}
+
+// parseCopyPath splits a <pid>:<path> argument. Anything else is a local path.
+func parseCopyPath(arg string) (string, string, bool) {
+	parts := strings.SplitN(arg, ":", 2)
+
+	// single letters are windows drives rather than process ids
+	if len(parts) != 2 || len(parts[0]) < 2 || strings.ContainsAny(parts[0], `/\`) || parts[1] == "" {
+		return "", arg, false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// writeTar writes a tar archive of a local file or directory to w with the base name of
+// the path as its top level entry
+func writeTar(w io.Writer, path string) error {
+	tw := tar.NewWriter(w)
+
+	base := filepath.Dir(filepath.Clean(path))
+
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(base, file)
+		if err != nil {
+			return err
+		}
+
+		link := ""
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		}
+
+		h, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		h.Name = filepath.ToSlash(rel)
+
+		if info.IsDir() {
+			h.Name += "/"
+		}
+
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		fd, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+
+		defer fd.Close()
+
+		_, err = io.Copy(tw, fd)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+// extractTar extracts a tar archive to a local path. If dst is an existing directory the
+// archive is extracted inside it, otherwise the top level entry is renamed to dst.
+// Symlinks must point inside dst and entries are never written through a symlink
+// from the same archive.
+func extractTar(r io.Reader, dst string) error {
+	tr := tar.NewReader(r)
+
+	dst = filepath.Clean(dst)
+	into := false
+	links := map[string]bool{}
+
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		into = true
+	}
+
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := filepath.FromSlash(strings.TrimPrefix(h.Name, "./"))
+
+		if strings.HasPrefix(filepath.Clean(name), "..") || filepath.IsAbs(name) {
+			return fmt.Errorf("invalid path in archive: %s", h.Name)
+		}
+
+		target := filepath.Join(dst, name)
+
+		if !into {
+			parts := strings.SplitN(filepath.Clean(name), string(filepath.Separator), 2)
+			parts[0] = dst
+			target = filepath.Join(parts...)
+		}
+
+		for dir := filepath.Dir(target); dir != dst && dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if links[dir] {
+				return fmt.Errorf("invalid path in archive: %s is inside a symlink", h.Name)
+			}
+		}
+
+		if links[target] && h.Typeflag != tar.TypeSymlink {
+			return fmt.Errorf("invalid path in archive: %s replaces a symlink", h.Name)
+		}
+
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(h.Mode)|0700); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(h.Linkname) || !insideDir(dst, filepath.Join(filepath.Dir(target), h.Linkname)) {
+				return fmt.Errorf("invalid symlink in archive: %s -> %s", h.Name, h.Linkname)
+			}
+
+			os.Remove(target)
+
+			links[target] = true
+
+			if err := os.Symlink(h.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+
+			fd, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.FileMode(h.Mode))
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(fd, tr)
+			fd.Close()
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// insideDir returns true if path is dir or below it
+func insideDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/convox/rack/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyPaths(t *testing.T) {
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox cp ./a ./b --app myapp",
+			Exit:    1,
+			Stderr:  "ERROR: one path must be in a process, e.g. <pid>:/app/file\n",
+		},
+		test.ExecRun{
+			Command: "convox cp 0123456789ab:/a 0123456789ab:/b --app myapp",
+			Exit:    1,
+			Stderr:  "ERROR: can not copy between processes, one path must be local\n",
+		},
+	)
+}
+
+func TestParseCopyPath(t *testing.T) {
+	pid, path, remote := parseCopyPath("0123456789ab:/app/config.yml")
+	assert.Equal(t, "0123456789ab", pid)
+	assert.Equal(t, "/app/config.yml", path)
+	assert.True(t, remote)
+
+	for _, arg := range []string{"./config.yml", "C:\\config.yml", "dir/file:name", "0123456789ab:"} {
+		_, path, remote = parseCopyPath(arg)
+		assert.Equal(t, arg, path)
+		assert.False(t, remote, arg)
+	}
+}
+
+func TestCopyTarRoundTrip(t *testing.T) {
+	src, err := ioutil.TempDir("", "convox-cp-src")
+	assert.NoError(t, err)
+	defer os.RemoveAll(src)
+
+	dst, err := ioutil.TempDir("", "convox-cp-dst")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "data", "nested"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(src, "data", "nested", "file.txt"), []byte("hello"), 0644))
+
+	var buf bytes.Buffer
+
+	assert.NoError(t, writeTar(&buf, filepath.Join(src, "data")))
+
+	archive := buf.Bytes()
+
+	// an existing directory receives the archive inside it
+	assert.NoError(t, extractTar(bytes.NewReader(archive), dst))
+
+	data, err := ioutil.ReadFile(filepath.Join(dst, "data", "nested", "file.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	// a new path renames the top level entry
+	assert.NoError(t, extractTar(bytes.NewReader(archive), filepath.Join(dst, "copy")))
+
+	data, err = ioutil.ReadFile(filepath.Join(dst, "copy", "nested", "file.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+}
+
+func TestExtractTarSymlinks(t *testing.T) {
+	dst, err := ioutil.TempDir("", "convox-cp-dst")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	archive := func(entries ...*tar.Header) []byte {
+		var buf bytes.Buffer
+
+		tw := tar.NewWriter(&buf)
+
+		for _, h := range entries {
+			assert.NoError(t, tw.WriteHeader(h))
+			tw.Write(make([]byte, h.Size))
+		}
+
+		assert.NoError(t, tw.Close())
+
+		return buf.Bytes()
+	}
+
+	// links inside the destination are kept
+	err = extractTar(bytes.NewReader(archive(
+		&tar.Header{Name: "data/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "data/file.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 5},
+		&tar.Header{Name: "data/link", Typeflag: tar.TypeSymlink, Linkname: "file.txt"},
+	)), dst)
+	assert.NoError(t, err)
+
+	link, err := os.Readlink(filepath.Join(dst, "data", "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, "file.txt", link)
+
+	err = extractTar(bytes.NewReader(archive(
+		&tar.Header{Name: "data/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "data/escape", Typeflag: tar.TypeSymlink, Linkname: "../../etc"},
+	)), dst)
+	assert.EqualError(t, err, "invalid symlink in archive: data/escape -> ../../etc")
+
+	err = extractTar(bytes.NewReader(archive(
+		&tar.Header{Name: "data/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "data/escape", Typeflag: tar.TypeSymlink, Linkname: "/etc"},
+	)), dst)
+	assert.EqualError(t, err, "invalid symlink in archive: data/escape -> /etc")
+
+	err = extractTar(bytes.NewReader(archive(
+		&tar.Header{Name: "data/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "data/sub", Typeflag: tar.TypeSymlink, Linkname: "."},
+		&tar.Header{Name: "data/sub/file.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 5},
+	)), dst)
+	assert.EqualError(t, err, "invalid path in archive: data/sub/file.txt is inside a symlink")
+
+	err = extractTar(bytes.NewReader(archive(
+		&tar.Header{Name: "data/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "data/link", Typeflag: tar.TypeSymlink, Linkname: "file.txt"},
+		&tar.Header{Name: "data/link", Typeflag: tar.TypeReg, Mode: 0644, Size: 5},
+	)), dst)
+	assert.EqualError(t, err, "invalid path in archive: data/link replaces a symlink")
+}
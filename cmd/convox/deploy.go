@@ -13,11 +13,15 @@ func init() {
 		Description: "deploy an app to AWS",
 		Usage:       "<directory>",
 		Action:      cmdDeploy,
-		Flags:       buildCreateFlags,
+		Flags:       withRackGroupFlags(buildCreateFlags),
 	})
 }
 
 func cmdDeploy(c *cli.Context) error {
+	if c.String("racks") != "" {
+		return runRackGroup(c, true, false)
+	}
+
 	wd := "."
 
 	if len(c.Args()) > 0 {
@@ -32,13 +32,13 @@ func init() {
 				Description: "set an environment variable",
 				Usage:       "VARIABLE=VALUE",
 				Action:      cmdEnvSet,
-				Flags: []cli.Flag{
+				Flags: withRackGroupFlags([]cli.Flag{
 					appFlag,
 					cli.BoolFlag{
 						Name:  "promote",
 						Usage: "promote the release after env change",
 					},
-				},
+				}),
 			},
 			{
 				Name:        "unset",
@@ -118,6 +118,11 @@ func cmdEnvGet(c *cli.Context) error {
 }
 
 func cmdEnvSet(c *cli.Context) error {
+	if c.String("racks") != "" {
+		// variables are read from stdin when none are given as arguments
+		return runRackGroup(c, true, len(c.Args()) == 0)
+	}
+
 	_, app, err := stdcli.DirApp(c, ".")
 	if err != nil {
 		return stdcli.ExitError(err)
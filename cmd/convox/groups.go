@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/convox/rack/cmd/convox/stdcli"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/urfave/cli.v1"
+)
+
+// ConfigGroups maps a rack group name to the rack hosts in rollout order
+type ConfigGroups map[string][]string
+
+var rackGroupFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "racks",
+		Usage: "Rack group to run this command against. Racks are updated concurrently unless --serial is specified.",
+	},
+	cli.BoolFlag{
+		Name:  "serial",
+		Usage: "With --racks, run against one rack at a time in group order and stop at the first failure.",
+	},
+	cli.BoolFlag{
+		Name:  "yes",
+		Usage: "With --racks, skip the confirmation prompt for commands that change the racks.",
+	},
+}
+
+// withRackGroupFlags returns a new flag list so shared flag slices are not modified
+func withRackGroupFlags(flags []cli.Flag) []cli.Flag {
+	all := make([]cli.Flag, 0, len(flags)+len(rackGroupFlags))
+	all = append(all, flags...)
+	return append(all, rackGroupFlags...)
+}
+
+func cmdRackGroups(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox racks groups` does not take arguments. Perhaps you meant `convox racks groups set`?"))
+	}
+
+	groups, err := getRackGroups()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	names := []string{}
+
+	for name := range groups {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	t := stdcli.NewTable("GROUP", "RACKS")
+
+	for _, name := range names {
+		t.AddRow(name, strings.Join(groups[name], " "))
+	}
+
+	t.Print()
+	return nil
+}
+
+func cmdRackGroupSet(c *cli.Context) error {
+	if len(c.Args()) < 2 {
+		stdcli.Usage(c, "set")
+		return nil
+	}
+
+	name := c.Args()[0]
+	racks := c.Args()[1:]
+
+	for _, rack := range racks {
+		password, err := getLogin(rack)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		if password == "" {
+			return stdcli.ExitError(fmt.Errorf("no login for %s, try `convox login %s`", rack, rack))
+		}
+	}
+
+	groups, err := getRackGroups()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	groups[name] = racks
+
+	err = saveRackGroups(groups)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Printf("Set rack group %s: %s\n", name, strings.Join(racks, " "))
+	return nil
+}
+
+func cmdRackGroupDelete(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "delete")
+		return nil
+	}
+
+	name := c.Args()[0]
+
+	groups, err := getRackGroups()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if _, ok := groups[name]; !ok {
+		return stdcli.ExitError(fmt.Errorf("no such rack group: %s", name))
+	}
+
+	delete(groups, name)
+
+	err = saveRackGroups(groups)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Printf("Deleted rack group %s\n", name)
+	return nil
+}
+
+// runRackGroup runs the current command once per rack in the group named by --racks
+// by invoking this binary with CONVOX_HOST and CONVOX_PASSWORD set for each rack.
+// Commands that change the racks are confirmed first unless --yes is given. Only
+// commands that read stdin get a copy of it, the rest run with no stdin.
+func runRackGroup(c *cli.Context, changes, readsStdin bool) error {
+	name := c.String("racks")
+
+	racks, err := getRackGroup(name)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	args := stripRackGroupArgs(os.Args[1:])
+	interactive := terminal.IsTerminal(int(os.Stdin.Fd()))
+
+	// `env set` can read from stdin so buffer it once for every rack. Other commands
+	// do not wait on it, as stdin may be a pipe that never closes.
+	var stdin []byte
+
+	if readsStdin && !interactive {
+		stdin, err = ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+	}
+
+	if changes && !c.Bool("yes") {
+		if !interactive {
+			return stdcli.ExitError(fmt.Errorf("Aborting. Use --yes for non-interactive rack group commands."))
+		}
+
+		fmt.Printf("Run `%s %s` on %s (%s)? y/N: ", stdcli.Binary, strings.Join(args, " "), name, strings.Join(racks, ", "))
+
+		confirm, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		if strings.TrimSpace(confirm) != "y" {
+			return stdcli.ExitError(fmt.Errorf("Aborting."))
+		}
+	}
+
+	width := 0
+
+	for _, rack := range racks {
+		if len(rack) > width {
+			width = len(rack)
+		}
+	}
+
+	ran := make([]bool, len(racks))
+	results := make([]error, len(racks))
+
+	if c.Bool("serial") {
+		for i, rack := range racks {
+			ran[i] = true
+			results[i] = runRack(rack, fmt.Sprintf("%-*s |", width, rack), args, stdin)
+
+			if results[i] != nil {
+				break
+			}
+		}
+	} else {
+		var wg sync.WaitGroup
+
+		for i, rack := range racks {
+			wg.Add(1)
+			ran[i] = true
+
+			go func(i int, rack string) {
+				defer wg.Done()
+				results[i] = runRack(rack, fmt.Sprintf("%-*s |", width, rack), args, stdin)
+			}(i, rack)
+		}
+
+		wg.Wait()
+	}
+
+	fmt.Println()
+
+	t := stdcli.NewTable("RACK", "RESULT")
+	failed := 0
+
+	for i, rack := range racks {
+		switch {
+		case !ran[i]:
+			t.AddRow(rack, "skipped")
+		case results[i] != nil:
+			failed++
+			t.AddRow(rack, fmt.Sprintf("failed: %s", results[i]))
+		default:
+			t.AddRow(rack, "ok")
+		}
+	}
+
+	t.Print()
+
+	if failed > 0 {
+		return stdcli.ExitError(fmt.Errorf("command failed on %d of %d racks", failed, len(racks)))
+	}
+
+	return nil
+}
+
+func runRack(rack, prefix string, args []string, stdin []byte) error {
+	password, err := getLogin(rack)
+	if err != nil {
+		return err
+	}
+
+	if password == "" {
+		password = os.Getenv("CONVOX_PASSWORD")
+	}
+
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("CONVOX_HOST=%s", rack), fmt.Sprintf("CONVOX_PASSWORD=%s", password))
+
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+	go outputWithPrefix(prefix, stdout, &wg)
+	go outputWithPrefix(prefix, stderr, &wg)
+	wg.Wait()
+
+	return cmd.Wait()
+}
+
+func outputWithPrefix(prefix string, r io.Reader, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		fmt.Printf("%s %s\n", prefix, scanner.Text())
+	}
+}
+
+// stripRackGroupArgs removes the rack group flags so each rack runs the plain command
+func stripRackGroupArgs(args []string) []string {
+	stripped := []string{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--racks" || arg == "-racks":
+			i++
+		case strings.HasPrefix(arg, "--racks=") || strings.HasPrefix(arg, "-racks="):
+		case arg == "--serial" || arg == "-serial" || arg == "--yes" || arg == "-yes":
+		case strings.HasPrefix(arg, "--serial=") || strings.HasPrefix(arg, "-serial="):
+		case strings.HasPrefix(arg, "--yes=") || strings.HasPrefix(arg, "-yes="):
+		default:
+			stripped = append(stripped, arg)
+		}
+	}
+
+	return stripped
+}
+
+func getRackGroup(name string) ([]string, error) {
+	groups, err := getRackGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	racks, ok := groups[name]
+	if !ok {
+		return nil, fmt.Errorf("no such rack group: %s", name)
+	}
+
+	if len(racks) == 0 {
+		return nil, fmt.Errorf("rack group is empty: %s", name)
+	}
+
+	return racks, nil
+}
+
+func getRackGroups() (ConfigGroups, error) {
+	data, _ := ioutil.ReadFile(filepath.Join(ConfigRoot, "groups"))
+
+	if data == nil {
+		data = []byte("{}")
+	}
+
+	var groups ConfigGroups
+
+	err := json.Unmarshal(data, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func saveRackGroups(groups ConfigGroups) error {
+	data, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(ConfigRoot, 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(ConfigRoot, "groups"), data, 0600)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRackGroupPs(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/foo/processes", Code: 200, Response: client.Processes{
+			client.Process{Id: "abc123", Name: "web", Release: "RABCDEFGHI", Size: 256, Command: "bin/web"},
+		}},
+	)
+
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+
+	config, err := ioutil.TempDir("", "convox")
+	assert.Nil(t, err)
+	defer os.RemoveAll(config)
+
+	ioutil.WriteFile(filepath.Join(config, "auth"), []byte(fmt.Sprintf(`{%q: "test"}`, u.Host)), 0600)
+	ioutil.WriteFile(filepath.Join(config, "groups"), []byte(fmt.Sprintf(`{"staging": [%q]}`, u.Host)), 0600)
+
+	test.Runs(t,
+		test.ExecRun{
+			Command:  "convox ps --app foo --racks staging --yes",
+			Env:      map[string]string{"CONVOX_CONFIG": config},
+			Exit:     0,
+			OutMatch: fmt.Sprintf("%s | abc123  web   RABCDEFGHI  256", u.Host),
+		},
+		test.ExecRun{
+			Command:  "convox ps --app foo --racks staging",
+			Env:      map[string]string{"CONVOX_CONFIG": config},
+			Exit:     0,
+			OutMatch: fmt.Sprintf("%s | abc123  web   RABCDEFGHI  256", u.Host),
+		},
+		test.ExecRun{
+			Command: "convox scale web --count 2 --app foo --racks staging",
+			Env:     map[string]string{"CONVOX_CONFIG": config},
+			Exit:    1,
+			Stderr:  "ERROR: Aborting. Use --yes for non-interactive rack group commands.",
+		},
+		test.ExecRun{
+			Command: "convox ps --app foo --racks prod --yes",
+			Env:     map[string]string{"CONVOX_CONFIG": config},
+			Exit:    1,
+			Stderr:  "ERROR: no such rack group: prod",
+		},
+	)
+}
+
+func TestStripRackGroupArgs(t *testing.T) {
+	assert.Equal(t, []string{"deploy", "--app", "foo"}, stripRackGroupArgs([]string{"deploy", "--racks", "prod", "--app", "foo", "--serial", "--yes"}))
+	assert.Equal(t, []string{"env", "set", "FOO=bar"}, stripRackGroupArgs([]string{"env", "set", "--racks=prod", "FOO=bar"}))
+	assert.Equal(t, []string{"scale", "web", "--count", "2"}, stripRackGroupArgs([]string{"scale", "web", "--count", "2", "--racks", "prod", "--serial=true", "--yes=true"}))
+}
@@ -1,20 +1,60 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/convox/rack/client"
 	"github.com/convox/rack/cmd/convox/stdcli"
 	"gopkg.in/urfave/cli.v1"
 )
 
+var drainAllFlag = cli.BoolFlag{
+	Name:  "all",
+	Usage: "rack-wide drains that cover every app",
+}
+
 func init() {
 	stdcli.RegisterCommand(cli.Command{
 		Name:        "logs",
 		Description: "stream the logs for an application",
 		Usage:       "",
 		Action:      cmdLogsStream,
+		Subcommands: []cli.Command{
+			{
+				Name:        "drains",
+				Description: "list log drains for an app",
+				Usage:       "",
+				Action:      cmdLogsDrains,
+				Flags:       []cli.Flag{appFlag, drainAllFlag},
+				Subcommands: []cli.Command{
+					{
+						Name:        "add",
+						Description: "forward logs to a syslog+tls://, syslog+tcp://, syslog+udp:// or https:// endpoint",
+						Usage:       "[app] <url>",
+						Action:      cmdLogsDrainAdd,
+						Flags: []cli.Flag{
+							appFlag,
+							drainAllFlag,
+							cli.StringFlag{
+								Name:  "format",
+								Value: "rfc5424",
+								Usage: "log line format: rfc5424, json or logfmt",
+							},
+						},
+					},
+					{
+						Name:        "remove",
+						Description: "remove a log drain",
+						Usage:       "[app] <id>",
+						Action:      cmdLogsDrainRemove,
+						Flags:       []cli.Flag{appFlag, drainAllFlag},
+					},
+				},
+			},
+		},
 		Flags: []cli.Flag{
 			appFlag,
 			cli.StringFlag{
@@ -30,6 +70,34 @@ func init() {
 				Usage: "Show logs since a duration, e.g. 10m or 1h2m10s.",
 				Value: 2 * time.Minute,
 			},
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "Show logs starting at a time (RFC3339) or a duration ago, e.g. 2016-09-01T10:00:00Z or 3h. Overrides --since.",
+			},
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "Show logs until a time (RFC3339) or a duration ago. Implies --follow=false.",
+			},
+			cli.StringFlag{
+				Name:  "process",
+				Usage: "Only return logs for a process.",
+			},
+			cli.StringFlag{
+				Name:  "release",
+				Usage: "Only return logs for a release.",
+			},
+			cli.StringFlag{
+				Name:  "instance",
+				Usage: "Only return logs for an instance or container id.",
+			},
+			cli.IntFlag{
+				Name:  "limit",
+				Usage: "Return at most this many log lines.",
+			},
+			cli.BoolFlag{
+				Name:  "json",
+				Usage: "Output one JSON record per line with the process, release and container of each line.",
+			},
 		},
 	})
 }
@@ -44,9 +112,194 @@ func cmdLogsStream(c *cli.Context) error {
 		return stdcli.ExitError(fmt.Errorf("`convox logs` does not take arguments. Perhaps you meant `convox logs`?"))
 	}
 
-	err = rackClient(c).StreamAppLogs(app, c.String("filter"), c.BoolT("follow"), c.Duration("since"), os.Stdout)
+	opts := client.LogOptions{
+		Filter:   c.String("filter"),
+		Follow:   c.BoolT("follow"),
+		Instance: c.String("instance"),
+		Limit:    c.Int("limit"),
+		Process:  c.String("process"),
+		Release:  c.String("release"),
+		Since:    c.Duration("since"),
+	}
+
+	if from := c.String("from"); from != "" {
+		opts.Start, err = parseLogTime(from)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+	}
+
+	if to := c.String("to"); to != "" {
+		opts.End, err = parseLogTime(to)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		opts.Follow = false
+	}
+
+	if c.Bool("json") {
+		err = queryLogs(rackClient(c), app, opts)
+	} else {
+		err = rackClient(c).StreamAppLogsOptions(app, opts, os.Stdout)
+	}
 	if err != nil {
 		return stdcli.ExitError(err)
 	}
 	return nil
 }
+
+// queryLogs prints log records as JSON, polling for new records when following
+func queryLogs(rc *client.Client, app string, opts client.LogOptions) error {
+	if opts.Start.IsZero() {
+		opts.Start = time.Now().Add(-opts.Since)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+
+	for {
+		records, err := rc.QueryAppLogs(app, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+
+			if next := r.Timestamp.Add(time.Millisecond); next.After(opts.Start) {
+				opts.Start = next
+			}
+		}
+
+		if opts.Limit > 0 {
+			opts.Limit -= len(records)
+
+			if opts.Limit <= 0 {
+				return nil
+			}
+		}
+
+		if !opts.Follow {
+			return nil
+		}
+
+		time.Sleep(1 * time.Second)
+	}
+}
+
+func parseLogTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %s, use RFC3339 or a duration like 1h", s)
+	}
+
+	return t, nil
+}
+
+func cmdLogsDrains(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox logs drains` does not take arguments. Perhaps you meant `convox logs drains add`?"))
+	}
+
+	app, err := drainApp(c, nil)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	drains, err := rackClient(c).ListDrains(app)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t := stdcli.NewTable("ID", "APP", "FORMAT", "URL", "DELIVERED", "QUEUED", "DROPPED", "FAILED", "LAST ERROR")
+
+	for _, d := range drains {
+		t.AddRow(d.Id, stdcli.Default(d.App, "(all)"), d.Format, d.Url,
+			fmt.Sprintf("%d", d.Status.Delivered),
+			fmt.Sprintf("%d", d.Status.Queued),
+			fmt.Sprintf("%d", d.Status.Dropped),
+			fmt.Sprintf("%d", d.Status.Failed),
+			d.Status.LastError,
+		)
+	}
+
+	t.Print()
+	return nil
+}
+
+func cmdLogsDrainAdd(c *cli.Context) error {
+	args := c.Args()
+
+	if len(args) < 1 || len(args) > 2 {
+		stdcli.Usage(c, "add")
+		return nil
+	}
+
+	app, err := drainApp(c, args[:len(args)-1])
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Print("Adding drain... ")
+
+	d, err := rackClient(c).CreateDrain(app, args[len(args)-1], c.String("format"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println(d.Id)
+	return nil
+}
+
+func cmdLogsDrainRemove(c *cli.Context) error {
+	args := c.Args()
+
+	if len(args) < 1 || len(args) > 2 {
+		stdcli.Usage(c, "remove")
+		return nil
+	}
+
+	app, err := drainApp(c, args[:len(args)-1])
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Printf("Removing drain %s... ", args[len(args)-1])
+
+	_, err = rackClient(c).DeleteDrain(app, args[len(args)-1])
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+	return nil
+}
+
+// drainApp returns the app named in args, by --app or by the current directory,
+// or an empty app for rack-wide drains with --all
+func drainApp(c *cli.Context, args []string) (string, error) {
+	if c.Bool("all") {
+		if len(args) > 0 {
+			return "", fmt.Errorf("--all can not be used with an app")
+		}
+
+		return "", nil
+	}
+
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return "", err
+	}
+
+	return app, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestLogsJson(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/logs/query", Code: 200, Response: client.LogRecords{
+			client.LogRecord{
+				Container: "0123456789ab",
+				Message:   "GET / 200",
+				Process:   "web",
+				Release:   "RABCDEFGHI",
+				Stream:    "web",
+				Timestamp: time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC),
+			},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox logs --app myapp --process web --from 2016-09-01T10:00:00Z --to 2016-09-01T11:00:00Z --json",
+			Exit:    0,
+			Stdout:  `{"container":"0123456789ab","message":"GET / 200","process":"web","release":"RABCDEFGHI","stream":"web","timestamp":"2016-09-01T10:00:00Z"}` + "\n",
+		},
+	)
+}
+
+func TestLogsInvalidFrom(t *testing.T) {
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox logs --app myapp --from yesterday",
+			Exit:    1,
+			Stderr:  "ERROR: invalid time yesterday, use RFC3339 or a duration like 1h\n",
+		},
+	)
+}
+
+func TestLogsDrains(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/drains", Code: 200, Response: client.Drains{
+			client.Drain{Id: "DABCDEFGHIJ", App: "myapp", Format: "rfc5424", Url: "syslog+tls://logs.example.org:6514", Status: client.DrainStatus{Delivered: 10, Queued: 2}},
+			client.Drain{Id: "DKLMNOPQRST", Format: "json", Url: "https://logs.example.org", Status: client.DrainStatus{Failed: 1, LastError: "timeout"}},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox logs drains --app myapp",
+			Exit:    0,
+			Stdout:  "ID           APP    FORMAT   URL                                 DELIVERED  QUEUED  DROPPED  FAILED  LAST ERROR\nDABCDEFGHIJ  myapp  rfc5424  syslog+tls://logs.example.org:6514  10         2       0        0                 \nDKLMNOPQRST  (all)  json     https://logs.example.org            0          0       0        1       timeout   \n",
+		},
+	)
+}
+
+func TestLogsDrainsAdd(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/apps/myapp/drains", Body: "format=logfmt&url=syslog%2Btls%3A%2F%2Flogs.example.org%3A6514", Code: 200, Response: client.Drain{Id: "DABCDEFGHIJ"}},
+		test.Http{Method: "POST", Path: "/drains", Body: "format=rfc5424&url=https%3A%2F%2Flogs.example.org", Code: 200, Response: client.Drain{Id: "DKLMNOPQRST"}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox logs drains add myapp syslog+tls://logs.example.org:6514 --format logfmt",
+			Exit:    0,
+			Stdout:  "Adding drain... DABCDEFGHIJ\n",
+		},
+		test.ExecRun{
+			Command: "convox logs drains add --all https://logs.example.org",
+			Exit:    0,
+			Stdout:  "Adding drain... DKLMNOPQRST\n",
+		},
+	)
+}
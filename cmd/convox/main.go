@@ -50,3 +50,17 @@ func rackClient(c *cli.Context) *client.Client {
 
 	return client.New(host, password, c.App.Version)
 }
+
+// rackClientHost returns a client for another rack host using its stored login
+func rackClientHost(c *cli.Context, host string) (*client.Client, error) {
+	password, err := getLogin(host)
+	if err != nil {
+		return nil, err
+	}
+
+	if password == "" {
+		return nil, fmt.Errorf("no login for %s, try `convox login %s`", host, host)
+	}
+
+	return client.New(host, password, c.App.Version), nil
+}
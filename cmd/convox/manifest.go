@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/convox/rack/api/manifest"
+	"github.com/convox/rack/cmd/convox/stdcli"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func init() {
+	stdcli.RegisterCommand(cli.Command{
+		Name:        "manifest",
+		Description: "work with the app manifest",
+		Usage:       "",
+		Action:      cmdManifest,
+		Subcommands: []cli.Command{
+			{
+				Name:        "validate",
+				Description: "check a manifest for problems before building or deploying",
+				Usage:       "[-f file] [--json] [--app <app>]",
+				Action:      cmdManifestValidate,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Value: "docker-compose.yml",
+						Usage: "path to an alternate docker compose manifest file",
+					},
+					cli.BoolFlag{
+						Name:  "json",
+						Usage: "print problems as JSON",
+					},
+					cli.StringFlag{
+						Name:  "app, a",
+						Usage: "validate on the rack, checking environment references against this app",
+					},
+				},
+			},
+		},
+	})
+}
+
+func cmdManifest(c *cli.Context) error {
+	stdcli.Usage(c, "")
+	return nil
+}
+
+func cmdManifestValidate(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox manifest validate` does not take arguments"))
+	}
+
+	file := c.String("file")
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return stdcli.ExitError(fmt.Errorf("file not found: %s", file))
+	}
+
+	var problems manifest.Problems
+
+	if app := c.String("app"); app != "" {
+		pp, err := rackClient(c).ValidateManifest(data, app)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		for _, p := range pp {
+			problems = append(problems, manifest.Problem(p))
+		}
+	} else {
+		env, err := localEnv(filepath.Dir(file))
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		problems = manifest.Validate(data, env)
+	}
+
+	if c.Bool("json") {
+		out, err := json.MarshalIndent(problems, "", "  ")
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		fmt.Println(string(out))
+	} else {
+		for _, p := range problems {
+			if p.Line > 0 {
+				fmt.Printf("%s:%s\n", file, p)
+			} else {
+				fmt.Printf("%s: %s\n", file, p)
+			}
+		}
+
+		if len(problems) == 0 {
+			fmt.Println("OK")
+		} else {
+			fmt.Printf("%d errors, %d warnings\n", problems.Errors(), len(problems)-problems.Errors())
+		}
+	}
+
+	if problems.Errors() > 0 {
+		return cli.NewExitError("", 1)
+	}
+
+	return nil
+}
+
+// localEnv returns the environment convox start would give a manifest, the
+// shell environment with .env from dir on top
+func localEnv(dir string) (map[string]string, error) {
+	env := map[string]string{}
+
+	for _, kv := range os.Environ() {
+		parts := strings.SplitN(kv, "=", 2)
+		env[parts[0]] = parts[1]
+	}
+
+	fd, err := os.Open(filepath.Join(dir, ".env"))
+	if os.IsNotExist(err) {
+		return env, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	scanner := bufio.NewScanner(fd)
+
+	for scanner.Scan() {
+		if parts := strings.SplitN(scanner.Text(), "=", 2); len(parts) == 2 {
+			env[parts[0]] = parts[1]
+		}
+	}
+
+	return env, scanner.Err()
+}
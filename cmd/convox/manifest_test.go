@@ -0,0 +1,70 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestManifestValidate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "docker-compose.yml")
+
+	ioutil.WriteFile(file, []byte("web:\n  build: .\n  environment:\n    - TOKEN\n  ports:\n    - 80:3000\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("TOKEN=secret\n"), 0644)
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox manifest validate -f " + file,
+			Exit:    0,
+			Stdout:  "OK\n",
+		},
+	)
+
+	ioutil.WriteFile(file, []byte("web:\n  build: .\n  links:\n    - db\n  ports:\n    - 80:3000\n"), 0644)
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox manifest validate -f " + file,
+			Exit:    1,
+			Stdout:  file + ":4:7: error: web: link to unknown process: db\n1 errors, 0 warnings\n",
+		},
+	)
+}
+
+func TestManifestValidateApp(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/manifest/validate", Body: "app=myapp&manifest=web%3A%0A++build%3A+.%0A", Code: 200, Response: client.ManifestProblems{
+			{Line: 1, Column: 1, Level: "warning", Process: "web", Message: "unknown key: tough"},
+		}},
+	)
+
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "docker-compose.yml")
+
+	ioutil.WriteFile(file, []byte("web:\n  build: .\n"), 0644)
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox manifest validate --app myapp --json -f " + file,
+			Exit:    0,
+			Stdout:  "[\n  {\n    \"line\": 1,\n    \"column\": 1,\n    \"level\": \"warning\",\n    \"process\": \"web\",\n    \"message\": \"unknown key: tough\"\n  }\n]\n",
+		},
+	)
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/convox/rack/client"
 	"github.com/convox/rack/cmd/convox/stdcli"
+	"github.com/dustin/go-humanize"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -14,13 +17,21 @@ func init() {
 		Description: "list an app's processes",
 		Usage:       "",
 		Action:      cmdPs,
-		Flags: []cli.Flag{
+		Flags: withRackGroupFlags([]cli.Flag{
 			appFlag,
 			cli.BoolFlag{
 				Name:  "stats",
 				Usage: "display process cpu/memory stats",
 			},
-		},
+			cli.BoolFlag{
+				Name:  "all",
+				Usage: "also display processes that exited or were stopped",
+			},
+			cli.BoolFlag{
+				Name:  "local",
+				Usage: "display the processes started with convox start",
+			},
+		}),
 		Subcommands: []cli.Command{
 			{
 				Name:        "info",
@@ -29,6 +40,42 @@ func init() {
 				Action:      cmdPsInfo,
 				Flags:       []cli.Flag{appFlag},
 			},
+			{
+				Name:        "stats",
+				Description: "show cpu, memory, network and restart history for an app's processes",
+				Usage:       "",
+				Action:      cmdPsStats,
+				Flags: []cli.Flag{
+					appFlag,
+					cli.StringFlag{
+						Name:  "process",
+						Usage: "only show metrics for a process",
+					},
+					cli.StringFlag{
+						Name:  "from",
+						Value: "1h",
+						Usage: "start time (RFC3339) or a duration ago, e.g. 2016-09-01T10:00:00Z or 3h",
+					},
+					cli.StringFlag{
+						Name:  "to",
+						Usage: "end time (RFC3339) or a duration ago",
+					},
+					cli.DurationFlag{
+						Name:  "step",
+						Value: 5 * time.Minute,
+						Usage: "time between samples",
+					},
+					cli.BoolFlag{
+						Name:  "watch",
+						Usage: "refresh a live view of each process until interrupted",
+					},
+					cli.DurationFlag{
+						Name:  "interval",
+						Value: 5 * time.Second,
+						Usage: "time between refreshes with --watch",
+					},
+				},
+			},
 			{
 				Name:        "stop",
 				Description: "stop a process",
@@ -41,6 +88,14 @@ func init() {
 }
 
 func cmdPs(c *cli.Context) error {
+	if c.String("racks") != "" {
+		return runRackGroup(c, false, false)
+	}
+
+	if c.Bool("local") {
+		return cmdPsLocal(c)
+	}
+
 	_, app, err := stdcli.DirApp(c, ".")
 	if err != nil {
 		return stdcli.ExitError(err)
@@ -69,6 +124,69 @@ func cmdPs(c *cli.Context) error {
 		t.Print()
 	}
 
+	if c.Bool("all") {
+		stopped, err := rackClient(c).GetStoppedProcesses(app)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		fmt.Println()
+
+		t := stdcli.NewTable("ID", "NAME", "RELEASE", "STATUS", "STOPPED", "REASON")
+
+		for _, p := range stopped {
+			status := "stopped"
+
+			if p.Crashed {
+				status = "crashed"
+			}
+
+			t.AddRow(p.Id, p.Name, p.Release, status, humanizeTime(p.Stopped), p.Description)
+		}
+
+		t.Print()
+
+		// the last lines logged before each process stopped
+		for _, p := range stopped {
+			if len(p.Logs) == 0 {
+				continue
+			}
+
+			fmt.Printf("\n%s %s:\n", p.Id, p.Name)
+
+			for _, line := range p.Logs {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+	}
+
+	return nil
+}
+
+func cmdPsLocal(c *cli.Context) error {
+	dir, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	m, err := localManifest(dir, "docker-compose.yml")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	ps, err := m.LocalProcesses(app)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t := stdcli.NewTable("NAME", "CONTAINER", "STATUS", "STARTED", "IMAGE")
+
+	for _, p := range ps {
+		t.AddRow(p.Name, p.Container, p.Status, humanizeTime(p.Started), p.Image)
+	}
+
+	t.Print()
+
 	return nil
 }
 
@@ -103,6 +221,123 @@ func cmdPsInfo(c *cli.Context) error {
 	return nil
 }
 
+func cmdPsStats(c *cli.Context) error {
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox ps stats` does not take arguments. Perhaps you meant `convox ps stats --watch`?"))
+	}
+
+	if c.Bool("watch") {
+		return watchPsStats(c, app)
+	}
+
+	opts := client.MetricsOptions{
+		Process: c.String("process"),
+		Step:    c.Duration("step"),
+	}
+
+	opts.Start, err = parseLogTime(c.String("from"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if to := c.String("to"); to != "" {
+		opts.End, err = parseLogTime(to)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+	}
+
+	samples, err := rackClient(c).GetAppMetrics(app, opts)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t := stdcli.NewTable("TIME", "PROCESS", "COUNT", "CPU", "MEM", "NET IN", "NET OUT", "RESTARTS")
+
+	for _, s := range samples {
+		t.AddRow(s.Timestamp.Format(time.RFC3339), s.Process, fmt.Sprintf("%0.1f", s.Count),
+			fmt.Sprintf("%0.2f%%", s.Cpu), fmt.Sprintf("%0.2f%%", s.Memory*100),
+			humanize.Bytes(uint64(s.NetworkRx)), humanize.Bytes(uint64(s.NetworkTx)), fmt.Sprintf("%0.0f", s.Restarts))
+	}
+
+	t.Print()
+	return nil
+}
+
+// watchPsStats redraws live process stats along with the recent network traffic
+// and restarts of each process until interrupted
+func watchPsStats(c *cli.Context, app string) error {
+	rc := rackClient(c)
+	process := c.String("process")
+
+	for {
+		ps, err := rc.GetProcesses(app, true)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		samples, err := rc.GetAppMetrics(app, client.MetricsOptions{
+			Process: process,
+			Start:   time.Now().Add(-1 * time.Hour),
+			Step:    5 * time.Minute,
+		})
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		// clear the screen and move to the top left
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("%s  %s\n\n", app, time.Now().Format("15:04:05"))
+
+		t := stdcli.NewTable("ID", "NAME", "RELEASE", "CPU", "MEM", "STARTED")
+
+		for _, p := range ps {
+			if process != "" && p.Name != process {
+				continue
+			}
+
+			t.AddRow(prettyId(p), p.Name, p.Release, fmt.Sprintf("%0.2f%%", p.Cpu), fmt.Sprintf("%0.2f%%", p.Memory*100), humanizeTime(p.Started))
+		}
+
+		t.Print()
+		fmt.Println()
+
+		totals := map[string]*client.MetricSample{}
+		names := []string{}
+
+		for _, s := range samples {
+			total, ok := totals[s.Process]
+			if !ok {
+				total = &client.MetricSample{Process: s.Process}
+				totals[s.Process] = total
+				names = append(names, s.Process)
+			}
+
+			total.NetworkRx += s.NetworkRx
+			total.NetworkTx += s.NetworkTx
+			total.Restarts += s.Restarts
+		}
+
+		sort.Strings(names)
+
+		t = stdcli.NewTable("PROCESS", "NET IN (1H)", "NET OUT (1H)", "RESTARTS (1H)")
+
+		for _, name := range names {
+			s := totals[name]
+			t.AddRow(s.Process, humanize.Bytes(uint64(s.NetworkRx)), humanize.Bytes(uint64(s.NetworkTx)), fmt.Sprintf("%0.0f", s.Restarts))
+		}
+
+		t.Print()
+
+		time.Sleep(c.Duration("interval"))
+	}
+}
+
 func cmdPsStop(c *cli.Context) error {
 	_, app, err := stdcli.DirApp(c, ".")
 	if err != nil {
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestPsStats(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/metrics", Code: 200, Response: client.MetricSamples{
+			client.MetricSample{
+				Process:   "web",
+				Timestamp: time.Date(2016, 9, 1, 10, 0, 0, 0, time.UTC),
+				Count:     2,
+				Cpu:       12.5,
+				Memory:    0.25,
+				NetworkRx: 2048000,
+				NetworkTx: 1024000,
+				Restarts:  1,
+			},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox ps stats --app myapp --from 2016-09-01T10:00:00Z --to 2016-09-01T11:00:00Z",
+			Exit:    0,
+			Stdout:  "TIME                  PROCESS  COUNT  CPU     MEM     NET IN  NET OUT  RESTARTS\n2016-09-01T10:00:00Z  web      2.0    12.50%  25.00%  2.0MB   1.0MB    1       \n",
+		},
+	)
+}
+
+func TestPsStatsInvalidFrom(t *testing.T) {
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox ps stats --app myapp --from yesterday",
+			Exit:    1,
+			Stderr:  "ERROR: invalid time yesterday, use RFC3339 or a duration like 1h\n",
+		},
+	)
+}
+
+func TestPsAll(t *testing.T) {
+	exit := int64(1)
+	stopped := time.Now().Add(-5 * time.Minute)
+
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/processes", Query: "stopped=true", Code: 200, Response: client.StoppedProcesses{
+			client.StoppedProcess{Id: "0123456789ab", Name: "web", Release: "RABCDEFGHI", Crashed: true, ExitCode: &exit, Description: "exit 1: Essential container in task exited", Stopped: stopped, Logs: []string{"starting", "panic: boom"}},
+			client.StoppedProcess{Id: "cdef01234567", Name: "worker", Release: "RABCDEFGHI", Description: "Scaling activity", Stopped: stopped},
+		}},
+		test.Http{Method: "GET", Path: "/apps/myapp/processes", Code: 200, Response: client.Processes{
+			client.Process{Id: "89abcdef0123", Name: "web", Release: "RABCDEFGHI", Size: 256, Command: "bin/web"},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox ps --all --app myapp",
+			Exit:    0,
+			Stdout:  "ID            NAME  RELEASE     SIZE  STARTED  COMMAND\n89abcdef0123  web   RABCDEFGHI  256            bin/web\n\nID            NAME    RELEASE     STATUS   STOPPED        REASON                                    \n0123456789ab  web     RABCDEFGHI  crashed  5 minutes ago  exit 1: Essential container in task exited\ncdef01234567  worker  RABCDEFGHI  stopped  5 minutes ago  Scaling activity                          \n\n0123456789ab web:\n  starting\n  panic: boom\n",
+		},
+	)
+}
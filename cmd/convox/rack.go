@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/convox/rack/client"
 	"github.com/convox/rack/cmd/convox/stdcli"
 	"github.com/convox/version"
 	"gopkg.in/urfave/cli.v1"
@@ -34,6 +37,27 @@ func init() {
 					},
 				},
 			},
+			{
+				Name:        "logs",
+				Description: "stream the logs for the rack api, monitor, builds and formation lambda",
+				Usage:       "",
+				Action:      cmdRackLogs,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "filter",
+						Usage: "Only return logs that match a filter pattern. If not specified, return all logs.",
+					},
+					cli.BoolTFlag{
+						Name:  "follow",
+						Usage: "Follow log output (default).",
+					},
+					cli.DurationFlag{
+						Name:  "since",
+						Usage: "Show logs since a duration, e.g. 10m or 1h2m10s.",
+						Value: 2 * time.Minute,
+					},
+				},
+			},
 			{
 				Name:        "scale",
 				Description: "scale the rack capacity",
@@ -96,6 +120,23 @@ func cmdRack(c *cli.Context) error {
 	return nil
 }
 
+func cmdRackLogs(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox rack logs` does not take arguments."))
+	}
+
+	err := rackClient(c).StreamSystemLogs(client.LogOptions{
+		Filter: c.String("filter"),
+		Follow: c.BoolT("follow"),
+		Since:  c.Duration("since"),
+	}, os.Stdout)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	return nil
+}
+
 func cmdRackParams(c *cli.Context) error {
 	system, err := rackClient(c).GetSystem()
 	if err != nil {
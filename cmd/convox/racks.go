@@ -13,6 +13,28 @@ func init() {
 		Description: "list your Convox racks",
 		Usage:       "",
 		Action:      cmdRacks,
+		Subcommands: []cli.Command{
+			{
+				Name:        "groups",
+				Description: "list rack groups",
+				Usage:       "",
+				Action:      cmdRackGroups,
+				Subcommands: []cli.Command{
+					{
+						Name:        "set",
+						Description: "create or replace a rack group",
+						Usage:       "<group> <rack> [rack...]",
+						Action:      cmdRackGroupSet,
+					},
+					{
+						Name:        "delete",
+						Description: "delete a rack group",
+						Usage:       "<group>",
+						Action:      cmdRackGroupDelete,
+					},
+				},
+			},
+		},
 	})
 }
 
@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/convox/rack/client"
 	"github.com/convox/rack/cmd/convox/stdcli"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -38,6 +40,20 @@ func init() {
 				Action:      cmdReleaseInfo,
 				Flags:       []cli.Flag{appFlag},
 			},
+			{
+				Name:        "diff",
+				Description: "show what changes between two releases",
+				Usage:       "<release id> <release id>",
+				Action:      cmdReleaseDiff,
+				Flags:       []cli.Flag{appFlag},
+			},
+			{
+				Name:        "template",
+				Description: "render the CloudFormation template a promote of a release would apply",
+				Usage:       "<release id> [--parameters] [--diff]",
+				Action:      cmdReleaseTemplate,
+				Flags:       append([]cli.Flag{appFlag}, templateFlags...),
+			},
 			{
 				Name:        "promote",
 				Description: "promote a release",
@@ -140,3 +156,155 @@ func cmdReleasePromote(c *cli.Context) error {
 	fmt.Println("UPDATING")
 	return nil
 }
+
+func cmdReleaseDiff(c *cli.Context) error {
+	if len(c.Args()) != 2 {
+		stdcli.Usage(c, "diff")
+		return nil
+	}
+
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	d, err := rackClient(c).DiffReleases(app, c.Args()[0], c.Args()[1])
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Printf("Comparing %s to %s\n", d.From, d.To)
+
+	if d.Build != nil {
+		fmt.Printf("\nBuild\n  %s -> %s\n", d.Build.From, d.Build.To)
+	}
+
+	if len(d.Env) > 0 {
+		fmt.Println("\nEnv")
+
+		for _, e := range d.Env {
+			fmt.Printf("  %s %s\n", changeSymbol(e.Action), e.Name)
+		}
+	}
+
+	if len(d.Processes) > 0 {
+		fmt.Println("\nProcesses")
+
+		for _, p := range d.Processes {
+			fmt.Printf("  %s %s\n", changeSymbol(p.Action), p.Process)
+
+			if p.Action != "changed" {
+				continue
+			}
+
+			for _, ch := range p.Changes {
+				fmt.Printf("      %s\n", describeChange(ch))
+			}
+		}
+	}
+
+	if len(d.Parameters) > 0 {
+		fmt.Printf("\nParameters changed by promoting %s\n", d.To)
+
+		for _, p := range d.Parameters {
+			fmt.Printf("  %s\n", describeChange(p))
+		}
+	}
+
+	if d.Build == nil && len(d.Env) == 0 && len(d.Processes) == 0 && len(d.Parameters) == 0 {
+		fmt.Println("\nNo changes")
+	}
+
+	return nil
+}
+
+func changeSymbol(action string) string {
+	switch action {
+	case "added":
+		return "+"
+	case "removed":
+		return "-"
+	}
+
+	return "~"
+}
+
+func describeChange(ch client.ReleaseChange) string {
+	switch {
+	case ch.Action == "added":
+		return fmt.Sprintf("+ %s: %s", ch.Name, ch.To)
+	case ch.Action == "removed":
+		return fmt.Sprintf("- %s: %s", ch.Name, ch.From)
+	}
+
+	return fmt.Sprintf("~ %s: %s -> %s", ch.Name, ch.From, ch.To)
+}
+
+// flags shared by the commands that render an app template
+var templateFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "parameters",
+		Usage: "show the stack parameters instead of the template",
+	},
+	cli.BoolFlag{
+		Name:  "diff",
+		Usage: "compare the template and parameters against the stack as it runs now",
+	},
+}
+
+func cmdReleaseTemplate(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "template")
+		return nil
+	}
+
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t, err := rackClient(c).GetReleaseTemplate(app, c.Args()[0], c.Bool("diff"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	printTemplate(c, t)
+	return nil
+}
+
+func printTemplate(c *cli.Context, t *client.ReleaseTemplate) {
+	switch {
+	case c.Bool("diff"):
+		if t.TemplateDiff == "" {
+			fmt.Println("Template unchanged")
+		} else {
+			fmt.Print(t.TemplateDiff)
+		}
+
+		if len(t.ParameterChanges) > 0 {
+			fmt.Println("\nParameters")
+
+			for _, p := range t.ParameterChanges {
+				fmt.Printf("  %s\n", describeChange(p))
+			}
+		}
+	case c.Bool("parameters"):
+		names := []string{}
+
+		for name := range t.Parameters {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		tb := stdcli.NewTable("NAME", "VALUE")
+
+		for _, name := range names {
+			tb.AddRow(name, t.Parameters[name])
+		}
+
+		tb.Print()
+	default:
+		fmt.Println(strings.TrimSpace(t.Template))
+	}
+}
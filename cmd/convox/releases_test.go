@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestReleasesDiff(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/releases/RAAAAAAAAAA/diff/RBBBBBBBBBB", Code: 200, Response: client.ReleaseDiff{
+			App:   "myapp",
+			From:  "RAAAAAAAAAA",
+			To:    "RBBBBBBBBBB",
+			Build: &client.ReleaseChange{Name: "build", Action: "changed", From: "BAAAAAAAAAA", To: "BBBBBBBBBBB"},
+			Env: client.ReleaseChanges{
+				{Name: "NEW", Action: "added"},
+				{Name: "SECRET", Action: "changed"},
+			},
+			Processes: []client.ProcessChange{
+				{Process: "web", Action: "changed", Changes: client.ReleaseChanges{
+					{Name: "command", Action: "changed", From: "bin/web", To: "bin/server"},
+				}},
+				{Process: "worker", Action: "added"},
+			},
+			Parameters: client.ReleaseChanges{
+				{Name: "Release", Action: "changed", From: "RAAAAAAAAAA", To: "RBBBBBBBBBB"},
+			},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox releases diff RAAAAAAAAAA RBBBBBBBBBB --app myapp",
+			Exit:    0,
+			Stdout:  "Comparing RAAAAAAAAAA to RBBBBBBBBBB\n\nBuild\n  BAAAAAAAAAA -> BBBBBBBBBBB\n\nEnv\n  + NEW\n  ~ SECRET\n\nProcesses\n  ~ web\n      ~ command: bin/web -> bin/server\n  + worker\n\nParameters changed by promoting RBBBBBBBBBB\n  ~ Release: RAAAAAAAAAA -> RBBBBBBBBBB\n",
+		},
+	)
+}
+
+func TestReleasesTemplate(t *testing.T) {
+	tmpl := client.ReleaseTemplate{
+		App:        "myapp",
+		Release:    "RBBBBBBBBBB",
+		Template:   "{\n  \"Resources\": {}\n}\n",
+		Parameters: map[string]string{"Release": "RBBBBBBBBBB", "Key": "(hidden)"},
+	}
+
+	diff := tmpl
+	diff.TemplateDiff = "--- deployed\n+++ RBBBBBBBBBB\n"
+	diff.ParameterChanges = client.ReleaseChanges{{Name: "Release", Action: "changed", From: "RAAAAAAAAAA", To: "RBBBBBBBBBB"}}
+
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/releases/RBBBBBBBBBB/template", Code: 200, Response: tmpl},
+	)
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox releases template RBBBBBBBBBB --app myapp",
+			Exit:    0,
+			Stdout:  "{\n  \"Resources\": {}\n}\n",
+		},
+		test.ExecRun{
+			Command: "convox releases template RBBBBBBBBBB --app myapp --parameters",
+			Exit:    0,
+			Stdout:  "NAME     VALUE      \nKey      (hidden)   \nRelease  RBBBBBBBBBB\n",
+		},
+	)
+
+	ts.Close()
+
+	ts = testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/releases/RBBBBBBBBBB/template", Code: 200, Response: diff},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox releases template RBBBBBBBBBB --app myapp --diff",
+			Exit:    0,
+			Stdout:  "--- deployed\n+++ RBBBBBBBBBB\n\nParameters\n  ~ Release: RAAAAAAAAAA -> RBBBBBBBBBB\n",
+		},
+	)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/convox/rack/cmd/convox/stdcli"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var reviewNameInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+func init() {
+	stdcli.RegisterCommand(cli.Command{
+		Name:        "review",
+		Description: "manage temporary apps built from a branch",
+		Usage:       "",
+		Action:      cmdReviews,
+		Subcommands: []cli.Command{
+			{
+				Name:        "create",
+				Description: "create a review app from a branch of an existing app",
+				Usage:       "--branch <branch> --from <app>",
+				Action:      cmdReviewCreate,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "branch",
+						Usage: "git branch to build",
+					},
+					cli.StringFlag{
+						Name:  "from",
+						Usage: "app to copy environment, links, parameters and formation from",
+					},
+					cli.StringFlag{
+						Name:  "name",
+						Usage: "name of the review app (defaults to <from>-<branch>)",
+					},
+					cli.StringFlag{
+						Name:  "repository",
+						Usage: "git repository to build from (defaults to the Repository parameter of the source app)",
+					},
+					cli.DurationFlag{
+						Name:  "ttl",
+						Value: 72 * time.Hour,
+						Usage: "delete the review app after this long, 0 to keep it until the branch is deleted",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Value: "docker-compose.yml",
+						Usage: "path to an alternate docker compose manifest file",
+					},
+					cli.BoolFlag{
+						Name:  "no-cache",
+						Usage: "pull fresh image dependencies",
+					},
+				},
+			},
+		},
+	})
+}
+
+func cmdReviews(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox review` does not take arguments. Perhaps you meant `convox review create`?"))
+	}
+
+	return listApps(c, true)
+}
+
+func cmdReviewCreate(c *cli.Context) error {
+	branch := c.String("branch")
+	source := c.String("from")
+
+	if branch == "" || source == "" || len(c.Args()) > 0 {
+		stdcli.Usage(c, "create")
+		return nil
+	}
+
+	rc := rackClient(c)
+
+	t, err := appTemplate(rc, source)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if repo := c.String("repository"); repo != "" {
+		t.Parameters["Repository"] = repo
+	}
+
+	repo := t.Parameters["Repository"]
+
+	if repo == "" {
+		return stdcli.ExitError(fmt.Errorf("no repository for %s, use --repository", source))
+	}
+
+	app := c.String("name")
+
+	if app == "" {
+		app = reviewAppName(source, branch)
+	}
+
+	fmt.Printf("Creating review app %s... ", app)
+
+	_, err = rc.CreateReviewApp(app, source, branch, c.Duration("ttl"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	err = waitForAppRunning(rc, app)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+
+	err = applyAppTemplateConfig(rc, app, *t)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	release, err := executeBuildUrl(c, fmt.Sprintf("%s#%s", repo, branch), app, c.String("file"), fmt.Sprintf("Review of %s", branch))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if release != "" {
+		fmt.Printf("Promoting %s... ", release)
+
+		_, err = rc.PromoteRelease(app, release)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		err = waitForAppRunning(rc, app)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		fmt.Println("OK")
+	}
+
+	err = applyAppTemplateScaling(rc, app, *t)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	formation, err := rc.ListFormation(app)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	for _, f := range formation {
+		for _, port := range f.Ports {
+			fmt.Printf("Endpoint: %s:%d (%s)\n", f.Balancer, port, f.Name)
+		}
+	}
+
+	return nil
+}
+
+// reviewAppName derives a valid app name from the source app and branch
+func reviewAppName(source, branch string) string {
+	name := fmt.Sprintf("%s-%s", source, reviewNameInvalid.ReplaceAllString(strings.ToLower(branch), "-"))
+
+	if len(name) > 30 {
+		name = name[0:30]
+	}
+
+	return strings.TrimRight(name, "-")
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewAppName(t *testing.T) {
+	assert.Equal(t, "myapp-feature-x", reviewAppName("myapp", "feature-x"))
+	assert.Equal(t, "myapp-user-fix-login", reviewAppName("myapp", "User/Fix_Login"))
+	assert.Equal(t, "myapp-a-very-long-branch-name", reviewAppName("myapp", "a-very-long-branch-name-for-review"))
+}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/ssh/terminal"
 
+	"github.com/convox/rack/client"
 	"github.com/convox/rack/cmd/convox/stdcli"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -28,8 +30,32 @@ func init() {
 				Name:  "release, r",
 				Usage: "Release Name. Defaults to current release.",
 			},
+			cli.IntFlag{
+				Name:  "cpu",
+				Usage: "cpu units to reserve for the process, 1024 is one core",
+			},
+			cli.IntFlag{
+				Name:  "memory",
+				Usage: "memory limit for the process in megabytes",
+			},
+			cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "stop the process after a duration, e.g. 30m",
+			},
+			cli.BoolFlag{
+				Name:  "local",
+				Usage: "run against the processes started with convox start",
+			},
 		},
 	})
+
+	stdcli.RegisterCommand(cli.Command{
+		Name:        "runs",
+		Description: "list detached one-off processes",
+		Usage:       "",
+		Action:      cmdRuns,
+		Flags:       []cli.Flag{appFlag},
+	})
 }
 
 func cmdRun(c *cli.Context) error {
@@ -37,6 +63,10 @@ func cmdRun(c *cli.Context) error {
 		return cmdRunDetached(c)
 	}
 
+	if c.Bool("local") {
+		return cmdRunLocal(c)
+	}
+
 	_, app, err := stdcli.DirApp(c, ".")
 	if err != nil {
 		return stdcli.ExitError(err)
@@ -93,15 +123,91 @@ func cmdRunDetached(c *cli.Context) error {
 
 	fmt.Printf("Running `%s` on %s... ", command, ps)
 
-	err = rackClient(c).RunProcessDetached(app, ps, command, release)
+	run, err := rackClient(c).RunProcessDetached(app, ps, command, release, runOptions(c))
 	if err != nil {
 		return err
 	}
 
-	fmt.Println("OK")
+	fmt.Printf("OK, %s\n", run.Id)
+
+	if run.Logs != "" {
+		fmt.Printf("Logs: %s\n", run.Logs)
+	}
+
+	return nil
+}
+
+func cmdRunLocal(c *cli.Context) error {
+	dir, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if len(c.Args()) < 2 {
+		stdcli.Usage(c, "run")
+		return nil
+	}
+
+	m, err := localManifest(dir, "docker-compose.yml")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	tty := terminal.IsTerminal(int(os.Stdin.Fd()))
+
+	code, err := m.RunLocal(app, c.Args()[0], strings.Join(c.Args()[1:], " "), tty)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	return cli.NewExitError("", code)
+}
+
+func cmdRuns(c *cli.Context) error {
+	_, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox runs` does not take arguments. Perhaps you meant `convox run`?"))
+	}
+
+	runs, err := rackClient(c).GetRuns(app)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	t := stdcli.NewTable("ID", "PROCESS", "RELEASE", "CLAIMED USER", "STATUS", "EXIT", "STARTED", "DURATION", "COMMAND")
+
+	for _, r := range runs {
+		exit := ""
+
+		if r.ExitCode != nil {
+			exit = fmt.Sprintf("%d", *r.ExitCode)
+		}
+
+		duration := ""
+
+		if !r.Ended.IsZero() {
+			duration = (r.Ended.Sub(r.Started) / time.Second * time.Second).String()
+		}
+
+		t.AddRow(r.Id, r.Process, r.Release, r.ClaimedUser, r.Status, exit, humanizeTime(r.Started), duration, r.Command)
+	}
+
+	t.Print()
 	return nil
 }
 
+func runOptions(c *cli.Context) client.RunOptions {
+	return client.RunOptions{
+		Cpu:     c.Int("cpu"),
+		Memory:  c.Int("memory"),
+		Timeout: c.Duration("timeout"),
+	}
+}
+
 func runAttached(c *cli.Context, app, ps, args, release string) (int, error) {
 	fd := os.Stdin.Fd()
 
@@ -121,7 +227,7 @@ func runAttached(c *cli.Context, app, ps, args, release string) (int, error) {
 		}
 	}
 
-	code, err := rackClient(c).RunProcessAttached(app, ps, args, release, h, w, os.Stdin, os.Stdout)
+	code, err := rackClient(c).RunProcessAttached(app, ps, args, release, h, w, runOptions(c), os.Stdin, os.Stdout)
 	if err != nil {
 		return -1, err
 	}
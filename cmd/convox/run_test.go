@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestRuns(t *testing.T) {
+	started := time.Now().Add(-2 * time.Hour)
+	exit := 0
+
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/apps/myapp/runs", Code: 200, Response: client.Runs{
+			client.Run{Id: "0123456789ab", Process: "worker", Release: "RABCDEFGHI", ClaimedUser: "ops@laptop", Status: "exited", Started: started, Ended: started.Add(90 * time.Second), ExitCode: &exit, Command: "rake db:migrate"},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox runs --app myapp",
+			Exit:    0,
+			Stdout:  "ID            PROCESS  RELEASE     CLAIMED USER  STATUS  EXIT  STARTED      DURATION  COMMAND        \n0123456789ab  worker   RABCDEFGHI  ops@laptop    exited  0     2 hours ago  1m30s     rake db:migrate\n",
+		},
+	)
+}
@@ -13,7 +13,7 @@ func init() {
 		Description: "scale an app's processes",
 		Usage:       "<process> [--count=2] [--memory=512]",
 		Action:      cmdScale,
-		Flags: []cli.Flag{appFlag,
+		Flags: withRackGroupFlags([]cli.Flag{appFlag,
 			cli.IntFlag{
 				Name:  "count",
 				Usage: "Number of processes to keep running for specified process type.",
@@ -22,11 +22,16 @@ func init() {
 				Name:  "memory",
 				Usage: "Amount of memory, in MB, available to specified process type.",
 			},
-		},
+		}),
 	})
 }
 
 func cmdScale(c *cli.Context) error {
+	if c.String("racks") != "" {
+		// without a new count or memory scale only lists the formation
+		return runRackGroup(c, c.IsSet("count") || c.IsSet("memory"), false)
+	}
+
 	_, app, err := stdcli.DirApp(c, ".")
 	if err != nil {
 		return stdcli.ExitError(err)
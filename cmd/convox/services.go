@@ -83,6 +83,22 @@ func init() {
 				Action:          cmdServiceUpdate,
 				SkipFlagParsing: true,
 			},
+			{
+				Name:        "rotate",
+				Description: "generate new credentials for a service and update linked apps.\n\nLinked apps move between the master user and a secondary user. The old credentials stay valid until every linked app runs with the new ones and the grace period has passed.",
+				Usage:       "<name>",
+				Action:      cmdServiceRotate,
+				Flags: []cli.Flag{
+					cli.DurationFlag{
+						Name:  "grace",
+						Usage: "keep the old credentials valid this long after linked apps are promoted, e.g. 1h",
+					},
+					cli.BoolFlag{
+						Name:  "status",
+						Usage: "show the progress of the last rotation",
+					},
+				},
+			},
 			{
 				Name:        "info",
 				Description: "info about a service.",
@@ -258,6 +274,55 @@ func cmdServiceDelete(c *cli.Context) error {
 	return nil
 }
 
+func cmdServiceRotate(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "rotate")
+		return nil
+	}
+
+	name := c.Args()[0]
+
+	if c.Bool("status") {
+		r, err := rackClient(c).GetServiceRotation(name)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		step := r.Step
+
+		if r.Waiting {
+			step += " (waiting)"
+		}
+
+		fmt.Printf("Step     %s\n", step)
+		fmt.Printf("User     %s\n", r.User)
+		fmt.Printf("Active   %s\n", r.Active)
+		fmt.Printf("Apps     %s\n", strings.Join(r.Apps, ", "))
+		fmt.Printf("Started  %s\n", humanizeTime(r.Started))
+
+		if r.Step == "grace" {
+			fmt.Printf("Until    %s\n", humanizeTime(r.Until))
+		}
+
+		if r.Error != "" {
+			fmt.Printf("Error    %s\n", r.Error)
+		}
+
+		return nil
+	}
+
+	fmt.Printf("Rotating %s... ", name)
+
+	r, err := rackClient(c).RotateService(name, c.Duration("grace"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("ROTATING")
+	fmt.Printf("Moving %d linked apps from %s to %s, run `convox services rotate %s --status` to follow\n", len(r.Apps), r.Previous, r.User, name)
+	return nil
+}
+
 func cmdServiceInfo(c *cli.Context) error {
 	if len(c.Args()) != 1 {
 		stdcli.Usage(c, "info")
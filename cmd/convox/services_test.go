@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+)
+
+func TestServicesRotate(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/services/mydb/rotate", Body: "grace=1h0m0s", Code: 200, Response: client.ServiceRotation{Service: "mydb", Step: "credentials", User: "postgres_b", Previous: "postgres", Apps: []string{"myapp", "other"}}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox services rotate mydb --grace 1h",
+			Exit:    0,
+			Stdout:  "Rotating mydb... ROTATING\nMoving 2 linked apps from postgres to postgres_b, run `convox services rotate mydb --status` to follow\n",
+		},
+	)
+}
+
+func TestServicesRotateStatus(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/services/mydb/rotation", Code: 200, Response: client.ServiceRotation{Service: "mydb", Step: "apps", Waiting: true, User: "postgres_b", Active: "postgres", Apps: []string{"myapp"}}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox services rotate mydb --status",
+			Exit:    0,
+			Stdout:  "Step     apps (waiting)\nUser     postgres_b\nActive   postgres\nApps     myapp\nStarted  \n",
+		},
+	)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/convox/rack/cmd/convox/stdcli"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func init() {
+	stdcli.RegisterCommand(cli.Command{
+		Name:        "sessions",
+		Description: "list recorded exec, run and ssh sessions",
+		Usage:       "",
+		Action:      cmdSessions,
+		Subcommands: []cli.Command{
+			{
+				Name:        "enable",
+				Description: "record exec, run and ssh sessions",
+				Usage:       "",
+				Action:      cmdSessionsEnable,
+			},
+			{
+				Name:        "disable",
+				Description: "stop recording sessions",
+				Usage:       "",
+				Action:      cmdSessionsDisable,
+			},
+			{
+				Name:        "play",
+				Description: "replay a recorded session",
+				Usage:       "<id>",
+				Action:      cmdSessionPlay,
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "speed",
+						Value: 1,
+						Usage: "playback speed multiplier",
+					},
+					cli.DurationFlag{
+						Name:  "idle",
+						Value: 2 * time.Second,
+						Usage: "longest pause between output during playback",
+					},
+					cli.BoolFlag{
+						Name:  "watch",
+						Usage: "attach read-only to a live session",
+					},
+				},
+			},
+			{
+				Name:        "export",
+				Description: "write a recorded session to stdout as an asciicast file",
+				Usage:       "<id>",
+				Action:      cmdSessionExport,
+			},
+		},
+	})
+}
+
+func cmdSessions(c *cli.Context) error {
+	if len(c.Args()) > 0 {
+		return stdcli.ExitError(fmt.Errorf("`convox sessions` does not take arguments. Perhaps you meant `convox sessions play`?"))
+	}
+
+	rc := rackClient(c)
+
+	enabled, err := rc.GetSessionRecording()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	sessions, err := rc.ListSessions()
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if enabled {
+		fmt.Println("Recording: on")
+	} else {
+		fmt.Println("Recording: off")
+	}
+
+	fmt.Println()
+
+	t := stdcli.NewTable("ID", "KIND", "APP", "TARGET", "CLAIMED USER", "STARTED", "DURATION", "EXIT", "COMMAND")
+
+	for _, s := range sessions {
+		duration := "live"
+
+		if !s.Ended.IsZero() {
+			duration = (s.Ended.Sub(s.Started) / time.Second * time.Second).String()
+		}
+
+		exit := ""
+
+		if s.ExitCode != nil {
+			exit = fmt.Sprintf("%d", *s.ExitCode)
+		}
+
+		t.AddRow(s.Id, s.Kind, s.App, s.Target, s.ClaimedUser, humanizeTime(s.Started), duration, exit, s.Command)
+	}
+
+	t.Print()
+	return nil
+}
+
+func cmdSessionsEnable(c *cli.Context) error {
+	return setSessionRecording(c, true)
+}
+
+func cmdSessionsDisable(c *cli.Context) error {
+	return setSessionRecording(c, false)
+}
+
+func setSessionRecording(c *cli.Context, enabled bool) error {
+	if enabled {
+		fmt.Print("Enabling session recording... ")
+	} else {
+		fmt.Print("Disabling session recording... ")
+	}
+
+	err := rackClient(c).SetSessionRecording(enabled)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	fmt.Println("OK")
+	return nil
+}
+
+func cmdSessionPlay(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "play")
+		return nil
+	}
+
+	id := c.Args()[0]
+
+	if c.Bool("watch") {
+		err := rackClient(c).WatchSession(id, os.Stdout)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+
+		return nil
+	}
+
+	if c.Float64("speed") <= 0 {
+		return stdcli.ExitError(fmt.Errorf("--speed must be greater than 0"))
+	}
+
+	var cast bytes.Buffer
+
+	err := rackClient(c).ExportSession(id, &cast)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	err = playCast(&cast, os.Stdout, c.Float64("speed"), c.Duration("idle"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	return nil
+}
+
+func cmdSessionExport(c *cli.Context) error {
+	if len(c.Args()) != 1 {
+		stdcli.Usage(c, "export")
+		return nil
+	}
+
+	err := rackClient(c).ExportSession(c.Args()[0], os.Stdout)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	return nil
+}
+
+// playCast writes the output events of an asciicast v2 recording with their original timing
+func playCast(r io.Reader, w io.Writer, speed float64, idle time.Duration) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	last := 0.0
+	header := true
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// the first line is the recording header
+		if header {
+			header = false
+			continue
+		}
+
+		if line == "" {
+			continue
+		}
+
+		var event []interface{}
+
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return fmt.Errorf("invalid recording: %s", err)
+		}
+
+		if len(event) != 3 || event[1] != "o" {
+			continue
+		}
+
+		at, _ := event[0].(float64)
+		out, _ := event[2].(string)
+
+		wait := time.Duration((at - last) / speed * float64(time.Second))
+
+		if wait > idle {
+			wait = idle
+		}
+
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+
+		last = at
+
+		if _, err := io.WriteString(w, out); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/convox/rack/client"
+	"github.com/convox/rack/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessions(t *testing.T) {
+	started := time.Now().Add(-2 * time.Hour)
+	exit := 0
+
+	ts := testServer(t,
+		test.Http{Method: "GET", Path: "/sessions/recording", Code: 200, Response: map[string]bool{"enabled": true}},
+		test.Http{Method: "GET", Path: "/sessions", Code: 200, Response: client.Sessions{
+			client.Session{Id: "SABCDEFGHIJ", Kind: "exec", App: "myapp", Target: "0123456789ab", Command: "bash", ClaimedUser: "ops@laptop", Started: started, Ended: started.Add(90 * time.Second), ExitCode: &exit},
+		}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox sessions",
+			Exit:    0,
+			Stdout:  "Recording: on\n\nID           KIND  APP    TARGET        CLAIMED USER  STARTED      DURATION  EXIT  COMMAND\nSABCDEFGHIJ  exec  myapp  0123456789ab  ops@laptop    2 hours ago  1m30s     0     bash   \n",
+		},
+	)
+}
+
+func TestSessionsEnable(t *testing.T) {
+	ts := testServer(t,
+		test.Http{Method: "POST", Path: "/sessions/recording", Body: "enabled=true", Code: 200, Response: map[string]bool{"enabled": true}},
+	)
+
+	defer ts.Close()
+
+	test.Runs(t,
+		test.ExecRun{
+			Command: "convox sessions enable",
+			Exit:    0,
+			Stdout:  "Enabling session recording... OK\n",
+		},
+	)
+}
+
+func TestPlayCast(t *testing.T) {
+	cast := strings.Join([]string{
+		`{"version":2,"width":80,"height":24}`,
+		`[0.01,"o","$ "]`,
+		`[0.02,"i","ls\r"]`,
+		`[0.03,"o","ls\r\nDockerfile\r\n"]`,
+	}, "\n")
+
+	var out bytes.Buffer
+
+	err := playCast(strings.NewReader(cast), &out, 10, 1*time.Second)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "$ ls\r\nDockerfile\r\n", out.String())
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -16,7 +17,7 @@ func init() {
 	stdcli.RegisterCommand(cli.Command{
 		Name:        "start",
 		Description: "start an app for local development",
-		Usage:       "[directory]",
+		Usage:       "[directory] [process...]",
 		Action:      cmdStart,
 		Flags: []cli.Flag{
 			cli.StringFlag{
@@ -28,6 +29,10 @@ func init() {
 				Name:  "no-cache",
 				Usage: "pull fresh image dependencies",
 			},
+			cli.BoolFlag{
+				Name:  "rack-services",
+				Usage: "run local stand-ins for the services linked to the app on the rack",
+			},
 			cli.IntFlag{
 				Name:  "shift",
 				Usage: "Shift allocated port numbers by the given amount",
@@ -38,6 +43,20 @@ func init() {
 			},
 		},
 	})
+
+	stdcli.RegisterCommand(cli.Command{
+		Name:        "stop",
+		Description: "stop processes started with convox start",
+		Usage:       "[process...]",
+		Action:      cmdStop,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "file, f",
+				Value: "docker-compose.yml",
+				Usage: "path to an alternate docker compose manifest file",
+			},
+		},
+	})
 }
 
 func cmdStart(c *cli.Context) error {
@@ -62,11 +81,7 @@ func cmdStart(c *cli.Context) error {
 		shift = si
 	}
 
-	wd := "."
-
-	if len(c.Args()) > 0 {
-		wd = c.Args()[0]
-	}
+	wd, processes := startArgs(c.Args(), c.String("file"))
 
 	dir, app, err := stdcli.DirApp(c, wd)
 	if err != nil {
@@ -88,6 +103,29 @@ func cmdStart(c *cli.Context) error {
 		}
 	}
 
+	services, err := manifest.ReadServices(dir)
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if services == nil && c.Bool("rack-services") {
+		services, err = rackServices(c, app)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+	}
+
+	if err := m.AddServices(services); err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if len(processes) > 0 {
+		m, err = m.Subset(processes)
+		if err != nil {
+			return stdcli.ExitError(err)
+		}
+	}
+
 	conflicts, err := m.PortConflicts(shift)
 	if err != nil {
 		return stdcli.QOSEventSend("cli-start", distinctId, stdcli.QOSEventProperties{Error: err})
@@ -123,3 +161,100 @@ func cmdStart(c *cli.Context) error {
 
 	return stdcli.QOSEventSend("cli-start", distinctId, ep)
 }
+
+// rackServices returns the services on the rack that are linked to an app or that have
+// their URL set in its environment
+func rackServices(c *cli.Context, app string) ([]manifest.Service, error) {
+	ss, err := rackClient(c).GetServices()
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := rackClient(c).GetEnvironment(app)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := map[string]bool{}
+
+	for _, value := range env {
+		urls[value] = true
+	}
+
+	services := []manifest.Service{}
+
+	for _, s := range ss {
+		linked := s.Exports["URL"] != "" && urls[s.Exports["URL"]]
+
+		for _, a := range s.Apps {
+			if a.Name == app {
+				linked = true
+			}
+		}
+
+		if linked {
+			services = append(services, manifest.Service{Name: s.Name, Type: s.Type})
+		}
+	}
+
+	return services, nil
+}
+
+func cmdStop(c *cli.Context) error {
+	dir, app, err := stdcli.DirApp(c, ".")
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	m, err := localManifest(dir, c.String("file"))
+	if err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	if err := m.Stop(app, c.Args()); err != nil {
+		return stdcli.ExitError(err)
+	}
+
+	return nil
+}
+
+// startArgs splits the arguments of convox start into a directory and process names. The
+// first argument is a directory if it is one and is not a process in the manifest of the
+// current directory.
+func startArgs(args []string, file string) (string, []string) {
+	if len(args) == 0 {
+		return ".", nil
+	}
+
+	if m, err := manifest.Read(".", file); err == nil {
+		if _, ok := (*m)[args[0]]; ok {
+			return ".", args
+		}
+	}
+
+	if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+		return args[0], args[1:]
+	}
+
+	return ".", args
+}
+
+// localManifest reads the manifest of an app along with the stand-ins for the services
+// declared in .convox/services
+func localManifest(dir, file string) (*manifest.Manifest, error) {
+	m, err := manifest.Read(dir, file)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := manifest.ReadServices(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.AddServices(services); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
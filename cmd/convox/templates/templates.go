@@ -1,5 +1,20 @@
 // Code generated by go-bindata.
 // sources:
+// templates/init/go/.dockerignore
+// templates/init/go/Dockerfile
+// templates/init/go/docker-compose.yml
+// templates/init/java/.dockerignore
+// templates/init/java/Dockerfile
+// templates/init/java/docker-compose.yml
+// templates/init/node/.dockerignore
+// templates/init/node/Dockerfile
+// templates/init/node/docker-compose.yml
+// templates/init/php/.dockerignore
+// templates/init/php/Dockerfile
+// templates/init/php/docker-compose.yml
+// templates/init/python/.dockerignore
+// templates/init/python/Dockerfile
+// templates/init/python/docker-compose.yml
 // templates/init/rails/.dockerignore
 // templates/init/rails/Dockerfile
 // templates/init/rails/docker-compose.yml
@@ -80,6 +95,306 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
+var _initGoDockerignore = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd2\xd7\x4b\xce\xcf\x2b\xcb\xaf\xe0\xd2\xd7\x4b\xcd\x2b\x03\x92\xe9\x99\x25\x5c\xfa\x49\x99\x79\x5c\xfa\x65\xa9\x79\x29\xf9\x45\x5c\x00\x00\x00\x00\xff\xff\x03\x00\x18\x73\x63\x66\x22\x00\x00\x00")
+
+func initGoDockerignoreBytes() ([]byte, error) {
+	return bindataRead(
+		_initGoDockerignore,
+		"init/go/.dockerignore",
+	)
+}
+
+func initGoDockerignore() (*asset, error) {
+	bytes, err := initGoDockerignoreBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/go/.dockerignore", size: 34, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initGoDockerfile = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x3c\xce\x4d\x0b\xc2\x30\x0c\x06\xe0\x7b\x7f\xc5\x0b\xde\x04\x3b\x7a\xf5\xaa\x08\x22\x6e\x52\x10\xf1\xd8\xad\xdd\x56\xe8\x9a\xb1\x0f\x64\x8c\xfd\x77\xd7\x55\x3c\x25\x2f\x21\x4f\x72\x91\xd9\x1d\x15\x39\xe5\xab\xa3\xe0\x42\x30\xf6\xca\xe4\xed\x7c\x95\x48\x54\xdb\x32\xb6\x83\xa6\x8f\x77\xa4\x34\x1a\xd2\xa3\x33\x3d\x72\x53\x52\x67\x50\x50\x3b\x59\x5f\x61\xa8\x0d\x7a\x1a\xbb\x22\x94\x90\x26\xa8\x30\x56\x45\x6d\x34\x3b\x65\x8f\xf7\xea\xf3\xfd\xe6\x25\x4c\x3e\xd3\x35\x06\xeb\x0f\x87\x23\x01\xdb\xa4\xce\xf4\x03\xa8\xdc\xfa\x75\x01\xca\x6b\xe4\xa3\x75\x1a\x76\x88\x18\x8f\x9f\xfd\xa0\x38\x3b\x50\xe4\x73\xeb\x93\x79\x06\x4f\x55\x63\xb0\x2c\xe0\xec\x0b\x00\x00\xff\xff\x03\x00\x42\x1a\x94\x08\xe0\x00\x00\x00")
+
+func initGoDockerfileBytes() ([]byte, error) {
+	return bindataRead(
+		_initGoDockerfile,
+		"init/go/Dockerfile",
+	)
+}
+
+func initGoDockerfile() (*asset, error) {
+	bytes, err := initGoDockerfileBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/go/Dockerfile", size: 224, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initGoDockerComposeYml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x3c\x8c\x4d\x0a\x02\x31\x0c\x85\xf7\x9e\x22\x17\xb0\x16\x9c\x45\x29\xcc\x15\x54\xc4\x0b\xb4\x35\x8b\x42\x9a\x0c\x9d\x3a\x0a\xc3\xdc\xdd\x14\x7f\x76\xf9\xde\xfb\x5e\x9e\x18\xfd\x0e\x20\x3e\x32\xdd\x3d\x18\x3d\x93\x94\x12\x58\x21\x66\x3e\xac\x2b\x98\x53\x28\x08\xdb\xa6\x15\xf2\x92\xab\x70\x41\x6e\x7d\x04\xb0\x87\xcb\xf9\x7a\x1b\x9d\x75\x56\x99\x42\x44\x9a\x7f\x4d\x12\x5e\xe4\x65\x26\xa9\xcd\x0c\xc3\xd1\x4c\x55\x9a\x24\xa1\xb1\xd1\xac\x4a\xcf\xff\xae\xb3\xfe\xfb\xa3\x93\xda\x1f\x7c\x03\x00\x00\xff\xff\x03\x00\x0a\xd0\x4a\xc3\x9d\x00\x00\x00")
+
+func initGoDockerComposeYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_initGoDockerComposeYml,
+		"init/go/docker-compose.yml",
+	)
+}
+
+func initGoDockerComposeYml() (*asset, error) {
+	bytes, err := initGoDockerComposeYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/go/docker-compose.yml", size: 157, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initJavaDockerignore = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd2\xd7\x4b\xce\xcf\x2b\xcb\xaf\xe0\xd2\xd7\x4b\xcd\x2b\x03\x92\xe9\x99\x25\x20\xb2\x28\x31\x25\x27\x95\x4b\x3f\xa9\x34\x33\x27\x85\x4b\xbf\x24\xb1\x28\x3d\xb5\x84\x0b\x00\x00\x00\xff\xff\x03\x00\x6a\x25\x0d\x25\x2d\x00\x00\x00")
+
+func initJavaDockerignoreBytes() ([]byte, error) {
+	return bindataRead(
+		_initJavaDockerignore,
+		"init/java/.dockerignore",
+	)
+}
+
+func initJavaDockerignore() (*asset, error) {
+	bytes, err := initJavaDockerignoreBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/java/.dockerignore", size: 45, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initJavaDockerfile = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x91\x31\x4f\xc3\x30\x14\x84\x77\xff\x8a\x53\x99\x9d\x82\x60\x40\x1d\xa1\x20\x55\x08\x82\x02\x15\x62\x74\xe3\x97\xd4\xc4\xb1\x8d\x9d\x56\xad\xa2\xfc\x77\xec\xb4\xa8\x95\xd8\x98\xfc\x4e\xf6\xfb\xee\x74\xee\x7b\x0e\x55\x81\xbe\x91\x2d\x4c\xe8\x84\xd6\xe4\x31\x69\xc5\x96\xcc\x04\x7c\x18\xd8\x63\x91\x3f\x63\xd4\xb3\x6b\xfe\x25\x1b\x7e\xcb\xd8\x47\x5e\x3c\xcd\x17\x05\xa6\xc2\x39\xc6\x2e\xe0\x29\x58\xbd\x25\x48\x72\x64\x24\x99\x52\x51\xc0\x8a\x2a\xeb\x09\xa5\x75\x7b\x65\x6a\x74\x6b\x42\xb0\x1b\x5f\xa6\x23\xa9\x3d\x44\xba\x16\xe5\x9a\x24\xbb\xcf\x5f\x3f\xe1\x6c\x9b\xed\x5a\x3d\x62\xa7\x47\xc1\x8a\xe5\x0b\xda\xad\x39\xb1\xf7\xb3\xda\x72\x5b\x55\x5a\x19\x02\xbf\x4b\xfe\xc9\x63\x34\x88\x41\x3a\xd8\x6a\x9c\x23\x04\xc2\x48\xac\x36\x4a\x4b\xa8\xee\xe0\x91\x1d\x42\xff\x52\x9d\x28\x1b\x51\x27\x0e\xf8\x3c\x34\xca\xbd\x47\x42\x60\x7d\x6c\x85\x74\xa0\x53\x03\xb5\x17\x52\xd3\xec\x26\xbb\xba\x4c\x2d\xc4\x12\x96\x6f\x0f\x05\xbc\xb5\xdd\x9f\x3a\xfe\x17\xa7\xef\xcf\xbf\x60\x18\x8e\x2f\xf9\x0e\x5d\xc4\x1c\x22\xc5\xfd\x18\xe8\x07\x00\x00\xff\xff\x03\x00\xb4\xe2\x33\xc5\xb4\x01\x00\x00")
+
+func initJavaDockerfileBytes() ([]byte, error) {
+	return bindataRead(
+		_initJavaDockerfile,
+		"init/java/Dockerfile",
+	)
+}
+
+func initJavaDockerfile() (*asset, error) {
+	bytes, err := initJavaDockerfileBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/java/Dockerfile", size: 436, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initJavaDockerComposeYml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x84\x8e\x41\x0e\x82\x30\x10\x45\xf7\x9c\x62\xd2\xa5\x49\x0b\x89\x2c\x08\x09\x07\x70\xa5\x31\x5e\xa0\x2d\xa3\x96\x94\x16\xdb\x5a\x4d\x08\x77\xb7\x80\x9a\xb8\x72\xf9\x66\xde\xff\x33\x0f\x14\x75\x06\x20\xee\x4a\xb7\x35\xb0\x6c\x1c\x29\xa8\x33\xe0\x0d\xd8\xce\xf8\xc0\xb5\x46\x07\xa4\xe7\x11\x0d\x81\x69\x4a\xaa\xb4\x7d\xcf\x4d\x92\xfd\x15\xa8\x04\xd2\xf1\xc8\x81\x76\xdc\x41\xe0\xee\x82\x21\xdf\xb0\x04\x64\x69\x42\xed\xf1\x5f\x6a\x39\x9d\x6b\x25\xfc\x4f\xd2\xb4\x6b\x10\x4d\x54\xce\x9a\x1e\x4d\x98\x1f\x05\xa0\x70\xd8\x1f\x4f\x4d\x55\x54\x45\x62\xcd\x45\x3a\xf2\xd9\x48\x6b\xa2\x7d\xb2\xc1\xba\xc0\xca\x72\xcb\x06\x67\x83\x95\x56\x37\x41\xfb\xa4\xcc\xf3\xaf\x5b\x15\xf5\xbb\x63\xa6\x64\xaf\xf8\x02\x00\x00\xff\xff\x03\x00\xd9\xea\x07\x4a\x11\x01\x00\x00")
+
+func initJavaDockerComposeYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_initJavaDockerComposeYml,
+		"init/java/docker-compose.yml",
+	)
+}
+
+func initJavaDockerComposeYml() (*asset, error) {
+	bytes, err := initJavaDockerComposeYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/java/docker-compose.yml", size: 273, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initNodeDockerignore = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd2\xd7\x4b\xce\xcf\x2b\xcb\xaf\xe0\xd2\xd7\x4b\xcd\x2b\x03\x92\xe9\x99\x25\x5c\xfa\x79\xf9\x29\xa9\xf1\xb9\xf9\x29\xa5\x39\xa9\xc5\x5c\x00\x00\x00\x00\xff\xff\x03\x00\xc2\x12\x21\xea\x23\x00\x00\x00")
+
+func initNodeDockerignoreBytes() ([]byte, error) {
+	return bindataRead(
+		_initNodeDockerignore,
+		"init/node/.dockerignore",
+	)
+}
+
+func initNodeDockerignore() (*asset, error) {
+	bytes, err := initNodeDockerignoreBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/node/.dockerignore", size: 35, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initNodeDockerfile = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x4c\x90\xc1\x0a\xc2\x30\x10\x44\xef\xf9\x8a\x41\xcf\xad\x37\x0f\x5e\x15\x41\x44\x2b\x05\x11\x8f\xa1\x99\x6a\x35\x6e\x62\x53\x0f\x45\xfc\x77\x4d\x8a\x62\x4e\x9b\x9d\xe5\xed\xcc\x2e\xcb\x62\x03\x71\x86\xb3\xa9\x52\x87\xa2\x5c\x2f\x56\x25\x26\xda\x7b\xa5\xc6\xa8\x9c\xef\xe1\xc4\xf6\xe8\xce\x44\xdd\x58\x06\x08\x69\x68\xd0\x39\x34\x12\x3a\x6d\x2d\x0c\x3d\xc5\x50\xaa\x86\x41\xcd\x8b\xdd\x11\x5e\x57\x57\x7d\x62\x7e\x09\x4e\x12\x6c\xf2\xdf\x51\xcf\x67\x86\xa6\x06\xef\xc8\x57\x03\x83\x2d\x46\xbd\x6e\x65\x84\xd7\x6b\x40\xc4\x5f\x6e\x5d\x75\xc5\xe7\x25\xc4\xaf\xa3\xca\xfd\x36\xe9\x3f\x07\x59\xe6\x1f\x2d\xb3\x28\x46\x93\x69\x01\x6d\x60\xa4\xc5\x61\xf1\xb7\xef\xec\xa0\x89\x89\xd2\x37\x61\x0c\xd7\x32\x74\x70\x75\xaa\x63\xfa\x64\x22\x1f\x2e\xf1\x06\x00\x00\xff\xff\x03\x00\xe5\x7f\xc5\x8b\x24\x01\x00\x00")
+
+func initNodeDockerfileBytes() ([]byte, error) {
+	return bindataRead(
+		_initNodeDockerfile,
+		"init/node/Dockerfile",
+	)
+}
+
+func initNodeDockerfile() (*asset, error) {
+	bytes, err := initNodeDockerfileBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/node/Dockerfile", size: 292, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initNodeDockerComposeYml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x3c\x8c\x41\x0a\xc2\x30\x10\x45\xf7\x9e\x62\x2e\x60\x08\xb4\x0b\x09\xf4\x00\xae\x14\xf1\x02\x49\x9a\x45\x61\x32\x53\x92\xb1\x15\x4a\xef\xee\x04\xab\xbb\xff\xfe\xbc\xf9\x6b\x0a\xee\x04\x10\x5e\x13\x8e\x0e\x8c\xc6\xc8\x39\x7b\x52\xd8\x36\x30\x57\xaa\xe2\x11\x53\x81\x7d\x07\x8d\x45\xd4\x48\xb4\x4c\x85\x29\x27\x92\xf6\x0b\x70\x86\xfb\xed\xf1\x1c\x3a\x6b\xad\x32\xfa\x90\xb0\xfe\x2e\x91\x69\xe1\xb7\x99\xb9\x88\xe9\xfb\xce\xcc\x85\x85\x23\xe3\x20\x58\x55\x69\xfd\xdf\xbd\x58\x77\x6c\x34\x52\xfb\x8b\x1f\x00\x00\x00\xff\xff\x03\x00\xc2\x94\x58\xa6\xa4\x00\x00\x00")
+
+func initNodeDockerComposeYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_initNodeDockerComposeYml,
+		"init/node/docker-compose.yml",
+	)
+}
+
+func initNodeDockerComposeYml() (*asset, error) {
+	bytes, err := initNodeDockerComposeYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/node/docker-compose.yml", size: 164, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initPhpDockerignore = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd2\xd7\x4b\xce\xcf\x2b\xcb\xaf\xe0\xd2\xd7\x4b\xcd\x2b\x03\x92\xe9\x99\x25\x5c\xfa\x65\xa9\x79\x29\xf9\x45\x5c\x00\x00\x00\x00\xff\xff\x03\x00\xb4\x2d\x89\x77\x1d\x00\x00\x00")
+
+func initPhpDockerignoreBytes() ([]byte, error) {
+	return bindataRead(
+		_initPhpDockerignore,
+		"init/php/.dockerignore",
+	)
+}
+
+func initPhpDockerignore() (*asset, error) {
+	bytes, err := initPhpDockerignoreBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/php/.dockerignore", size: 29, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initPhpDockerfile = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x5c\x90\x4d\x4b\x03\x31\x10\x86\xef\xf9\x15\x03\x42\x0f\x85\x34\x78\x12\x0a\x3d\x29\x42\x11\xad\xac\x88\x78\x4c\x93\xd9\x6e\x34\x9b\x0c\xc9\x6c\x4b\x8b\x3f\xde\x6c\x77\xdd\x52\x21\x30\xdf\xef\x3c\x93\xc7\x6a\xf3\x0c\xd4\xd0\xf2\x6e\x71\x2b\x35\x69\xd3\xa0\x10\xd5\xfb\x0b\x68\x62\xb9\x43\x86\x8e\xac\x66\x84\xd9\x6c\xca\xb8\x90\x59\x7b\x0f\xf2\x08\x3b\x57\x1a\xc2\xc9\x51\x5f\x4f\x2d\xc8\x54\x83\xda\xeb\xa4\xbc\xdb\xaa\xd2\x5f\x6c\xe6\xac\xe6\x67\x45\xd3\xa5\x32\x94\xdf\xa0\x61\xa6\xbc\x54\xaa\x88\x99\xd8\x52\xcc\x98\x16\x31\xed\xd4\x28\x8c\x09\x7e\x7a\x24\x90\xb2\xbc\x31\x29\xad\x4b\x2b\xd5\xe5\x22\x1d\x8d\xf6\x6a\xeb\x42\x29\xd6\xce\x63\xd0\x2d\xae\xfe\x74\x84\xf8\xd8\x54\x4f\x0f\xeb\x6a\xc0\x38\x1c\x0e\xaa\xe1\xd6\x0b\x71\x03\x26\xd2\x11\x62\xf0\x47\xe0\x06\xa1\x9f\xcc\x10\x10\x2d\x5a\xe0\x38\x1d\x65\x91\x30\x58\x0c\xc6\x61\x16\xf7\x9b\xd7\x4f\x98\x10\xbf\x72\x0c\x97\xa8\x60\x7c\xcf\xaf\xb7\xa8\xe1\xcc\xb1\xe3\xf2\x4f\x32\x44\x69\x71\x3f\x38\xd9\x24\x47\x9c\x87\x40\x77\x1c\x7d\xd4\xb6\x07\x1f\x09\x7b\xb8\x84\x99\x21\xd6\x67\x5f\x13\x0d\x1c\x8b\x7f\x27\x5d\xed\xb2\x5d\x4b\x93\x5a\xd1\x8e\xc4\xae\x75\x27\x14\xbf\x00\x00\x00\xff\xff\x03\x00\x6f\xe9\xe3\xa7\xe0\x01\x00\x00")
+
+func initPhpDockerfileBytes() ([]byte, error) {
+	return bindataRead(
+		_initPhpDockerfile,
+		"init/php/Dockerfile",
+	)
+}
+
+func initPhpDockerfile() (*asset, error) {
+	bytes, err := initPhpDockerfileBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/php/Dockerfile", size: 480, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initPhpDockerComposeYml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x2a\x4f\x4d\xb2\xe2\x52\x50\x48\x2a\xcd\xcc\x49\xb1\x52\xd0\x03\x32\x73\x12\x93\x52\x73\x8a\x41\x82\x0a\x0a\xba\x0a\xc9\xf9\x79\x65\xf9\x15\x7a\x05\xf9\x45\x25\x7a\x26\x26\xc6\x7a\x05\x45\xf9\x25\xf9\xc9\xf9\x39\xb6\x25\x39\xc5\x40\x25\x20\x71\xb8\x5a\x0b\x03\x2b\x0b\x03\x28\x1b\xa8\x16\xc4\x01\x00\x00\x00\xff\xff\x03\x00\x19\x82\x1d\x31\x5f\x00\x00\x00")
+
+func initPhpDockerComposeYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_initPhpDockerComposeYml,
+		"init/php/docker-compose.yml",
+	)
+}
+
+func initPhpDockerComposeYml() (*asset, error) {
+	bytes, err := initPhpDockerComposeYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/php/docker-compose.yml", size: 95, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initPythonDockerignore = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd2\xd7\x4b\xce\xcf\x2b\xcb\xaf\xe0\xd2\xd7\x4b\xcd\x2b\x03\x92\xe9\x99\x25\x5c\x5a\x7a\x05\x95\xc9\x5c\xf1\xf1\x40\x32\x31\x39\x23\x35\x3e\x9e\x0b\x00\x00\x00\xff\xff\x03\x00\x1b\x08\x34\xc8\x27\x00\x00\x00")
+
+func initPythonDockerignoreBytes() ([]byte, error) {
+	return bindataRead(
+		_initPythonDockerignore,
+		"init/python/.dockerignore",
+	)
+}
+
+func initPythonDockerignore() (*asset, error) {
+	bytes, err := initPythonDockerignoreBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/python/.dockerignore", size: 39, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initPythonDockerfile = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x64\x90\x41\x8b\xc2\x30\x14\x84\xef\xf9\x15\x83\x82\x87\x85\xd6\x83\xb0\x07\xaf\x2b\x82\xc8\x6e\xa5\xb0\x88\x47\x31\x53\x0c\xc4\x24\x26\x4f\xd9\x22\xfe\xf7\xb5\x0d\xf5\xa0\xb7\xe1\xbd\x79\xdf\xf0\x66\x59\x57\xdf\x08\xad\x1c\xbd\x9b\xcf\xca\x4f\xa5\xb6\x55\xbd\x5e\xac\x6a\x4c\xf7\x21\x28\x35\xc6\xc1\x87\x16\xde\xd9\x16\x72\x24\x1a\x63\x99\xe0\x48\x4d\x0d\xf1\x30\x2e\xc9\xde\x5a\x68\x06\x3a\x4d\x77\x30\x4c\xea\x76\x2b\x60\x1a\xf0\x8c\x72\x95\xf7\x8c\x18\x05\xf3\xb0\x5c\x47\xb8\xdf\xd5\x57\xb5\xd9\x61\x63\x42\x47\xfb\xe8\x93\xa6\xaa\xfe\xfd\xc1\xc3\xf2\x24\x66\x3b\x26\x93\x41\x0d\x8b\xa2\x48\x6d\x12\x9e\xfa\x18\xda\xc4\x27\x31\xf2\x7c\x31\x91\x27\x3a\x49\xa5\xfc\x49\x26\xbf\x4e\xdf\x92\x8a\xf8\x76\x99\xd9\x4e\x77\xe8\xa1\x83\xee\xfd\xc8\x24\xf0\x4d\xaf\xbb\x7e\xfa\xd8\x32\x77\xf5\x0f\x00\x00\xff\xff\x03\x00\xf3\x16\x80\xc2\x4a\x01\x00\x00")
+
+func initPythonDockerfileBytes() ([]byte, error) {
+	return bindataRead(
+		_initPythonDockerfile,
+		"init/python/Dockerfile",
+	)
+}
+
+func initPythonDockerfile() (*asset, error) {
+	bytes, err := initPythonDockerfileBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/python/Dockerfile", size: 330, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
+var _initPythonDockerComposeYml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x74\x90\x4d\x4f\x83\x40\x10\x86\xef\xfd\x15\x13\xee\x6c\x30\xf6\xd0\x90\x70\xa8\xb1\xc4\x44\x6d\x49\x6d\x0f\x9e\xcc\x02\xd3\x82\x2c\x3b\xb8\xbb\xb4\x12\xd2\xff\xee\xf2\x61\x23\x46\xb3\xa7\x77\xe6\x99\x27\x3b\x73\xc6\xd8\x9f\x01\xc4\x75\x2e\x52\x1f\xd8\xac\x6d\x5d\xc8\x0f\x80\x1f\xc0\x42\xc5\x4b\x3c\x93\x2a\xc0\x49\xdf\xb9\x3c\x92\x03\x97\x8b\x65\x13\x2a\x4b\x2e\x2d\x5d\x35\x26\x23\x09\x36\xf0\x23\xb2\xaa\x01\x55\x4b\x8d\xea\x84\x0a\x3c\xd6\x3f\x7f\xe1\x79\x5e\xef\x44\xa1\xf1\x0f\xf1\x41\x70\x5d\xfc\xf6\xf6\xc5\x4e\x06\xae\x9b\x91\x36\xc1\x68\xb3\xb1\x22\x65\x82\xa9\x74\x3a\x8b\x49\x46\xe0\x60\x9a\x1b\x48\x29\x29\x50\xb9\xb6\x55\x91\x46\xd6\x94\x02\xce\xb9\xc9\xa0\xa1\x5a\x81\x36\x5c\x99\xba\xfa\x1e\x74\x06\x9f\x4c\x07\x1d\xca\x53\xae\x48\x96\x28\x8d\xff\xcf\x49\x7e\xfe\x1c\xc0\x85\xf0\x69\xf9\xf2\xf8\xb6\x8c\xa2\xa0\x6d\x81\x3d\xf3\x5c\x76\xbd\x89\xb5\xc3\xa2\xcd\x76\x37\x2c\x30\xe6\xd7\xdd\xc3\x66\xbd\x5f\xdf\xed\xc3\x70\xb5\x5d\xdd\x07\x37\xb6\x21\x78\x6c\x57\xf3\x47\x24\x21\x79\xa2\x4f\xd6\xad\xce\xe6\xf3\x5b\x56\x29\x32\x94\x90\x08\x8c\xd0\x16\xe9\xea\x57\x76\x31\x9e\x7c\x48\x96\x1e\xe2\x17\x00\x00\x00\xff\xff\x03\x00\x22\xe5\x0f\xe5\xe6\x01\x00\x00")
+
+func initPythonDockerComposeYmlBytes() ([]byte, error) {
+	return bindataRead(
+		_initPythonDockerComposeYml,
+		"init/python/docker-compose.yml",
+	)
+}
+
+func initPythonDockerComposeYml() (*asset, error) {
+	bytes, err := initPythonDockerComposeYmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "init/python/docker-compose.yml", size: 486, mode: os.FileMode(420), modTime: time.Unix(1792397268, 0)}
+	a := &asset{bytes: bytes, info:  info}
+	return a, nil
+}
+
 var _initRailsDockerignore = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd2\xd7\x4b\x2a\xcd\x4b\xc9\x49\xe5\xd2\xd7\x4b\xce\xcf\x2b\xcb\xaf\x00\x32\x52\xf3\xca\x80\x64\x7a\x66\x09\x97\x7e\x4a\x92\xbe\x96\x5e\x71\x61\x4e\x66\x49\xaa\x31\x2a\x4f\x37\x2b\xbf\xb4\x28\x2f\x31\x87\x4b\x3f\x27\x3f\x5d\x5f\x8b\x4b\xbf\x24\xb7\x80\x0b\x10\x00\x00\xff\xff\xa0\x04\x95\x56\x4e\x00\x00\x00")
 
 func initRailsDockerignoreBytes() ([]byte, error) {
@@ -392,6 +707,21 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"init/go/.dockerignore": initGoDockerignore,
+	"init/go/Dockerfile": initGoDockerfile,
+	"init/go/docker-compose.yml": initGoDockerComposeYml,
+	"init/java/.dockerignore": initJavaDockerignore,
+	"init/java/Dockerfile": initJavaDockerfile,
+	"init/java/docker-compose.yml": initJavaDockerComposeYml,
+	"init/node/.dockerignore": initNodeDockerignore,
+	"init/node/Dockerfile": initNodeDockerfile,
+	"init/node/docker-compose.yml": initNodeDockerComposeYml,
+	"init/php/.dockerignore": initPhpDockerignore,
+	"init/php/Dockerfile": initPhpDockerfile,
+	"init/php/docker-compose.yml": initPhpDockerComposeYml,
+	"init/python/.dockerignore": initPythonDockerignore,
+	"init/python/Dockerfile": initPythonDockerfile,
+	"init/python/docker-compose.yml": initPythonDockerComposeYml,
 	"init/rails/.dockerignore": initRailsDockerignore,
 	"init/rails/Dockerfile": initRailsDockerfile,
 	"init/rails/docker-compose.yml": initRailsDockerComposeYml,
@@ -448,6 +778,46 @@ type bintree struct {
 }
 var _bintree = &bintree{nil, map[string]*bintree{
 	"init": &bintree{nil, map[string]*bintree{
+		"go": &bintree{nil, map[string]*bintree{
+			".dockerignore": &bintree{initGoDockerignore, map[string]*bintree{
+			}},
+			"Dockerfile": &bintree{initGoDockerfile, map[string]*bintree{
+			}},
+			"docker-compose.yml": &bintree{initGoDockerComposeYml, map[string]*bintree{
+			}},
+		}},
+		"java": &bintree{nil, map[string]*bintree{
+			".dockerignore": &bintree{initJavaDockerignore, map[string]*bintree{
+			}},
+			"Dockerfile": &bintree{initJavaDockerfile, map[string]*bintree{
+			}},
+			"docker-compose.yml": &bintree{initJavaDockerComposeYml, map[string]*bintree{
+			}},
+		}},
+		"node": &bintree{nil, map[string]*bintree{
+			".dockerignore": &bintree{initNodeDockerignore, map[string]*bintree{
+			}},
+			"Dockerfile": &bintree{initNodeDockerfile, map[string]*bintree{
+			}},
+			"docker-compose.yml": &bintree{initNodeDockerComposeYml, map[string]*bintree{
+			}},
+		}},
+		"php": &bintree{nil, map[string]*bintree{
+			".dockerignore": &bintree{initPhpDockerignore, map[string]*bintree{
+			}},
+			"Dockerfile": &bintree{initPhpDockerfile, map[string]*bintree{
+			}},
+			"docker-compose.yml": &bintree{initPhpDockerComposeYml, map[string]*bintree{
+			}},
+		}},
+		"python": &bintree{nil, map[string]*bintree{
+			".dockerignore": &bintree{initPythonDockerignore, map[string]*bintree{
+			}},
+			"Dockerfile": &bintree{initPythonDockerfile, map[string]*bintree{
+			}},
+			"docker-compose.yml": &bintree{initPythonDockerComposeYml, map[string]*bintree{
+			}},
+		}},
 		"rails": &bintree{nil, map[string]*bintree{
 			".dockerignore": &bintree{initRailsDockerignore, map[string]*bintree{
 			}},
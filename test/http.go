@@ -11,8 +11,12 @@ import (
 )
 
 type Http struct {
-	Method   string
-	Path     string
+	Method string
+	Path   string
+
+	// Query limits the stub to requests with this exact query string when set
+	Query string
+
 	Code     int
 	Body     string
 	Response interface{}
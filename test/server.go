@@ -17,7 +17,7 @@ func Server(t *testing.T, stubs ...Http) *httptest.Server {
 		found := false
 
 		for _, stub := range stubs {
-			if stub.Method == r.Method && stub.Path == r.URL.Path {
+			if stub.Method == r.Method && stub.Path == r.URL.Path && (stub.Query == "" || stub.Query == r.URL.RawQuery) {
 				data, err := json.Marshal(stub.Response)
 
 				if err != nil {